@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+// defaultRetention is how long archived rows are kept before purge removes them
+const defaultRetention = 30 * 24 * time.Hour
+
+func formsArchiveCmds() []*cobra.Command {
+	archive := &cobra.Command{
+		Use:   "archive <form-id>",
+		Short: "Archive a form so it stops receiving submissions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			if err := db.ArchiveForm(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("🗄  Archived form %s\n", args[0])
+			return nil
+		},
+	}
+
+	restore := &cobra.Command{
+		Use:   "restore <form-id>",
+		Short: "Restore an archived form",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			if err := db.UnarchiveForm(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Restored form %s\n", args[0])
+			return nil
+		},
+	}
+
+	var (
+		olderThan time.Duration
+		dryRun    bool
+	)
+	purge := &cobra.Command{
+		Use:   "purge",
+		Short: "Permanently delete forms archived longer than the retention window",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				forms, err := db.GetArchivedForms()
+				if err != nil {
+					return err
+				}
+				cutoff := time.Now().Add(-olderThan)
+				for _, form := range forms {
+					if form.ArchivedAt != nil && !form.ArchivedAt.After(cutoff) {
+						fmt.Printf("would purge %s (archived %s)\n", form.ID, form.ArchivedAt.Format("2006-01-02"))
+					}
+				}
+				return nil
+			}
+
+			purged, err := db.PurgeArchivedForms(olderThan)
+			if err != nil {
+				return err
+			}
+			for _, id := range purged {
+				fmt.Printf("🗑  Purged form %s\n", id)
+			}
+			fmt.Printf("%d form(s) purged\n", len(purged))
+			return nil
+		},
+	}
+	purge.Flags().DurationVar(&olderThan, "older-than", defaultRetention, "only purge forms archived at least this long ago")
+	purge.Flags().BoolVar(&dryRun, "dry-run", false, "list the forms that would be purged without deleting them")
+
+	return []*cobra.Command{archive, restore, purge}
+}
+
+func contactsArchiveCmds() []*cobra.Command {
+	archive := &cobra.Command{
+		Use:   "archive <contact-id>",
+		Short: "Archive a contact so forms stop notifying it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid contact ID %q", args[0])
+			}
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			if err := db.ArchiveContact(id); err != nil {
+				return err
+			}
+			fmt.Printf("🗄  Archived contact %d\n", id)
+			return nil
+		},
+	}
+
+	restore := &cobra.Command{
+		Use:   "restore <contact-id>",
+		Short: "Restore an archived contact",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid contact ID %q", args[0])
+			}
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			if err := db.UnarchiveContact(id); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Restored contact %d\n", id)
+			return nil
+		},
+	}
+
+	var (
+		olderThan time.Duration
+		dryRun    bool
+	)
+	purge := &cobra.Command{
+		Use:   "purge",
+		Short: "Permanently delete contacts archived longer than the retention window",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				contacts, err := db.GetArchivedContacts()
+				if err != nil {
+					return err
+				}
+				cutoff := time.Now().Add(-olderThan)
+				for _, contact := range contacts {
+					if contact.ArchivedAt != nil && !contact.ArchivedAt.After(cutoff) {
+						fmt.Printf("would purge #%d %s (archived %s)\n", contact.ID, contact.Name, contact.ArchivedAt.Format("2006-01-02"))
+					}
+				}
+				return nil
+			}
+
+			purged, err := db.PurgeArchivedContacts(olderThan)
+			if err != nil {
+				return err
+			}
+			for _, id := range purged {
+				fmt.Printf("🗑  Purged contact %d\n", id)
+			}
+			fmt.Printf("%d contact(s) purged\n", len(purged))
+			return nil
+		},
+	}
+	purge.Flags().DurationVar(&olderThan, "older-than", defaultRetention, "only purge contacts archived at least this long ago")
+	purge.Flags().BoolVar(&dryRun, "dry-run", false, "list the contacts that would be purged without deleting them")
+
+	return []*cobra.Command{archive, restore, purge}
+}
+
+// openDatabase loads the config and connects to D1
+func openDatabase() (*database.Client, error) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+	return database.NewClient(cfg)
+}
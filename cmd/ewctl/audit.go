@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+func auditCmd() *cobra.Command {
+	var (
+		filter    database.AuditFilter
+		formID    string
+		contactID int
+		since     time.Duration
+		asJSON    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show who changed forms and contacts, and what changed",
+		Long: `Lists audit entries newest first. Every change made through ewctl
+records the actor, the config profile, the action and the entity's state
+before and after.`,
+		Example: `  ewctl audit --form contact-form
+  ewctl audit --actor alice --since 24h
+  ewctl audit --action delete --json | jq .`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if formID != "" && contactID != 0 {
+				return fmt.Errorf("use either --form or --contact, not both")
+			}
+			if formID != "" {
+				filter.EntityType = database.AuditForm
+				filter.EntityID = formID
+			}
+			if contactID != 0 {
+				filter.EntityType = database.AuditContact
+				filter.EntityID = strconv.Itoa(contactID)
+			}
+			if since > 0 {
+				filter.Since = time.Now().Add(-since)
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			entries, err := db.GetAuditEntries(filter)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(entries)
+			}
+
+			if len(entries) == 0 {
+				fmt.Println("No audit entries found")
+				return nil
+			}
+			for _, entry := range entries {
+				printAuditEntry(entry)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&formID, "form", "", "only show changes to this form")
+	cmd.Flags().IntVar(&contactID, "contact", 0, "only show changes to this contact ID")
+	cmd.Flags().StringVar(&filter.EntityType, "entity", "", "only show this entity type (form or contact)")
+	cmd.Flags().StringVar(&filter.Actor, "actor", "", "only show changes made by this actor")
+	cmd.Flags().StringVar(&filter.Action, "action", "", "only show this action (create, update, delete, import, archive, ...)")
+	cmd.Flags().DurationVar(&since, "since", 0, "only show changes from this long ago (e.g. 24h)")
+	cmd.Flags().IntVar(&filter.Limit, "limit", 50, "maximum number of entries (0 for all)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print entries as JSON")
+
+	return cmd
+}
+
+func printAuditEntry(entry database.AuditEntry) {
+	fmt.Printf("%s  %s@%s  %s %s %s\n",
+		entry.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+		entry.Actor, entry.Profile,
+		entry.Action, entry.EntityType, entry.EntityID,
+	)
+	for _, change := range entry.Changes() {
+		if change.Added != nil || change.Removed != nil {
+			for _, item := range change.Removed {
+				fmt.Printf("    %s: - %s\n", change.Field, item)
+			}
+			for _, item := range change.Added {
+				fmt.Printf("    %s: + %s\n", change.Field, item)
+			}
+			continue
+		}
+		fmt.Printf("    %s: %q → %q\n", change.Field, change.Before, change.After)
+	}
+}
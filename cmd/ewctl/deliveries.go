@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/replay"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+func deliveriesCmd() *cobra.Command {
+	var (
+		filter database.DeliveryFilter
+		since  time.Duration
+		asJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "deliveries",
+		Short: "Inspect and resend failed WhatsApp deliveries",
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List failed and partial deliveries",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if since > 0 {
+				filter.Since = time.Now().Add(-since)
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			deliveries, err := db.GetFailedDeliveries(filter)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(deliveries)
+			}
+
+			if len(deliveries) == 0 {
+				fmt.Println("No failed deliveries")
+				return nil
+			}
+			for _, d := range deliveries {
+				var failed []string
+				for _, r := range d.Failed() {
+					failed = append(failed, r.Phone)
+				}
+				fmt.Printf("#%-6d %s  %-20s %-8s failed: %s\n",
+					d.ID,
+					d.CreatedAt.Local().Format("2006-01-02 15:04"),
+					d.FormID, d.Status,
+					strings.Join(failed, ", "),
+				)
+			}
+			return nil
+		},
+	}
+	list.Flags().StringVar(&filter.FormID, "form", "", "only show deliveries of this form")
+	list.Flags().DurationVar(&since, "since", 0, "only show deliveries from this long ago (e.g. 2h)")
+	list.Flags().IntVar(&filter.Limit, "limit", 50, "maximum number of deliveries (0 for all)")
+	list.Flags().BoolVar(&asJSON, "json", false, "print deliveries as JSON")
+
+	var (
+		retryFilter database.DeliveryFilter
+		retrySince  time.Duration
+		opts        replay.Options
+	)
+
+	retry := &cobra.Command{
+		Use:   "retry",
+		Short: "Resend failed deliveries through Z-API",
+		Long: `Resends the stored message of failed and partial deliveries. By default
+only the recipients that failed are retried; --current-recipients sends to
+the form's current numbers instead. Recipients that already received a
+delivery are never sent it again, even across concurrent retries.`,
+		Example: `  ewctl deliveries retry --since 2h --form contact-form
+  ewctl deliveries retry --id 42 --id 43 --current-recipients
+  ewctl deliveries retry --since 24h --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if retrySince == 0 && retryFilter.FormID == "" && len(retryFilter.IDs) == 0 {
+				return fmt.Errorf("specify --since, --form or --id to choose what to retry")
+			}
+			if retrySince > 0 {
+				retryFilter.Since = time.Now().Add(-retrySince)
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return err
+			}
+			db, err := database.NewClient(cfg)
+			if err != nil {
+				return err
+			}
+			sender := zapi.NewClient(cfg.ZAPI.InstanceID, cfg.ZAPI.InstanceToken, cfg.ZAPI.ClientToken).WithBaseURL(cfg.ZAPI.BaseURL)
+			if !sender.Configured() && !opts.DryRun {
+				return fmt.Errorf("z-api credentials are not configured (zapi.instance_id and zapi.instance_token)")
+			}
+
+			deliveries, err := db.GetFailedDeliveries(retryFilter)
+			if err != nil {
+				return err
+			}
+			if len(deliveries) == 0 {
+				fmt.Println("No failed deliveries to retry")
+				return nil
+			}
+
+			var sent, failed, skipped int
+			for _, d := range deliveries {
+				outcomes, err := replay.Resend(db, sender, d, opts)
+				if err != nil {
+					fmt.Printf("#%d: %v\n", d.ID, err)
+					failed++
+					continue
+				}
+				for _, o := range outcomes {
+					switch {
+					case o.Err != nil:
+						failed++
+						fmt.Printf("#%d %s: failed: %v\n", o.DeliveryID, o.Phone, o.Err)
+					case o.Skipped != "":
+						skipped++
+						fmt.Printf("#%d %s: skipped (%s)\n", o.DeliveryID, o.Phone, o.Skipped)
+					case o.RecordErr != nil:
+						sent++
+						fmt.Printf("#%d %s: sent, but not recorded: %v\n", o.DeliveryID, o.Phone, o.RecordErr)
+					default:
+						sent++
+						fmt.Printf("#%d %s: sent\n", o.DeliveryID, o.Phone)
+					}
+				}
+			}
+
+			fmt.Printf("\n%d sent, %d failed, %d skipped\n", sent, failed, skipped)
+			if failed > 0 {
+				return fmt.Errorf("%d resends failed", failed)
+			}
+			return nil
+		},
+	}
+	retry.Flags().StringVar(&retryFilter.FormID, "form", "", "only retry deliveries of this form")
+	retry.Flags().DurationVar(&retrySince, "since", 0, "only retry deliveries from this long ago (e.g. 2h)")
+	retry.Flags().IntSliceVar(&retryFilter.IDs, "id", nil, "retry these delivery IDs")
+	retry.Flags().BoolVar(&opts.CurrentRecipients, "current-recipients", false, "send to the form's current numbers instead of the original recipients")
+	retry.Flags().BoolVar(&opts.DryRun, "dry-run", false, "show who would be sent to without sending")
+
+	cmd.AddCommand(list, retry)
+	return cmd
+}
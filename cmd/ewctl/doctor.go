@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/huh"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/doctor"
+)
+
+func doctorCmd() *cobra.Command {
+	var (
+		fix    bool
+		yes    bool
+		checks []string
+		list   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Audit and repair referential integrity",
+		Long: `Runs a suite of named checks against the D1 database and reports
+every inconsistency found. With --fix, each failing check applies its
+automatic repair and is re-run to confirm the result. Repairs with effects
+outside the database, such as renaming a form, are confirmed first; pass
+--yes to apply them without asking.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if list {
+				for _, check := range doctor.Checks() {
+					fmt.Printf("%-26s %s\n", check.Name, check.Description)
+					fmt.Printf("%-26s fix: %s\n", "", check.FixDescription)
+				}
+				return nil
+			}
+
+			for _, name := range checks {
+				if _, ok := doctor.Find(name); !ok {
+					return fmt.Errorf("unknown check %q (see ewctl doctor --list)", name)
+				}
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return err
+			}
+			db, err := database.NewClient(cfg)
+			if err != nil {
+				return err
+			}
+
+			problems := 0
+			for _, result := range doctor.Run(db, checks...) {
+				if fix && !result.OK() && result.Err == nil && result.Check.Fix != nil && confirmDoctorFix(result.Check, yes) {
+					fixed, err := result.Check.Fix(db)
+					if err != nil {
+						fmt.Printf("✗ %s: fix failed: %v\n", result.Check.Name, err)
+					} else {
+						fmt.Printf("🔧 %s: fixed %d\n", result.Check.Name, fixed)
+					}
+					result = doctor.RunCheck(db, result.Check)
+				}
+
+				printDoctorResult(result)
+				if !result.OK() {
+					problems++
+					if result.Err == nil && result.Check.Fix == nil {
+						fmt.Printf("    fix: %s\n", result.Check.FixDescription)
+					}
+				}
+			}
+
+			if problems > 0 {
+				if !fix {
+					fmt.Println("\nRun 'ewctl doctor --fix' to repair automatically.")
+				}
+				return fmt.Errorf("%d check(s) reported problems", problems)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "apply the automatic fix of every failing check")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "apply fixes that need confirmation without asking")
+	cmd.Flags().StringSliceVar(&checks, "check", nil, "only run the named checks")
+	cmd.Flags().BoolVar(&list, "list", false, "list available checks and their fixes")
+
+	return cmd
+}
+
+// confirmDoctorFix asks before a fix that needs confirmation. Without a
+// terminal such fixes only run with --yes.
+func confirmDoctorFix(check doctor.Check, yes bool) bool {
+	if check.Confirm == "" || yes {
+		return true
+	}
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		fmt.Printf("⚠ %s: not fixed without --yes. %s\n", check.Name, check.Confirm)
+		return false
+	}
+
+	apply := false
+	err := huh.NewConfirm().
+		Title(fmt.Sprintf("%s: %s?", check.Name, check.FixDescription)).
+		Description(check.Confirm).
+		Value(&apply).
+		Run()
+	return err == nil && apply
+}
+
+func printDoctorResult(result doctor.Result) {
+	switch {
+	case result.Err != nil:
+		fmt.Printf("✗ %s: %v\n", result.Check.Name, result.Err)
+	case result.OK():
+		fmt.Printf("✓ %s\n", result.Check.Name)
+	default:
+		fmt.Printf("✗ %s: %d issue(s)\n", result.Check.Name, len(result.Issues))
+		for _, issue := range result.Issues {
+			fmt.Printf("    %s: %s\n", issue.Subject, issue.Detail)
+		}
+	}
+}
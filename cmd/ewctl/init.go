@@ -0,0 +1,385 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/probe"
+)
+
+func initCmd() *cobra.Command {
+	var (
+		nonInteractive bool
+		force          bool
+		migrate        bool
+		skipProbes     bool
+		values         config.Config
+	)
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Set up ewctl: enter and verify credentials, then write config.yaml",
+		Long: `Asks for the Cloudflare account, API token, D1 database and worker URL and
+the Z-API credentials, checks each against the live service (D1 SELECT 1, the
+worker's /health and the Z-API instance status), offers to create or migrate
+the schema and writes the config file.
+
+Values are prefilled from an existing config file and from the
+CLOUDFLARE_ACCOUNT_ID, CLOUDFLARE_API_TOKEN, DATABASE_ID, WORKER_URL,
+ZAPI_INSTANCE_ID, ZAPI_INSTANCE_TOKEN and ZAPI_CLIENT_TOKEN environment
+variables; flags override both. Tokens may be given as references such as
+keyring:ewctl/cloudflare or cmd:pass show cf-token; see ewctl config secret.
+With --non-interactive, or when stdin is not a terminal, nothing is asked and
+any failed check aborts.`,
+		Example: `  ewctl init
+  ewctl init --non-interactive --account-id ... --api-token ... \
+    --database-id ... --worker-url https://my-worker.workers.dev --migrate`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return err
+			}
+			applyInitFlags(cmd, cfg, &values)
+			if err := resolveSecretRefs(cfg); err != nil {
+				return err
+			}
+
+			path := cfgFile
+			if path == "" {
+				if path, err = config.DefaultPath(); err != nil {
+					return err
+				}
+			}
+			_, statErr := os.Stat(path)
+			exists := statErr == nil
+
+			interactive := !nonInteractive && isatty.IsTerminal(os.Stdin.Fd())
+			if !interactive {
+				if exists && !force {
+					return fmt.Errorf("%s already exists; pass --force to update it", path)
+				}
+				return initNonInteractive(cfg, path, migrate, skipProbes)
+			}
+
+			if exists && !force {
+				update := true
+				err := huh.NewConfirm().
+					Title(fmt.Sprintf("%s already exists. Update it?", path)).
+					Description("Its current values are prefilled; comments and other settings are kept.").
+					Value(&update).
+					Run()
+				if err != nil {
+					return err
+				}
+				if !update {
+					return nil
+				}
+			}
+			return initInteractive(cfg, path, skipProbes)
+		},
+	}
+
+	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "take values from flags and environment variables without asking")
+	cmd.Flags().BoolVar(&force, "force", false, "update an existing config file without asking")
+	cmd.Flags().BoolVar(&migrate, "migrate", false, "create missing tables and columns without asking")
+	cmd.Flags().BoolVar(&skipProbes, "skip-probes", false, "do not check the values against the live services")
+	cmd.Flags().StringVar(&values.Cloudflare.AccountID, "account-id", "", "Cloudflare account ID")
+	cmd.Flags().StringVar(&values.Cloudflare.APIToken, "api-token", "", "Cloudflare API token with D1 access")
+	cmd.Flags().StringVar(&values.Cloudflare.DatabaseID, "database-id", "", "D1 database ID")
+	cmd.Flags().StringVar(&values.Cloudflare.WorkerURL, "worker-url", "", "URL of the deployed worker")
+	cmd.Flags().StringVar(&values.ZAPI.InstanceID, "zapi-instance-id", "", "Z-API instance ID")
+	cmd.Flags().StringVar(&values.ZAPI.InstanceToken, "zapi-instance-token", "", "Z-API instance token")
+	cmd.Flags().StringVar(&values.ZAPI.ClientToken, "zapi-client-token", "", "Z-API account security token")
+
+	return cmd
+}
+
+// applyInitFlags copies the flags that were set onto cfg
+func applyInitFlags(cmd *cobra.Command, cfg, values *config.Config) {
+	for flag, pair := range map[string][2]*string{
+		"account-id":          {&cfg.Cloudflare.AccountID, &values.Cloudflare.AccountID},
+		"api-token":           {&cfg.Cloudflare.APIToken, &values.Cloudflare.APIToken},
+		"database-id":         {&cfg.Cloudflare.DatabaseID, &values.Cloudflare.DatabaseID},
+		"worker-url":          {&cfg.Cloudflare.WorkerURL, &values.Cloudflare.WorkerURL},
+		"zapi-instance-id":    {&cfg.ZAPI.InstanceID, &values.ZAPI.InstanceID},
+		"zapi-instance-token": {&cfg.ZAPI.InstanceToken, &values.ZAPI.InstanceToken},
+		"zapi-client-token":   {&cfg.ZAPI.ClientToken, &values.ZAPI.ClientToken},
+	} {
+		if cmd.Flags().Changed(flag) {
+			*pair[0] = strings.TrimSpace(*pair[1])
+		}
+	}
+}
+
+func initNonInteractive(cfg *config.Config, path string, migrate, skipProbes bool) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if !skipProbes {
+		failures := 0
+		for _, result := range probe.All(cfg) {
+			printProbe(result)
+			if !result.OK && !result.Skipped {
+				failures++
+			}
+		}
+		if failures > 0 {
+			return fmt.Errorf("%d checks failed; fix the values or pass --skip-probes", failures)
+		}
+
+		db, err := database.NewClient(cfg)
+		if err != nil {
+			return err
+		}
+		drift, err := db.GetSchemaDrift()
+		if err != nil {
+			return err
+		}
+		if len(drift) > 0 {
+			if !migrate {
+				fmt.Printf("The database is missing %d tables or columns; run ewctl init --migrate or ewctl doctor --fix\n", len(drift))
+			} else if err := migrateSchema(db); err != nil {
+				return err
+			}
+		}
+	}
+
+	return config.Save(cfg, path)
+}
+
+func initInteractive(cfg *config.Config, path string, skipProbes bool) error {
+	fmt.Println(successStyle.Render("Welcome to ewctl!") + " Let's connect it to your Cloudflare worker and Z-API instance.")
+	fmt.Println()
+
+	// Cloudflare, until the checks pass or the user moves on
+	for {
+		if err := askCloudflare(cfg); err != nil {
+			return err
+		}
+		if skipProbes {
+			break
+		}
+		d1 := probe.D1(cfg)
+		printProbe(d1)
+		printProbe(probe.Worker(cfg))
+		if d1.OK || !retry("Some checks failed. Edit the Cloudflare settings again?") {
+			break
+		}
+	}
+
+	// Z-API is optional; the worker has its own copy of the credentials
+	configure := cfg.ZAPI.InstanceID != ""
+	if !configure {
+		err := huh.NewConfirm().
+			Title("Configure Z-API now?").
+			Description("ewctl needs it to resend deliveries, drain the queue and check the connection.").
+			Value(&configure).
+			Run()
+		if err != nil {
+			return err
+		}
+	}
+	for configure {
+		if err := askZAPI(cfg); err != nil {
+			return err
+		}
+		if skipProbes {
+			break
+		}
+		result := probe.ZAPI(cfg)
+		printProbe(result)
+		if result.OK || !retry("Edit the Z-API settings again?") {
+			break
+		}
+	}
+
+	if !skipProbes {
+		if err := offerMigration(cfg); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("not saving an incomplete configuration: %w", err)
+	}
+	if err := config.Save(cfg, path); err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Println(successStyle.Render("All set!") + " Run ewctl to open the TUI.")
+	return nil
+}
+
+func askCloudflare(cfg *config.Config) error {
+	err := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Cloudflare account ID").
+				Description("Shown on the right of the Workers & Pages overview.").
+				Value(&cfg.Cloudflare.AccountID).
+				Validate(required("account ID")),
+			huh.NewInput().
+				Title("Cloudflare API token").
+				Description("Needs the D1 Edit permission.").
+				EchoMode(huh.EchoModePassword).
+				Value(&cfg.Cloudflare.APIToken).
+				Validate(requiredSecret(cfg, "API token")),
+			huh.NewInput().
+				Title("D1 database ID").
+				Description("See wrangler.toml or `wrangler d1 list`.").
+				Value(&cfg.Cloudflare.DatabaseID).
+				Validate(required("database ID")),
+			huh.NewInput().
+				Title("Worker URL").
+				Value(&cfg.Cloudflare.WorkerURL).
+				Validate(func(s string) error {
+					if !strings.HasPrefix(s, "http://") && !strings.HasPrefix(s, "https://") {
+						return errors.New("the worker URL must start with http:// or https://")
+					}
+					return nil
+				}),
+		).Title("Cloudflare"),
+	).Run()
+	if err != nil {
+		return err
+	}
+	return resolveSecretRefs(cfg)
+}
+
+func askZAPI(cfg *config.Config) error {
+	err := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Z-API instance ID").
+				Value(&cfg.ZAPI.InstanceID).
+				Validate(required("instance ID")),
+			huh.NewInput().
+				Title("Z-API instance token").
+				EchoMode(huh.EchoModePassword).
+				Value(&cfg.ZAPI.InstanceToken).
+				Validate(requiredSecret(cfg, "instance token")),
+			huh.NewInput().
+				Title("Z-API client token").
+				Description("The account security token; leave empty if it is disabled.").
+				EchoMode(huh.EchoModePassword).
+				Value(&cfg.ZAPI.ClientToken).
+				Validate(resolvable(cfg)),
+		).Title("Z-API"),
+	).Run()
+	if err != nil {
+		return err
+	}
+	return resolveSecretRefs(cfg)
+}
+
+// offerMigration creates missing tables and columns if the user agrees
+func offerMigration(cfg *config.Config) error {
+	db, err := database.NewClient(cfg)
+	if err != nil {
+		return nil
+	}
+	drift, err := db.GetSchemaDrift()
+	if err != nil {
+		fmt.Println(failedStyle.Render(fmt.Sprintf("✗ Could not inspect the schema: %v", err)))
+		return nil
+	}
+	if len(drift) == 0 {
+		fmt.Println(successStyle.Render("✓ Schema is up to date"))
+		return nil
+	}
+
+	title := fmt.Sprintf("The database is missing %d tables or columns. Create them now?", len(drift))
+	if len(drift) == len(database.ExpectedSchema) {
+		title = "The database is empty. Create the schema now?"
+	}
+	create := true
+	if err := huh.NewConfirm().Title(title).Value(&create).Run(); err != nil {
+		return err
+	}
+	if !create {
+		fmt.Println("Skipped; run ewctl doctor --fix later")
+		return nil
+	}
+	return migrateSchema(db)
+}
+
+func migrateSchema(db *database.Client) error {
+	fixed, err := db.MigrateSchema()
+	if err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Created %d missing tables and columns", fixed)))
+	return nil
+}
+
+func retry(question string) bool {
+	again := true
+	if err := huh.NewConfirm().Title(question).Affirmative("Edit").Negative("Continue anyway").Value(&again).Run(); err != nil {
+		return false
+	}
+	return again
+}
+
+func required(what string) func(string) error {
+	return func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return fmt.Errorf("the %s is required", what)
+		}
+		return nil
+	}
+}
+
+// requiredSecret is required plus resolvable
+func requiredSecret(cfg *config.Config, what string) func(string) error {
+	return func(s string) error {
+		if err := required(what)(s); err != nil {
+			return err
+		}
+		return resolvable(cfg)(s)
+	}
+}
+
+// resolvable rejects secret references that cannot be resolved
+func resolvable(cfg *config.Config) func(string) error {
+	return func(s string) error {
+		_, err := cfg.Resolver().Resolve(strings.TrimSpace(s))
+		return err
+	}
+}
+
+// resolveSecretRefs resolves tokens given as references, so they are probed
+// with the secret and saved as the reference
+func resolveSecretRefs(cfg *config.Config) error {
+	for key, value := range map[string]string{
+		"cloudflare.api_token": cfg.Cloudflare.APIToken,
+		"zapi.instance_token":  cfg.ZAPI.InstanceToken,
+		"zapi.client_token":    cfg.ZAPI.ClientToken,
+	} {
+		if cfg.Resolver().IsRef(value) {
+			if err := cfg.SetSecret(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// printProbe prints one checklist line per probe
+func printProbe(result probe.Result) {
+	switch {
+	case result.Skipped:
+		fmt.Println(mutedStyle.Render(fmt.Sprintf("- %s: skipped, %s", result.Name, result.Detail)))
+	case result.OK:
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ %s: %s", result.Name, result.Detail)))
+	default:
+		fmt.Println(failedStyle.Render(fmt.Sprintf("✗ %s: %s", result.Name, result.Error)))
+	}
+}
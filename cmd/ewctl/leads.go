@@ -0,0 +1,334 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+func leadsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "leads",
+		Short: "Work submissions as leads: status, owner, notes and follow-ups",
+		Long: `Every stored submission is a lead that moves through the statuses
+new → contacted → qualified, or lost.`,
+	}
+
+	var (
+		filter database.SubmissionFilter
+		due    bool
+		asJSON bool
+	)
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List leads, newest first",
+		Example: `  ewctl leads list --form contact-form --status new
+  ewctl leads list --owner 3 --due`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if filter.LeadStatus != "" && !database.ValidLeadStatus(filter.LeadStatus) {
+				return fmt.Errorf("unknown status %q (use %s)", filter.LeadStatus, strings.Join(database.LeadStatuses, ", "))
+			}
+			if due {
+				filter.FollowUpBefore = time.Now()
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			leads, err := db.GetSubmissions(filter)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(leads)
+			}
+			if len(leads) == 0 {
+				fmt.Println("No leads found")
+				return nil
+			}
+
+			fields, err := db.GetFieldsByForm()
+			if err != nil {
+				return err
+			}
+			owners := contactNames(db)
+			for _, lead := range leads {
+				printLead(lead, fields[lead.FormID], owners)
+			}
+			return nil
+		},
+	}
+	list.Flags().StringVar(&filter.FormID, "form", "", "only list leads of this form")
+	list.Flags().StringVar(&filter.LeadStatus, "status", "", "only list leads with this status")
+	list.Flags().IntVar(&filter.OwnerContactID, "owner", 0, "only list leads owned by this contact ID")
+	list.Flags().BoolVar(&due, "due", false, "only list leads whose follow-up is due")
+	list.Flags().IntVar(&filter.Limit, "limit", 50, "maximum number of leads (0 for all)")
+	list.Flags().BoolVar(&asJSON, "json", false, "print leads as JSON")
+
+	show := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a lead with its notes and status history",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseLeadID(args[0])
+			if err != nil {
+				return err
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			lead, err := db.GetSubmission(id)
+			if err != nil {
+				return err
+			}
+			var fields []database.Field
+			if form, err := db.GetForm(lead.FormID); err == nil {
+				fields = form.Fields
+			}
+			owners := contactNames(db)
+
+			fmt.Printf("Lead #%d (%s)\n", lead.ID, lead.FormID)
+			fmt.Printf("Status:     %s\n", lead.LeadStatus)
+			fmt.Printf("Owner:      %s\n", ownerName(lead.OwnerContactID, owners))
+			if lead.FollowUpAt != nil {
+				fmt.Printf("Follow up:  %s\n", lead.FollowUpAt.Local().Format("2006-01-02 15:04"))
+			}
+			fmt.Printf("Received:   %s\n\n", lead.CreatedAt.Local().Format("2006-01-02 15:04"))
+			for _, field := range lead.LabeledFields(fields) {
+				fmt.Printf("%s: %s\n", field.Label, field.Value)
+			}
+
+			notes, err := db.GetLeadNotes(id)
+			if err != nil {
+				return err
+			}
+			if len(notes) > 0 {
+				fmt.Println("\nNotes:")
+				for _, note := range notes {
+					fmt.Printf("  %s  %s: %s\n", note.CreatedAt.Local().Format("2006-01-02 15:04"), note.Actor, note.Body)
+				}
+			}
+
+			history, err := db.GetLeadStatusHistory(id)
+			if err != nil {
+				return err
+			}
+			if len(history) > 0 {
+				fmt.Println("\nHistory:")
+				for _, change := range history {
+					fmt.Printf("  %s  %s: %s → %s\n", change.CreatedAt.Local().Format("2006-01-02 15:04"), change.Actor, change.FromStatus, change.ToStatus)
+				}
+			}
+			return nil
+		},
+	}
+
+	status := &cobra.Command{
+		Use:       "status <id> <status>",
+		Short:     "Move a lead to another status",
+		Example:   "  ewctl leads status 42 contacted",
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: database.LeadStatuses,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseLeadID(args[0])
+			if err != nil {
+				return err
+			}
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			if err := db.SetLeadStatus(id, args[1]); err != nil {
+				return err
+			}
+			fmt.Printf("Lead #%d is now %s\n", id, args[1])
+			return nil
+		},
+	}
+
+	assign := &cobra.Command{
+		Use:     "assign <id> <contact-id|none>",
+		Short:   "Set the contact working a lead",
+		Example: "  ewctl leads assign 42 3",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseLeadID(args[0])
+			if err != nil {
+				return err
+			}
+			var owner *int
+			if args[1] != "none" {
+				contactID, err := strconv.Atoi(args[1])
+				if err != nil {
+					return fmt.Errorf("invalid contact ID %q", args[1])
+				}
+				owner = &contactID
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			if err := db.AssignLead(id, owner); err != nil {
+				return err
+			}
+			if owner == nil {
+				fmt.Printf("Lead #%d is unassigned\n", id)
+			} else {
+				fmt.Printf("Lead #%d is assigned to contact %d\n", id, *owner)
+			}
+			return nil
+		},
+	}
+
+	note := &cobra.Command{
+		Use:     "note <id> <text>",
+		Short:   "Add a note to a lead",
+		Example: `  ewctl leads note 42 "Called, will send a quote on Monday"`,
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseLeadID(args[0])
+			if err != nil {
+				return err
+			}
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			if err := db.AddLeadNote(id, strings.Join(args[1:], " ")); err != nil {
+				return err
+			}
+			fmt.Printf("Added a note to lead #%d\n", id)
+			return nil
+		},
+	}
+
+	var in time.Duration
+	followUp := &cobra.Command{
+		Use:   "follow-up <id> [when|none]",
+		Short: "Set when to follow up on a lead",
+		Example: `  ewctl leads follow-up 42 --in 48h
+  ewctl leads follow-up 42 "2024-06-03 09:00"
+  ewctl leads follow-up 42 none`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseLeadID(args[0])
+			if err != nil {
+				return err
+			}
+
+			var at *time.Time
+			switch {
+			case in > 0:
+				t := time.Now().Add(in)
+				at = &t
+			case len(args) == 2 && args[1] == "none":
+			case len(args) == 2:
+				t, err := parseLocalTime(args[1])
+				if err != nil {
+					return err
+				}
+				at = &t
+			default:
+				return fmt.Errorf("pass a time, none, or --in")
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			if err := db.SetLeadFollowUp(id, at); err != nil {
+				return err
+			}
+			if at == nil {
+				fmt.Printf("Cleared the follow-up of lead #%d\n", id)
+			} else {
+				fmt.Printf("Follow up on lead #%d at %s\n", id, at.Local().Format("2006-01-02 15:04"))
+			}
+			return nil
+		},
+	}
+	followUp.Flags().DurationVar(&in, "in", 0, "follow up after this long (e.g. 48h)")
+
+	var summaryForm string
+	summary := &cobra.Command{
+		Use:   "summary",
+		Short: "Count leads by status",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			counts, err := db.GetLeadCounts(summaryForm)
+			if err != nil {
+				return err
+			}
+			for _, status := range database.LeadStatuses {
+				fmt.Printf("%-10s %d\n", status, counts[status])
+			}
+			return nil
+		},
+	}
+	summary.Flags().StringVar(&summaryForm, "form", "", "only count leads of this form")
+
+	cmd.AddCommand(list, show, status, assign, note, followUp, summary)
+	return cmd
+}
+
+func parseLeadID(value string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(value, "#"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid lead ID %q", value)
+	}
+	return id, nil
+}
+
+// contactNames maps contact IDs to names for showing lead owners
+func contactNames(db *database.Client) map[int]string {
+	names := make(map[int]string)
+	contacts, err := db.GetAllContacts()
+	if err != nil {
+		return names
+	}
+	for _, contact := range contacts {
+		names[contact.ID] = contact.Name
+	}
+	return names
+}
+
+func ownerName(id *int, names map[int]string) string {
+	if id == nil {
+		return "unassigned"
+	}
+	if name, ok := names[*id]; ok {
+		return name
+	}
+	return fmt.Sprintf("contact %d", *id)
+}
+
+func printLead(lead database.Submission, fields []database.Field, owners map[int]string) {
+	followUp := ""
+	if lead.FollowUpAt != nil {
+		followUp = "follow up " + lead.FollowUpAt.Local().Format("2006-01-02 15:04")
+	}
+	fmt.Printf("#%-6d %s  %-20s %-10s %-16s %s  %s\n",
+		lead.ID,
+		lead.CreatedAt.Local().Format("2006-01-02"),
+		lead.FormID, lead.LeadStatus,
+		ownerName(lead.OwnerContactID, owners),
+		submissionSummary(lead, fields),
+		followUp,
+	)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tail"
+)
+
+func logsCmd() *cobra.Command {
+	var (
+		filter   database.TailFilter
+		follow   bool
+		lines    int
+		interval time.Duration
+		asJSON   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show recent submissions and deliveries, or follow them live",
+		Long: `Prints the latest submissions and delivery attempts recorded by the worker.
+With --follow it keeps polling and prints new rows as they arrive; --json writes
+one JSON object per line for piping into jq.`,
+		Example: `  ewctl logs --follow --form contact-form
+  ewctl logs -f --status failed --status partial
+  ewctl logs -f --json | jq 'select(.kind == "delivery") | .delivery.recipients'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			follower, err := tail.NewFollower(db, filter, lines)
+			if err != nil {
+				return err
+			}
+			fields, err := db.GetFieldsByForm()
+			if err != nil {
+				return err
+			}
+
+			enc := json.NewEncoder(os.Stdout)
+			emit := func(events []tail.Event) error {
+				for _, event := range events {
+					if asJSON {
+						if err := enc.Encode(event); err != nil {
+							return err
+						}
+						continue
+					}
+					printEvent(event, fields)
+				}
+				return nil
+			}
+
+			if !follow {
+				events, err := follower.Poll()
+				if err != nil {
+					return err
+				}
+				return emit(events)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return follower.Follow(ctx, interval, emit)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "keep printing new rows as they arrive")
+	cmd.Flags().StringVar(&filter.FormID, "form", "", "only show rows of this form")
+	cmd.Flags().StringSliceVar(&filter.Statuses, "status", nil, "only show rows with this delivery status (repeatable)")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 10, "how many recent rows of each kind to start with")
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "how often to poll with --follow")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print one JSON object per line")
+
+	return cmd
+}
+
+var (
+	failedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+)
+
+// printEvent prints one line per event; colors are dropped when stdout is
+// not a terminal
+func printEvent(event tail.Event, fields map[string][]database.Field) {
+	line := fmt.Sprintf("%s %-10s #%-6d %-20s %-8s %s",
+		event.Time.Local().Format("2006-01-02 15:04:05"),
+		event.Kind, event.ID, event.FormID, event.Status,
+		event.Summary(fields),
+	)
+	switch {
+	case event.Failed():
+		line = failedStyle.Render(line)
+	case event.Kind == tail.KindDelivery:
+		line = successStyle.Render(line)
+	}
+	fmt.Println(line)
+}
@@ -15,6 +15,7 @@ var (
 	commit    = "none"
 	date      = "unknown"
 	cfgFile   string
+	profile   string
 	debugMode bool
 )
 
@@ -56,13 +57,26 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/ewctl/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "config profile to use (overrides EWCTL_PROFILE)")
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "enable debug mode")
 
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(initCmd())
 	rootCmd.AddCommand(formsCmd())
 	rootCmd.AddCommand(contactsCmd())
 	rootCmd.AddCommand(webhookCmd())
 	rootCmd.AddCommand(configCmd())
+	rootCmd.AddCommand(doctorCmd())
+	rootCmd.AddCommand(auditCmd())
+	rootCmd.AddCommand(deliveriesCmd())
+	rootCmd.AddCommand(queueCmd())
+	rootCmd.AddCommand(serveCmd())
+	rootCmd.AddCommand(submissionsCmd())
+	rootCmd.AddCommand(leadsCmd())
+	rootCmd.AddCommand(statsCmd())
+	rootCmd.AddCommand(logsCmd())
+	rootCmd.AddCommand(monitorCmd())
+	rootCmd.AddCommand(themeCmd())
 }
 
 func initConfig() {
@@ -71,6 +85,11 @@ func initConfig() {
 	} else {
 		log.SetLevel(log.InfoLevel)
 	}
+
+	// config.Load reads the profile from the environment
+	if profile != "" {
+		os.Setenv("EWCTL_PROFILE", profile)
+	}
 }
 
 func main() {
@@ -110,6 +129,10 @@ func formsCmd() *cobra.Command {
 		},
 	})
 
+	cmd.AddCommand(formsArchiveCmds()...)
+	cmd.AddCommand(formsPauseCmds()...)
+	cmd.AddCommand(formsVersionCmds()...)
+
 	return cmd
 }
 
@@ -143,6 +166,8 @@ func contactsCmd() *cobra.Command {
 		},
 	})
 
+	cmd.AddCommand(contactsArchiveCmds()...)
+
 	return cmd
 }
 
@@ -204,5 +229,8 @@ func configCmd() *cobra.Command {
 		},
 	})
 
+	cmd.AddCommand(configValidateCmd())
+	cmd.AddCommand(configSecretCmd())
+
 	return cmd
 }
\ No newline at end of file
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/alert"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/monitor"
+)
+
+func monitorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "monitor",
+		Short: "Show the Z-API connection state and run alerting rules",
+		Long: `The worker's cron checks the Z-API instance and records every time the
+WhatsApp connection goes up or down; status and history read that record.
+run evaluates the alert rules in config.yaml and notifies when they fire.`,
+	}
+
+	cmd.AddCommand(monitorStatusCmd())
+	cmd.AddCommand(monitorHistoryCmd())
+	cmd.AddCommand(monitorRunCmd())
+
+	return cmd
+}
+
+func monitorStatusCmd() *cobra.Command {
+	var (
+		since  time.Duration
+		asJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the current connection state, uptime and recent outages",
+		Example: `  ewctl monitor status
+  ewctl monitor status --since 720h --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			report, err := monitor.Compute(db, database.MonitorZAPI, time.Now().Add(-since))
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(report)
+			}
+			printMonitorReport(report)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&since, "since", 7*24*time.Hour, "cover this long ago until now")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print the report as JSON")
+
+	return cmd
+}
+
+func monitorHistoryCmd() *cobra.Command {
+	var (
+		limit  int
+		asJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the recorded connection flips, newest first",
+		Example: `  ewctl monitor history --limit 20
+  ewctl monitor history --json | jq '.[] | select(.connected == false)'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			history, err := db.GetMonitoringHistory(database.MonitorZAPI, limit)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(history)
+			}
+			if len(history) == 0 {
+				fmt.Println("No connection changes recorded yet")
+				return nil
+			}
+
+			// Each flip lasted until the next, newer one
+			until := time.Now()
+			for _, event := range history {
+				line := fmt.Sprintf("%s  %-12s %-10s lasted %s",
+					event.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+					connectionLabel(event.Connected), sessionLabel(event.Session),
+					monitor.FormatDuration(until.Sub(event.CreatedAt)))
+				if msg := database.StatusError(event.Status); msg != "" {
+					line += " • " + msg
+				}
+				fmt.Println(connectionStyle(event.Connected).Render(line))
+				until = event.CreatedAt
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 20, "how many flips to list (the worker keeps 100)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print the flips as JSON")
+
+	return cmd
+}
+
+func monitorRunCmd() *cobra.Command {
+	var (
+		interval time.Duration
+		once     bool
+		dryRun   bool
+		test     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Evaluate the alert rules and notify when they fire or resolve",
+		Long: `Evaluates the rules under alerts.rules in config.yaml every alerts.interval
+and sends an alert through the rule's notifiers when it starts firing and when
+it resolves. With alerts.repeat set, still firing alerts are re-sent that often.
+
+Rule types: zapi_down, failure_rate, no_submissions, d1_unreachable.
+Notifier types: smtp, webhook, slack, whatsapp.`,
+		Example: `  ewctl monitor run
+  ewctl monitor run --once --dry-run
+  ewctl monitor run --test`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return err
+			}
+			db, err := database.NewClient(cfg)
+			if err != nil {
+				return err
+			}
+			engine, err := alert.NewEngine(cfg, db)
+			if err != nil {
+				return err
+			}
+			if len(engine.Rules()) == 0 {
+				return fmt.Errorf("no alert rules configured; add them under alerts.rules in config.yaml")
+			}
+			engine.DryRun = dryRun
+			engine.OnAlert = printAlert
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			if test {
+				failures := engine.Test(ctx)
+				if len(failures) == 0 {
+					fmt.Println("Test alert sent through every notifier")
+					return nil
+				}
+				names := make([]string, 0, len(failures))
+				for name := range failures {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					fmt.Println(failedStyle.Render(fmt.Sprintf("%s: %v", name, failures[name])))
+				}
+				return fmt.Errorf("%d notifiers failed", len(failures))
+			}
+
+			if once {
+				engine.Evaluate(ctx, time.Now())
+				return nil
+			}
+
+			if interval <= 0 {
+				interval = cfg.Alerts.Interval
+			}
+			fmt.Printf("Evaluating %d rules every %s (Ctrl+C to stop)\n", len(engine.Rules()), interval)
+			return engine.Run(ctx, interval)
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 0, "how often to evaluate the rules (default alerts.interval)")
+	cmd.Flags().BoolVar(&once, "once", false, "evaluate the rules once and exit")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print alerts without notifying anyone")
+	cmd.Flags().BoolVar(&test, "test", false, "send a test alert through every notifier and exit")
+
+	return cmd
+}
+
+// printAlert prints one line per alert
+func printAlert(a alert.Alert) {
+	line := fmt.Sprintf("%s  %-8s %-24s %s", a.Time.Local().Format("2006-01-02 15:04:05"), a.State, a.Rule, a.Summary)
+	if a.State == alert.StateFiring {
+		fmt.Println(failedStyle.Render(line))
+		return
+	}
+	fmt.Println(successStyle.Render(line))
+}
+
+func printMonitorReport(report *monitor.Report) {
+	state := report.State
+	if state == nil {
+		fmt.Println("No state recorded yet; the worker's cron has not checked Z-API")
+		return
+	}
+
+	fmt.Printf("Z-API         %s since %s (%s)\n",
+		connectionStyle(state.Connected).Render(connectionLabel(state.Connected)),
+		state.LastChanged.Local().Format("2006-01-02 15:04"),
+		monitor.FormatDuration(time.Since(state.LastChanged)))
+	fmt.Printf("Session       %s\n", sessionLabel(state.Session))
+	if msg := database.StatusError(state.Status); msg != "" {
+		fmt.Printf("Last error    %s\n", msg)
+	}
+
+	fmt.Printf("\nUptime        %s over the last %s\n", formatRate(report.Uptime), monitor.FormatDuration(report.Until.Sub(report.Since)))
+	fmt.Printf("Downtime      %s in %d outages\n", monitor.FormatDuration(report.Downtime), len(report.Outages))
+	fmt.Printf("Timeline      %s\n", components.Timeline(report.Timeline(60), successStyle, failedStyle, warningStyle, mutedStyle))
+	fmt.Printf("              %s → now\n", report.Since.Local().Format("2006-01-02 15:04"))
+
+	if len(report.Outages) > 0 {
+		fmt.Println("\nOutages:")
+		for _, outage := range report.Outages {
+			end := outage.End.Local().Format("2006-01-02 15:04")
+			if outage.Ongoing {
+				end = "ongoing"
+			}
+			line := fmt.Sprintf("  %s → %-16s %s", outage.Start.Local().Format("2006-01-02 15:04"), end, monitor.FormatDuration(outage.Duration()))
+			if outage.Error != "" {
+				line += " • " + outage.Error
+			}
+			fmt.Println(line)
+		}
+	}
+}
+
+var (
+	warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	mutedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+)
+
+func connectionStyle(connected bool) lipgloss.Style {
+	if connected {
+		return successStyle
+	}
+	return failedStyle
+}
+
+func connectionLabel(connected bool) string {
+	if connected {
+		return "connected"
+	}
+	return "disconnected"
+}
+
+func sessionLabel(session bool) string {
+	if session {
+		return "session up"
+	}
+	return "no session"
+}
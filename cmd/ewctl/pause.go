@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func formsPauseCmds() []*cobra.Command {
+	var (
+		pauseFor   time.Duration
+		pauseUntil string
+	)
+	pause := &cobra.Command{
+		Use:   "pause <form-id>",
+		Short: "Pause a form; submissions are buffered until it is resumed",
+		Long: `Pauses a form for maintenance. The worker keeps accepting submissions
+but holds them instead of messaging recipients, and delivers them once the
+form is resumed or the pause window ends.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if pauseFor > 0 && pauseUntil != "" {
+				return fmt.Errorf("use either --for or --until, not both")
+			}
+
+			var until *time.Time
+			switch {
+			case pauseFor > 0:
+				t := time.Now().Add(pauseFor)
+				until = &t
+			case pauseUntil != "":
+				t, err := parseLocalTime(pauseUntil)
+				if err != nil {
+					return err
+				}
+				if !t.After(time.Now()) {
+					return fmt.Errorf("--until %s is in the past", pauseUntil)
+				}
+				until = &t
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			if err := db.PauseForm(args[0], until); err != nil {
+				return err
+			}
+
+			if until != nil {
+				fmt.Printf("⏸  Paused form %s until %s\n", args[0], until.Format("2006-01-02 15:04"))
+			} else {
+				fmt.Printf("⏸  Paused form %s until resumed\n", args[0])
+			}
+			return nil
+		},
+	}
+	pause.Flags().DurationVar(&pauseFor, "for", 0, "resume automatically after this long (e.g. 2h)")
+	pause.Flags().StringVar(&pauseUntil, "until", "", `resume automatically at this local time ("2006-01-02 15:04" or RFC3339)`)
+
+	resume := &cobra.Command{
+		Use:   "resume <form-id>",
+		Short: "Resume a paused form and deliver its buffered submissions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+
+			counts, err := db.GetBufferedCounts()
+			if err != nil {
+				return err
+			}
+			if err := db.ResumeForm(args[0]); err != nil {
+				return err
+			}
+
+			fmt.Printf("▶  Resumed form %s\n", args[0])
+			if queued := counts[args[0]]; queued > 0 {
+				fmt.Printf("%d buffered submission(s) will be delivered on the worker's next run\n", queued)
+			}
+			return nil
+		},
+	}
+
+	return []*cobra.Command{pause, resume}
+}
+
+// parseLocalTime accepts RFC3339 or a local "2006-01-02 15:04" timestamp
+func parseLocalTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use \"2006-01-02 15:04\" or RFC3339)", value)
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/queue"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+func queueCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Inspect and drain the outbound message queue",
+		Long: `The worker queues every recipient it could not reach. Jobs are retried
+with exponential backoff (queue.base_delay doubling up to queue.max_delay)
+and dead-lettered after queue.max_attempts.`,
+	}
+
+	status := &cobra.Command{
+		Use:   "status",
+		Short: "Count jobs by status",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			stats, err := db.GetQueueStats()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Pending:   %d\n", stats.Pending)
+			fmt.Printf("In flight: %d\n", stats.InFlight)
+			fmt.Printf("Sent:      %d\n", stats.Sent)
+			fmt.Printf("Dead:      %d\n", stats.Dead)
+			return nil
+		},
+	}
+
+	var (
+		listStatus string
+		listLimit  int
+		asJSON     bool
+	)
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List queued jobs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			jobs, err := db.GetJobs(listStatus, listLimit)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(jobs)
+			}
+			if len(jobs) == 0 {
+				fmt.Println("No jobs found")
+				return nil
+			}
+			for _, job := range jobs {
+				printJob(job)
+			}
+			return nil
+		},
+	}
+	list.Flags().StringVar(&listStatus, "status", "", "only list jobs with this status (pending, in_flight, sent, dead)")
+	list.Flags().IntVar(&listLimit, "limit", 50, "maximum number of jobs (0 for all)")
+	list.Flags().BoolVar(&asJSON, "json", false, "print jobs as JSON")
+
+	var watch bool
+	run := &cobra.Command{
+		Use:   "run",
+		Short: "Send every due job, then exit",
+		Long: `Claims due jobs and sends them through Z-API until none are left. With
+--watch it keeps polling every queue.poll_interval until interrupted, like
+ewctl serve.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner, err := newQueueRunner()
+			if err != nil {
+				return err
+			}
+			runner.OnResult = printJobResult
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			if watch {
+				fmt.Printf("Draining the queue every %s (Ctrl+C to stop)\n", runner.PollInterval)
+				return runner.Run(ctx)
+			}
+			return runner.Drain(ctx)
+		},
+	}
+	run.Flags().BoolVar(&watch, "watch", false, "keep polling for due jobs")
+
+	var all bool
+	requeue := &cobra.Command{
+		Use:   "requeue [job-id...]",
+		Short: "Give dead jobs a fresh set of attempts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && !all {
+				return fmt.Errorf("pass job IDs or --all")
+			}
+			var ids []int
+			for _, arg := range args {
+				id, err := strconv.Atoi(arg)
+				if err != nil {
+					return fmt.Errorf("invalid job ID %q", arg)
+				}
+				ids = append(ids, id)
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			n, err := db.RequeueDeadJobs(ids...)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Requeued %d dead job(s)\n", n)
+			return nil
+		},
+	}
+	requeue.Flags().BoolVar(&all, "all", false, "requeue every dead job")
+
+	cmd.AddCommand(status, list, run, requeue)
+	return cmd
+}
+
+// newQueueRunner connects to D1 and Z-API and applies the queue settings
+func newQueueRunner() (*queue.Runner, error) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+	db, err := database.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	sender := zapi.NewClient(cfg.ZAPI.InstanceID, cfg.ZAPI.InstanceToken, cfg.ZAPI.ClientToken).WithBaseURL(cfg.ZAPI.BaseURL)
+	if !sender.Configured() {
+		return nil, fmt.Errorf("z-api credentials are not configured (zapi.instance_id and zapi.instance_token)")
+	}
+	return queue.NewRunner(db, sender, cfg.Queue), nil
+}
+
+func printJob(job database.OutboundJob) {
+	fmt.Printf("#%-6d %-9s %-20s %-16s attempt %d/%d",
+		job.ID, job.Status, job.FormID, job.Phone, job.Attempts, job.MaxAttempts)
+	if job.Status == database.JobPending {
+		fmt.Printf("  next %s", job.NextAttemptAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	if job.LastError != "" {
+		fmt.Printf("  %s", job.LastError)
+	}
+	fmt.Println()
+}
+
+func printJobResult(r queue.Result) {
+	prefix := fmt.Sprintf("%s #%d %s", time.Now().Format("15:04:05"), r.Job.ID, r.Job.Phone)
+	switch r.Outcome {
+	case queue.OutcomeSent:
+		fmt.Printf("%s: sent\n", prefix)
+	case queue.OutcomeSkipped:
+		fmt.Printf("%s: skipped, already delivered\n", prefix)
+	case queue.OutcomeRetry:
+		fmt.Printf("%s: attempt %d failed (%v), retrying at %s\n",
+			prefix, r.Job.Attempts, r.Err, r.NextAttempt.Local().Format("15:04:05"))
+	case queue.OutcomeDead:
+		fmt.Printf("%s: dead after %d attempts (%v)\n", prefix, r.Job.Attempts, r.Err)
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/secrets"
+)
+
+func configSecretCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secret",
+		Short: "Keep credentials out of config.yaml",
+		Long: `Credentials in config.yaml can be references instead of plaintext:
+
+  keyring:ewctl/cloudflare   the system keyring (service/user)
+  age:cloudflare             an entry of the age-encrypted secrets.age file
+  cmd:pass show cf-token     the output of a command
+  env:CF_API_TOKEN           an environment variable
+
+secrets.age sits next to config.yaml unless secrets_file or
+EWCTL_SECRETS_FILE says otherwise. It is decrypted with the identities in
+EWCTL_AGE_IDENTITY, or with a passphrase from EWCTL_SECRETS_PASSPHRASE or the
+terminal.`,
+	}
+
+	cmd.AddCommand(configSecretSetCmd())
+	cmd.AddCommand(configSecretListCmd())
+
+	return cmd
+}
+
+func configSecretSetCmd() *cobra.Command {
+	var (
+		store string
+		name  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set <key>",
+		Short: "Store a credential in the keyring or secrets.age and reference it from config.yaml",
+		Example: `  ewctl config secret set cloudflare.api_token
+  ewctl config secret set zapi.instance_token --store age
+  pass show cf-token | ewctl config secret set cloudflare.api_token`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return err
+			}
+			if !containsString(cfg.SecretKeys(), key) {
+				return fmt.Errorf("%s is not a secret setting; choose one of %s", key, strings.Join(cfg.SecretKeys(), ", "))
+			}
+
+			if name == "" {
+				name = key
+				if store == "keyring" {
+					name = secrets.DefaultService + "/" + key
+				}
+			}
+
+			value, err := readSecret(key)
+			if err != nil {
+				return err
+			}
+			ref, err := cfg.Resolver().Set(store, name, value)
+			if err != nil {
+				return err
+			}
+			if err := cfg.SetSecret(key, ref); err != nil {
+				return err
+			}
+			if err := config.Save(cfg, cfgFile); err != nil {
+				return err
+			}
+			fmt.Println(successStyle.Render(fmt.Sprintf("✓ %s now reads %s", key, ref)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&store, "store", "keyring", "where to keep the secret: keyring or age")
+	cmd.Flags().StringVar(&name, "name", "", "entry name (default ewctl/<key> in the keyring, <key> in secrets.age)")
+
+	return cmd
+}
+
+func configSecretListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Show where each credential comes from",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return err
+			}
+			for _, key := range cfg.SecretKeys() {
+				source := cfg.SecretSource(key)
+				line := fmt.Sprintf("%-32s %s", key, source)
+				switch {
+				case strings.HasPrefix(source, "unresolved"):
+					fmt.Println(failedStyle.Render(line))
+				case strings.HasPrefix(source, "plaintext"):
+					fmt.Println(warningStyle.Render(line))
+				default:
+					fmt.Println(line)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// readSecret asks for a secret without echoing it, or reads it from stdin
+// when stdin is not a terminal
+func readSecret(key string) (string, error) {
+	var value string
+	if isatty.IsTerminal(os.Stdin.Fd()) {
+		err := huh.NewInput().
+			Title(key).
+			EchoMode(huh.EchoModePassword).
+			Value(&value).
+			Validate(required("value")).
+			Run()
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(value), nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read the secret from stdin: %w", err)
+	}
+	if value = strings.TrimRight(line, "\r\n"); value == "" {
+		return "", fmt.Errorf("the secret read from stdin is empty")
+	}
+	return value, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
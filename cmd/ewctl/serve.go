@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/queue"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+func serveCmd() *cobra.Command {
+	var addr string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the background worker that drains the outbound queue",
+		Long: `Runs until interrupted, sending due queue jobs every queue.poll_interval.
+With --addr it also serves /healthz and /queue (job counts as JSON) for
+uptime checks.`,
+		Example: "  ewctl serve --addr :8080",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return err
+			}
+			db, err := database.NewClient(cfg)
+			if err != nil {
+				return err
+			}
+			sender := zapi.NewClient(cfg.ZAPI.InstanceID, cfg.ZAPI.InstanceToken, cfg.ZAPI.ClientToken).WithBaseURL(cfg.ZAPI.BaseURL)
+			if !sender.Configured() {
+				log.Warn("Z-API credentials are not configured; every send will fail")
+			}
+
+			runner := queue.NewRunner(db, sender, cfg.Queue)
+			runner.OnResult = func(r queue.Result) {
+				switch r.Outcome {
+				case queue.OutcomeRetry:
+					log.Warn("Send failed, retrying", "job", r.Job.ID, "phone", r.Job.Phone, "attempt", r.Job.Attempts, "next", r.NextAttempt, "error", r.Err)
+				case queue.OutcomeDead:
+					log.Error("Job dead-lettered", "job", r.Job.ID, "phone", r.Job.Phone, "attempts", r.Job.Attempts, "error", r.Err)
+				default:
+					log.Info("Job "+r.Outcome, "job", r.Job.ID, "phone", r.Job.Phone)
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			if addr != "" {
+				server := &http.Server{Addr: addr, Handler: serveMux(db)}
+				go func() {
+					log.Info("Serving health checks", "addr", addr)
+					if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+						log.Error("HTTP server failed", "error", err)
+						stop()
+					}
+				}()
+				defer func() {
+					shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+					defer cancel()
+					server.Shutdown(shutdownCtx)
+				}()
+			}
+
+			log.Info("Queue worker started", "runner", runner.ID, "poll", runner.PollInterval)
+			err = runner.Run(ctx)
+			log.Info("Queue worker stopped")
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", "", "listen address for /healthz and /queue (disabled when empty)")
+
+	return cmd
+}
+
+func serveMux(db *database.Client) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/queue", func(w http.ResponseWriter, r *http.Request) {
+		stats, err := db.GetQueueStats()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(stats)
+	})
+	return mux
+}
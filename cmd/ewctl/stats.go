@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/analytics"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
+)
+
+func statsCmd() *cobra.Command {
+	var (
+		since  time.Duration
+		bucket string
+		formID string
+		top    int
+		asJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show submission and delivery metrics over time",
+		Long: `Computes submissions and deliveries per hour, day or week, the delivery
+success rate, delivery latency percentiles and volume per form and recipient
+from the worker's logs.`,
+		Example: `  ewctl stats
+  ewctl stats --since 24h --bucket hour --form contact-form
+  ewctl stats --since 2160h --bucket week --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := analytics.ParseBucket(bucket)
+			if err != nil {
+				return err
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			opts := analytics.Options{Bucket: b, FormID: formID}
+			if since > 0 {
+				opts.Since = time.Now().Add(-since)
+			}
+			report, err := analytics.Compute(db, opts)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(report)
+			}
+			printReport(report, top)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&since, "since", 7*24*time.Hour, "cover this long ago until now (0 for all time)")
+	cmd.Flags().StringVar(&bucket, "bucket", "day", "series bucket: hour, day or week")
+	cmd.Flags().StringVar(&formID, "form", "", "only cover this form")
+	cmd.Flags().IntVar(&top, "top", 5, "how many forms and recipients to list")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print the report as JSON")
+
+	return cmd
+}
+
+func printReport(report *analytics.Report, top int) {
+	if len(report.Series) == 0 {
+		fmt.Println("No submissions or deliveries in this range")
+		return
+	}
+
+	layout := "2006-01-02 15:04"
+	fmt.Printf("%s → %s, per %s\n\n", report.Series[0].Start.Format(layout), report.Until.Local().Format(layout), report.Bucket)
+
+	submissions := make([]float64, len(report.Series))
+	rates := make([]float64, len(report.Series))
+	for i, point := range report.Series {
+		submissions[i] = float64(point.Submissions)
+		rates[i] = point.SuccessRate()
+	}
+
+	fmt.Printf("Submissions   %-8d %s\n", report.Submissions, components.Sparkline(submissions))
+	fmt.Printf("Deliveries    %-8d\n", report.Deliveries)
+	fmt.Printf("Success rate  %-8s %s\n", formatRate(report.SuccessRate), components.Sparkline(rates))
+
+	latency := report.Latency
+	if latency.Samples > 0 {
+		fmt.Printf("Latency       p50 %dms • p90 %dms • p95 %dms • p99 %dms • max %dms\n",
+			latency.P50, latency.P90, latency.P95, latency.P99, latency.Max)
+	}
+
+	if len(report.ByForm) > 0 {
+		fmt.Println("\nBy form:")
+		topCount := report.ByForm[0].Count
+		for i, volume := range report.ByForm {
+			if i == top {
+				break
+			}
+			fmt.Printf("  %-24s %6d %s\n", volume.Name, volume.Count, components.Bar(volume.Count, topCount, 30))
+		}
+	}
+
+	if len(report.ByRecipient) > 0 {
+		fmt.Println("\nBy recipient:")
+		for i, volume := range report.ByRecipient {
+			if i == top {
+				break
+			}
+			fmt.Printf("  %-24s %6d sent %6d failed\n", volume.Phone, volume.Sent, volume.Failed)
+		}
+	}
+}
+
+func formatRate(rate float64) string {
+	if rate < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", rate*100)
+}
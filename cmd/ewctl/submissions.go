@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/export"
+)
+
+// submissionFlags are the filters shared by list and export
+type submissionFlags struct {
+	filter database.SubmissionFilter
+	since  time.Duration
+	from   string
+	to     string
+}
+
+func (f *submissionFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.filter.FormID, "form", "", "only include submissions of this form")
+	cmd.Flags().StringVar(&f.filter.Search, "search", "", "only include submissions containing this text")
+	cmd.Flags().DurationVar(&f.since, "since", 0, "only include submissions from this long ago (e.g. 24h)")
+	cmd.Flags().StringVar(&f.from, "from", "", "only include submissions on or after this date (2006-01-02)")
+	cmd.Flags().StringVar(&f.to, "to", "", "only include submissions on or before this date (2006-01-02)")
+}
+
+// resolve turns the flags into a filter
+func (f *submissionFlags) resolve() (database.SubmissionFilter, error) {
+	filter := f.filter
+	if f.since > 0 {
+		filter.Since = time.Now().Add(-f.since)
+	}
+	if f.from != "" {
+		from, err := time.ParseInLocation("2006-01-02", f.from, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --from date %q (use 2006-01-02)", f.from)
+		}
+		filter.Since = from
+	}
+	if f.to != "" {
+		to, err := time.ParseInLocation("2006-01-02", f.to, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --to date %q (use 2006-01-02)", f.to)
+		}
+		filter.Until = to.AddDate(0, 0, 1)
+	}
+	return filter, nil
+}
+
+func submissionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submissions",
+		Short: "Browse and export stored form submissions",
+		Long: `The worker stores every submission with its fields, raw payload, source
+and delivery outcome, so leads are never lost with a WhatsApp message.`,
+	}
+
+	var (
+		listFlags submissionFlags
+		asJSON    bool
+	)
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List submissions, newest first",
+		Example: `  ewctl submissions list --form contact-form --since 24h
+  ewctl submissions list --search alice@example.com`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter, err := listFlags.resolve()
+			if err != nil {
+				return err
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			submissions, err := db.GetSubmissions(filter)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(submissions)
+			}
+			if len(submissions) == 0 {
+				fmt.Println("No submissions found")
+				return nil
+			}
+
+			fields, err := db.GetFieldsByForm()
+			if err != nil {
+				return err
+			}
+			for _, s := range submissions {
+				fmt.Printf("#%-6d %s  %-20s %-8s %-3s %s\n",
+					s.ID,
+					s.CreatedAt.Local().Format("2006-01-02 15:04"),
+					s.FormID, s.DeliveryStatus, s.Country,
+					submissionSummary(s, fields[s.FormID]),
+				)
+			}
+			return nil
+		},
+	}
+	listFlags.register(list)
+	list.Flags().IntVar(&listFlags.filter.Limit, "limit", 50, "maximum number of submissions (0 for all)")
+	list.Flags().BoolVar(&asJSON, "json", false, "print submissions as JSON")
+
+	var raw bool
+	show := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a submission with its source and delivery outcome",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			if err != nil {
+				return fmt.Errorf("invalid submission ID %q", args[0])
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			s, err := db.GetSubmission(id)
+			if err != nil {
+				return err
+			}
+			if raw {
+				fmt.Println(s.Payload)
+				return nil
+			}
+
+			var fields []database.Field
+			if form, err := db.GetForm(s.FormID); err == nil {
+				fields = form.Fields
+			}
+
+			fmt.Printf("Submission #%d\n", s.ID)
+			fmt.Printf("Form:      %s\n", s.FormID)
+			fmt.Printf("Received:  %s\n", s.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+			fmt.Printf("Delivery:  %s\n", s.DeliveryStatus)
+			if s.LogID != nil {
+				fmt.Printf("Log:       #%d\n", *s.LogID)
+			}
+			fmt.Printf("Source:    %s\n", submissionSource(*s))
+			if s.UserAgent != "" {
+				fmt.Printf("Agent:     %s\n", s.UserAgent)
+			}
+			if s.Referer != "" {
+				fmt.Printf("Referer:   %s\n", s.Referer)
+			}
+			fmt.Println()
+			for _, field := range s.LabeledFields(fields) {
+				fmt.Printf("%s: %s\n", field.Label, field.Value)
+			}
+			return nil
+		},
+	}
+	show.Flags().BoolVar(&raw, "raw", false, "print the raw request body instead")
+
+	var (
+		exportFlags submissionFlags
+		format      string
+		output      string
+	)
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export submissions as CSV, JSON lines or XLSX",
+		Long: `Columns follow the form's field labels in field order. Submissions are
+fetched in batches, so large ranges can be exported without loading them all.`,
+		Example: `  ewctl submissions export --form contact-form --from 2024-05-01 --to 2024-05-31 -o may.xlsx
+  ewctl submissions export --form contact-form --format csv -o leads.csv
+  ewctl submissions export --since 168h --format jsonl`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter, err := exportFlags.resolve()
+			if err != nil {
+				return err
+			}
+			if format == "" {
+				format = export.FormatFromPath(output)
+			}
+			if format == "" {
+				format = "csv"
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			fields, err := db.GetFieldsByForm()
+			if err != nil {
+				return err
+			}
+
+			var w io.Writer = os.Stdout
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create %s: %w", output, err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			writer, err := export.NewWriter(format, w, export.NewLayout(fields, filter.FormID))
+			if err != nil {
+				return err
+			}
+			n, err := export.Stream(db, filter, writer)
+			if err != nil {
+				return err
+			}
+			if output != "" {
+				fmt.Fprintf(os.Stderr, "Exported %d submissions to %s\n", n, output)
+			}
+			return nil
+		},
+	}
+	exportFlags.register(exportCmd)
+	exportCmd.Flags().StringVar(&format, "format", "", "output format: csv, jsonl or xlsx (default from the -o extension, else csv)")
+	exportCmd.Flags().StringVarP(&output, "output", "o", "", "write to this file instead of stdout")
+
+	cmd.AddCommand(list, show, exportCmd)
+	return cmd
+}
+
+// submissionSummary shows the first few submitted values
+func submissionSummary(s database.Submission, fields []database.Field) string {
+	var values []string
+	for _, field := range s.LabeledFields(fields) {
+		if field.Value == "" {
+			continue
+		}
+		values = append(values, field.Value)
+		if len(values) == 3 {
+			break
+		}
+	}
+	return strings.Join(values, " • ")
+}
+
+func submissionSource(s database.Submission) string {
+	var parts []string
+	for _, part := range []string{s.City, s.Country, s.IP} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "unknown"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui"
+)
+
+func themeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "theme",
+		Short: "List and preview color themes",
+		Long: `Themes are chosen with ui.theme. Besides the built-in ones, every YAML or
+TOML file in ~/.config/ewctl/themes defines a theme named after the file
+(or its name key). A theme sets every color role under colors, and may
+override some of them for one terminal background under dark or light:
+
+  name: solarized
+  colors:
+    primary: "#268BD2"
+    secondary: "#D33682"
+    success: "#859900"
+    warning: "#B58900"
+    error: "#DC322F"
+    info: "#2AA198"
+    muted: "#586E75"
+    bg_primary: "#002B36"
+    bg_secondary: "#073642"
+    border: "#586E75"
+  light:
+    bg_primary: "#FDF6E3"
+    bg_secondary: "#EEE8D5"
+
+Colors are #RRGGBB values or ANSI numbers (0-255). The "auto" theme and
+themes with light or dark overrides follow the terminal background.`,
+	}
+
+	cmd.AddCommand(themeListCmd())
+	cmd.AddCommand(themePreviewCmd())
+	return cmd
+}
+
+func themeListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the built-in and user themes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return err
+			}
+			loadThemes(cfg)
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tSOURCE\tACTIVE")
+			for _, name := range styles.Names() {
+				theme, _ := styles.Find(name)
+				source := "built-in"
+				if theme.Path != "" {
+					source = theme.Path
+				}
+				active := ""
+				if name == cfg.UI.Theme {
+					active = "*"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", name, source, active)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func themePreviewCmd() *cobra.Command {
+	var background string
+
+	cmd := &cobra.Command{
+		Use:   "preview [theme]",
+		Short: "Render every component in a theme",
+		Long: `Renders the color roles and every styled component in a theme, by default
+the configured one, for the detected terminal background. Use --background
+to see the other variant.`,
+		Example: `  ewctl theme preview
+  ewctl theme preview solarized --background light`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return err
+			}
+			loadThemes(cfg)
+			name := cfg.UI.Theme
+			if len(args) > 0 {
+				name = args[0]
+			}
+			theme, ok := styles.Find(name)
+			if !ok {
+				return fmt.Errorf("unknown theme %q (choose %s)", name, strings.Join(styles.Names(), ", "))
+			}
+
+			dark := styles.HasDarkBackground()
+			switch background {
+			case "auto":
+			case "dark", "light":
+				dark = background == "dark"
+			default:
+				return fmt.Errorf("invalid background %q (choose auto, dark or light)", background)
+			}
+			colors := theme.Light
+			if dark {
+				colors = theme.Dark
+			}
+
+			fmt.Println(renderPreview(name, dark, colors))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&background, "background", "auto", "terminal background to preview: auto, dark or light")
+	return cmd
+}
+
+// loadThemes registers the user themes, warning about broken theme files
+// and an unknown ui.theme
+func loadThemes(cfg *config.Config) {
+	for _, p := range tui.CheckUI(cfg, tui.LoadThemes()) {
+		if p.Key == "ui.theme" {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", p.Message)
+		}
+	}
+}
+
+// renderPreview draws every component the TUI styles in colors
+func renderPreview(name string, dark bool, colors styles.ColorScheme) string {
+	s := styles.NewStylesFor(colors)
+	variant := "light"
+	if dark {
+		variant = "dark"
+	}
+
+	section := func(title string, body ...string) string {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			append([]string{"", s.Subtitle.Render(title)}, body...)...)
+	}
+
+	var swatches []string
+	for _, role := range styles.Roles {
+		color := *colors.Color(role)
+		swatch := lipgloss.NewStyle().Background(color).Render("      ")
+		swatches = append(swatches, fmt.Sprintf("%s %-13s %s", swatch, role, s.Muted.Render(string(color))))
+	}
+
+	t := table.New(
+		table.WithColumns([]table.Column{{Title: "Name", Width: 16}, {Title: "Phone", Width: 18}, {Title: "Forms", Width: 6}}),
+		table.WithRows([]table.Row{
+			{"Ana Souza", "+55 11 91234-5678", "3"},
+			{"Bruno Lima", "+55 21 99876-5432", "1"},
+			{"Carla Dias", "+55 31 98765-4321", "0"},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(4),
+	)
+	tableStyle := table.DefaultStyles()
+	tableStyle.Header = tableStyle.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(s.Colors.Border).
+		BorderBottom(true).
+		Bold(false)
+	tableStyle.Selected = tableStyle.Selected.
+		Foreground(s.Colors.Secondary).
+		Background(s.Colors.BgSecondary).
+		Bold(false)
+	t.SetStyles(tableStyle)
+	highlighted := components.HighlightTable(t, s.Highlight, func(row int) bool { return row == 2 })
+
+	var (
+		formName = "Contact form"
+		notify   = true
+		channel  = "whatsapp"
+	)
+	form := huh.NewForm(huh.NewGroup(
+		huh.NewInput().Title("Form name").Description("Shown in the forms list").Value(&formName),
+		huh.NewSelect[string]().Title("Channel").Options(
+			huh.NewOption("WhatsApp", "whatsapp"),
+			huh.NewOption("Email", "email"),
+		).Value(&channel),
+		huh.NewConfirm().Title("Notify contacts?").Value(&notify),
+	)).WithTheme(s.Form()).WithWidth(60).WithShowHelp(false)
+	form.Init()
+
+	confirm := components.NewConfirm(s)
+	confirm.Ask(true, components.Prompt{
+		Title:   "Delete 2 contacts?",
+		Action:  "Delete",
+		Details: []string{"Ana Souza", "Bruno Lima"},
+	}, nil)
+
+	button := s.Button.Copy()
+	blurred := s.Button.Copy().Background(s.Colors.BgSecondary)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		s.Header.Render(s.Title.Render(fmt.Sprintf("Theme %s (%s background)", name, variant))),
+		section("Colors", swatches...),
+		section("Typography",
+			s.Title.Render("Title"),
+			s.Subtitle.Render("Subtitle"),
+			s.Label.Render("Label")+" "+s.Text.Render("Text"),
+			s.Muted.Render("Muted text"),
+		),
+		section("Status",
+			s.Success.Render("✓ Success")+"  "+s.Warning.Render("⚠ Warning")+"  "+
+				s.Error.Render("✗ Error")+"  "+s.Info.Render("ℹ Info"),
+			s.StatusBar.Render("Status bar"),
+		),
+		section("Navigation",
+			s.Breadcrumb.Render("Dashboard › Contacts"),
+			components.RenderTabs(s, []string{"Edit", "History"}, 0),
+			s.MenuItem.Render("Menu item")+s.ActiveItem.Render("Active item"),
+		),
+		section("Table", highlighted, s.Highlight.Render("Changed since the last refresh")),
+		section("Controls",
+			lipgloss.JoinHorizontal(lipgloss.Top, button.Render("Save"), blurred.Render("Cancel")),
+			s.Input.Render("Input"),
+			s.Select.Render("Select")+" "+s.Checkbox.Render("[x] Checkbox"),
+			s.Badge.Render("3 forms"),
+			s.Spinner.Render("⣾")+" "+s.Muted.Render("Loading…"),
+			s.Progress.Render(components.Bar(7, 10, 20))+s.Muted.Render(strings.Repeat("░", 6)),
+			s.Help.Render("↑/↓: Navigate • Enter: Select • q: Back"),
+		),
+		section("Form", form.View()),
+		section("Dialog", confirm.View()),
+		s.Footer.Render("ewctl theme preview"),
+	)
+}
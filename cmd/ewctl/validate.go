@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/alert"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/probe"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui"
+)
+
+func configValidateCmd() *cobra.Command {
+	var (
+		runProbes bool
+		strict    bool
+		asJSON    bool
+		apiURL    string
+		zapiURL   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Report every problem with the configuration",
+		Long: `Checks the whole configuration and lists every problem instead of stopping
+at the first: missing values, malformed account, database and instance IDs,
+invalid URLs, unknown themes, negative durations, secret references that do
+not resolve, alert rules and notifiers, and profiles that are not defined.
+
+Warnings are problems ewctl works around, such as an unknown theme. With
+--probe each credential is also tried against the live services; point them
+at a mock with --cloudflare-api-url and --zapi-url.`,
+		Example: `  ewctl config validate
+  ewctl config validate --probe
+  ewctl config validate --probe --cloudflare-api-url http://localhost:9000/client/v4 --zapi-url http://localhost:9001`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return err
+			}
+			if apiURL != "" {
+				cfg.Cloudflare.APIURL = apiURL
+			}
+			if zapiURL != "" {
+				cfg.ZAPI.BaseURL = zapiURL
+			}
+
+			problems := append(cfg.Check(), tui.CheckUI(cfg, tui.LoadThemes())...)
+			// Rules and notifiers are checked by building them
+			if _, err := alert.NewEngine(cfg, nil); err != nil {
+				problems = append(problems, config.Problem{Key: "alerts", Message: err.Error()})
+			}
+
+			var results []probe.Result
+			if runProbes {
+				results = probe.All(cfg)
+			}
+
+			errorCount, warnings, failures := 0, 0, 0
+			for _, p := range problems {
+				if p.Warning {
+					warnings++
+				} else {
+					errorCount++
+				}
+			}
+			for _, result := range results {
+				if !result.OK && !result.Skipped {
+					failures++
+				}
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				err := enc.Encode(struct {
+					Path     string           `json:"path"`
+					Problems []config.Problem `json:"problems"`
+					Probes   []probe.Result   `json:"probes,omitempty"`
+				}{cfg.Path(), problems, results})
+				if err != nil {
+					return err
+				}
+			} else {
+				printValidation(cfg, problems, results, errorCount, warnings)
+			}
+
+			if errorCount > 0 || failures > 0 || (strict && warnings > 0) {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d errors, %d warnings, %d failed checks", errorCount, warnings, failures)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&runProbes, "probe", false, "also try the credentials against the live services")
+	cmd.Flags().BoolVar(&strict, "strict", false, "fail on warnings too")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print the problems and checks as JSON")
+	cmd.Flags().StringVar(&apiURL, "cloudflare-api-url", "", "probe this Cloudflare API endpoint instead (e.g. a mock)")
+	cmd.Flags().StringVar(&zapiURL, "zapi-url", "", "probe this Z-API endpoint instead (e.g. a mock)")
+
+	return cmd
+}
+
+func printValidation(cfg *config.Config, problems []config.Problem, results []probe.Result, errorCount, warnings int) {
+	path := cfg.Path()
+	if path == "" {
+		path = "defaults and environment"
+	}
+	fmt.Println(mutedStyle.Render("Checking " + path))
+
+	for _, p := range problems {
+		if p.Warning {
+			fmt.Println(warningStyle.Render(fmt.Sprintf("! %s %s", p.Key, p.Message)))
+		} else {
+			fmt.Println(failedStyle.Render(fmt.Sprintf("✗ %s %s", p.Key, p.Message)))
+		}
+	}
+	if len(problems) == 0 {
+		fmt.Println(successStyle.Render("✓ No problems found"))
+	} else {
+		fmt.Printf("%d errors, %d warnings\n", errorCount, warnings)
+	}
+
+	if len(results) > 0 {
+		fmt.Println()
+		for _, result := range results {
+			printProbe(result)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/diff"
+)
+
+func formsVersionCmds() []*cobra.Command {
+	history := &cobra.Command{
+		Use:   "history <form-id>",
+		Short: "List the saved versions of a form",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			versions, err := db.GetFormVersions(args[0])
+			if err != nil {
+				return err
+			}
+			if len(versions) == 0 {
+				fmt.Printf("No saved versions of %s\n", args[0])
+				return nil
+			}
+
+			for _, v := range versions {
+				note := ""
+				if v.Note != "" {
+					note = "  " + v.Note
+				}
+				fmt.Printf("v%-4d %s  %-12s %d fields, %d recipients%s\n",
+					v.Version,
+					v.CreatedAt.Local().Format("2006-01-02 15:04"),
+					v.Actor,
+					len(v.Form.Fields), len(v.Form.Numbers),
+					note,
+				)
+			}
+			return nil
+		},
+	}
+
+	diffCmd := &cobra.Command{
+		Use:   "diff <form-id> <version> [version]",
+		Short: "Show what changed between two versions of a form",
+		Long: `Compares two saved versions of a form. With a single version, it is
+compared against the latest one.`,
+		Example: "  ewctl forms diff contact-form v3 v5",
+		Args:    cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := parseVersion(args[1])
+			if err != nil {
+				return err
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+
+			a, err := db.GetFormVersion(args[0], from)
+			if err != nil {
+				return err
+			}
+
+			var b *database.FormVersion
+			if len(args) == 3 {
+				to, err := parseVersion(args[2])
+				if err != nil {
+					return err
+				}
+				if b, err = db.GetFormVersion(args[0], to); err != nil {
+					return err
+				}
+			} else {
+				versions, err := db.GetFormVersions(args[0])
+				if err != nil {
+					return err
+				}
+				b = &versions[0]
+			}
+
+			fmt.Printf("--- v%d (%s)\n+++ v%d (%s)\n",
+				a.Version, a.CreatedAt.Local().Format("2006-01-02 15:04"),
+				b.Version, b.CreatedAt.Local().Format("2006-01-02 15:04"))
+			lines := diff.Lines(a.Lines(), b.Lines())
+			if !diff.Changed(lines) {
+				fmt.Println("No differences")
+				return nil
+			}
+			for _, line := range lines {
+				switch line.Op {
+				case diff.Equal:
+					fmt.Println("  " + line.Left)
+				case diff.Delete:
+					fmt.Println("- " + line.Left)
+				case diff.Insert:
+					fmt.Println("+ " + line.Right)
+				case diff.Change:
+					fmt.Println("- " + line.Left)
+					fmt.Println("+ " + line.Right)
+				}
+			}
+			return nil
+		},
+	}
+
+	rollback := &cobra.Command{
+		Use:   "rollback <form-id> <version>",
+		Short: "Restore a form's fields and recipients from a saved version",
+		Long: `Restores the name, description, fields and recipients saved in a
+version. The rollback is saved as a new version, so it can be undone by
+rolling back again.`,
+		Example: "  ewctl forms rollback contact-form v3",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := parseVersion(args[1])
+			if err != nil {
+				return err
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			if err := db.RollbackForm(args[0], version); err != nil {
+				return err
+			}
+
+			fmt.Printf("↩  Rolled %s back to v%d\n", args[0], version)
+			return nil
+		},
+	}
+
+	return []*cobra.Command{history, diffCmd, rollback}
+}
+
+// parseVersion accepts "v3" or "3"
+func parseVersion(value string) (int, error) {
+	version, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(value), "v"))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid version %q (use v1, v2, ...)", value)
+	}
+	return version, nil
+}
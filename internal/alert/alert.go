@@ -0,0 +1,239 @@
+// Package alert evaluates alerting rules against D1 and Z-API and sends
+// firing and resolved alerts through the configured notifiers
+package alert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+// Alert states
+const (
+	StateFiring   = "firing"
+	StateResolved = "resolved"
+)
+
+// Alert is a rule starting, continuing or stopping to fire
+type Alert struct {
+	Rule    string    `json:"rule"`
+	Type    string    `json:"type"`
+	State   string    `json:"state"`
+	Summary string    `json:"summary"`
+	Since   time.Time `json:"since"`
+	Time    time.Time `json:"time"`
+}
+
+// Title is a one-line heading for the alert
+func (a Alert) Title() string {
+	if a.State == StateResolved {
+		return fmt.Sprintf("✅ Resolved: %s", a.Rule)
+	}
+	return fmt.Sprintf("🚨 Firing: %s", a.Rule)
+}
+
+// Text is the alert as a short plain-text message
+func (a Alert) Text() string {
+	return fmt.Sprintf("%s\n%s\nSince %s", a.Title(), a.Summary, a.Since.Local().Format("2006-01-02 15:04:05"))
+}
+
+// Result is the outcome of checking a rule once
+type Result struct {
+	Firing  bool
+	Summary string
+}
+
+// Rule is a condition that is checked periodically
+type Rule interface {
+	Name() string
+	Type() string
+	// Check reports whether the condition holds at now. An error means the
+	// rule could not be evaluated and its state is left unchanged.
+	Check(now time.Time) (Result, error)
+}
+
+// Notifier delivers alerts somewhere people will see them
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// ruleState tracks one rule between evaluations
+type ruleState struct {
+	rule      Rule
+	notifiers []Notifier
+	firing    bool
+	since     time.Time
+	lastSent  time.Time
+}
+
+// Engine evaluates rules and notifies on state changes
+type Engine struct {
+	rules []*ruleState
+	// Repeat re-sends still firing alerts this often; zero disables it
+	Repeat time.Duration
+	// DryRun reports alerts through OnAlert without notifying anyone
+	DryRun bool
+	// OnAlert is called for every alert, before it is sent
+	OnAlert func(Alert)
+}
+
+// NewEngine builds the rules and notifiers of the alerts settings
+func NewEngine(cfg *config.Config, db *database.Client) (*Engine, error) {
+	sender := zapi.NewClient(cfg.ZAPI.InstanceID, cfg.ZAPI.InstanceToken, cfg.ZAPI.ClientToken).WithBaseURL(cfg.ZAPI.BaseURL)
+
+	notifiers := make(map[string]Notifier)
+	var all []Notifier
+	for _, nc := range cfg.Alerts.Notifiers {
+		notifier, err := NewNotifier(nc, sender)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := notifiers[notifier.Name()]; ok {
+			return nil, fmt.Errorf("duplicate notifier name %q", notifier.Name())
+		}
+		notifiers[notifier.Name()] = notifier
+		all = append(all, notifier)
+	}
+
+	engine := &Engine{Repeat: cfg.Alerts.Repeat}
+	seen := make(map[string]bool)
+	for _, rc := range cfg.Alerts.Rules {
+		rule, err := NewRule(rc, db, sender)
+		if err != nil {
+			return nil, err
+		}
+		if seen[rule.Name()] {
+			return nil, fmt.Errorf("duplicate rule name %q", rule.Name())
+		}
+		seen[rule.Name()] = true
+
+		state := &ruleState{rule: rule, notifiers: all}
+		if len(rc.Notify) > 0 {
+			state.notifiers = nil
+			for _, name := range rc.Notify {
+				notifier, ok := notifiers[name]
+				if !ok {
+					return nil, fmt.Errorf("rule %s: unknown notifier %q", rule.Name(), name)
+				}
+				state.notifiers = append(state.notifiers, notifier)
+			}
+		}
+		engine.rules = append(engine.rules, state)
+	}
+	return engine, nil
+}
+
+// Rules returns the names of the rules the engine evaluates
+func (e *Engine) Rules() []string {
+	names := make([]string, len(e.rules))
+	for i, state := range e.rules {
+		names[i] = state.rule.Name()
+	}
+	return names
+}
+
+// Evaluate checks every rule once and notifies about the ones that
+// started or stopped firing, or are due a repeat. Rules that cannot be
+// checked are logged and keep their state.
+func (e *Engine) Evaluate(ctx context.Context, now time.Time) []Alert {
+	var alerts []Alert
+	for _, state := range e.rules {
+		result, err := state.rule.Check(now)
+		if err != nil {
+			log.Error("Failed to check alert rule", "rule", state.rule.Name(), "error", err)
+			continue
+		}
+
+		alert := Alert{
+			Rule:    state.rule.Name(),
+			Type:    state.rule.Type(),
+			Summary: result.Summary,
+			Time:    now,
+		}
+		switch {
+		case result.Firing && !state.firing:
+			state.firing = true
+			state.since = now
+			alert.State = StateFiring
+		case result.Firing && e.Repeat > 0 && now.Sub(state.lastSent) >= e.Repeat:
+			alert.State = StateFiring
+		case !result.Firing && state.firing:
+			state.firing = false
+			alert.State = StateResolved
+		default:
+			continue
+		}
+		alert.Since = state.since
+
+		state.lastSent = now
+		alerts = append(alerts, alert)
+		if e.OnAlert != nil {
+			e.OnAlert(alert)
+		}
+		if !e.DryRun {
+			notifyAll(ctx, state.notifiers, alert)
+		}
+	}
+	return alerts
+}
+
+// Run evaluates the rules every interval until ctx is done
+func (e *Engine) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		e.Evaluate(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Test sends a test alert through every notifier of the engine's rules
+// and returns the failures by notifier name
+func (e *Engine) Test(ctx context.Context) map[string]error {
+	now := time.Now()
+	alert := Alert{
+		Rule:    "test",
+		Type:    "test",
+		State:   StateFiring,
+		Summary: "This is a test alert from ewctl monitor run --test",
+		Since:   now,
+		Time:    now,
+	}
+
+	failures := make(map[string]error)
+	sent := make(map[string]bool)
+	for _, state := range e.rules {
+		for _, notifier := range state.notifiers {
+			if sent[notifier.Name()] {
+				continue
+			}
+			sent[notifier.Name()] = true
+			if err := notifier.Notify(ctx, alert); err != nil {
+				failures[notifier.Name()] = err
+			}
+		}
+	}
+	return failures
+}
+
+func notifyAll(ctx context.Context, notifiers []Notifier, alert Alert) {
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(ctx, alert); err != nil {
+			log.Error("Failed to send alert", "rule", alert.Rule, "notifier", notifier.Name(), "error", err)
+		}
+	}
+}
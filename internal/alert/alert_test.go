@@ -0,0 +1,160 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+)
+
+// fakeRule returns the next queued result on each check
+type fakeRule struct {
+	baseRule
+	results []Result
+	errs    []error
+}
+
+func (r *fakeRule) Check(now time.Time) (Result, error) {
+	result, err := r.results[0], r.errs[0]
+	r.results, r.errs = r.results[1:], r.errs[1:]
+	return result, err
+}
+
+// fakeNotifier records the alerts it was asked to send
+type fakeNotifier struct {
+	name string
+	sent []Alert
+	err  error
+}
+
+func (n *fakeNotifier) Name() string { return n.name }
+
+func (n *fakeNotifier) Notify(ctx context.Context, alert Alert) error {
+	n.sent = append(n.sent, alert)
+	return n.err
+}
+
+func newFakeEngine(firing []bool, errs []error) (*Engine, *fakeNotifier) {
+	rule := &fakeRule{baseRule: baseRule{name: "down", kind: RuleZAPIDown}, errs: errs}
+	for _, f := range firing {
+		rule.results = append(rule.results, Result{Firing: f, Summary: "summary"})
+	}
+	notifier := &fakeNotifier{name: "ops"}
+	return &Engine{rules: []*ruleState{{rule: rule, notifiers: []Notifier{notifier}}}}, notifier
+}
+
+func TestEvaluateNotifiesOnStateChanges(t *testing.T) {
+	checkErr := errors.New("d1 timeout")
+	engine, notifier := newFakeEngine(
+		[]bool{false, true, true, false, true, false},
+		[]error{nil, nil, nil, checkErr, nil, nil},
+	)
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	var states []string
+	for i := range 6 {
+		for _, alert := range engine.Evaluate(context.Background(), start.Add(time.Duration(i)*time.Minute)) {
+			states = append(states, alert.State)
+		}
+	}
+
+	// A failed check keeps the rule firing instead of resolving it
+	if len(states) != 2 || states[0] != StateFiring || states[1] != StateResolved {
+		t.Fatalf("alerts = %v, want one firing and one resolved", states)
+	}
+	if len(notifier.sent) != 2 {
+		t.Fatalf("%d alerts sent, want 2", len(notifier.sent))
+	}
+	if resolved := notifier.sent[1]; !resolved.Since.Equal(start.Add(time.Minute)) || resolved.Title() != "✅ Resolved: down" {
+		t.Errorf("resolved alert = %+v, want it to keep when the rule started firing", resolved)
+	}
+}
+
+func TestEvaluateRepeatsStillFiringAlerts(t *testing.T) {
+	engine, notifier := newFakeEngine([]bool{true, true, true, true}, make([]error, 4))
+	engine.Repeat = 10 * time.Minute
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	for _, minutes := range []int{0, 5, 10, 15} {
+		engine.Evaluate(context.Background(), start.Add(time.Duration(minutes)*time.Minute))
+	}
+	if len(notifier.sent) != 2 || !notifier.sent[1].Time.Equal(start.Add(10*time.Minute)) {
+		t.Errorf("sent %d alerts, want the first and one repeat after 10m", len(notifier.sent))
+	}
+	if !notifier.sent[1].Since.Equal(start) {
+		t.Errorf("repeat Since = %v, want when the rule started firing", notifier.sent[1].Since)
+	}
+}
+
+func TestEvaluateDryRunOnlyReports(t *testing.T) {
+	engine, notifier := newFakeEngine([]bool{true}, []error{nil})
+	engine.DryRun = true
+	var reported []Alert
+	engine.OnAlert = func(a Alert) { reported = append(reported, a) }
+
+	engine.Evaluate(context.Background(), time.Now())
+	if len(reported) != 1 || len(notifier.sent) != 0 {
+		t.Errorf("reported %d and sent %d alerts, want 1 and 0", len(reported), len(notifier.sent))
+	}
+}
+
+func TestNewEngineRoutesRulesToNotifiers(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Alerts.Notifiers = []config.NotifierConfig{
+		{Name: "ops", Type: NotifierWebhook, URL: "https://example.com/hook"},
+		{Type: NotifierSlack, URL: "https://hooks.slack.com/x"},
+	}
+	cfg.Alerts.Rules = []config.AlertRule{
+		{Type: RuleD1Unreachable},
+		{Type: RuleNoSubmissions, FormID: "contact", For: time.Hour, Notify: []string{"slack"}},
+	}
+
+	engine, err := NewEngine(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := engine.Rules(); len(names) != 2 || names[0] != "d1_unreachable" || names[1] != "no_submissions:contact" {
+		t.Errorf("Rules = %v, want names derived from type and form", names)
+	}
+	if n := len(engine.rules[0].notifiers); n != 2 {
+		t.Errorf("rule without notify has %d notifiers, want every notifier", n)
+	}
+	if n := engine.rules[1].notifiers; len(n) != 1 || n[0].Name() != "slack" {
+		t.Errorf("rule notifying slack routed to %v", n)
+	}
+
+	for name, mutate := range map[string]func(*config.Config){
+		"unknown notifier": func(c *config.Config) { c.Alerts.Rules[1].Notify = []string{"pager"} },
+		"duplicate rule":   func(c *config.Config) { c.Alerts.Rules[1] = config.AlertRule{Type: RuleD1Unreachable} },
+		"duplicate notifier": func(c *config.Config) {
+			c.Alerts.Notifiers[1] = config.NotifierConfig{Name: "ops", Type: NotifierSlack, URL: "https://hooks.slack.com/x"}
+		},
+	} {
+		bad := config.DefaultConfig()
+		bad.Alerts.Notifiers = append([]config.NotifierConfig(nil), cfg.Alerts.Notifiers...)
+		bad.Alerts.Rules = append([]config.AlertRule(nil), cfg.Alerts.Rules...)
+		mutate(bad)
+		if _, err := NewEngine(bad, nil); err == nil {
+			t.Errorf("NewEngine with a %s succeeded", name)
+		}
+	}
+}
+
+func TestEngineTestSendsOncePerNotifier(t *testing.T) {
+	shared := &fakeNotifier{name: "ops"}
+	failing := &fakeNotifier{name: "pager", err: errors.New("unauthorized")}
+	engine := &Engine{rules: []*ruleState{
+		{rule: &fakeRule{baseRule: baseRule{name: "a"}}, notifiers: []Notifier{shared}},
+		{rule: &fakeRule{baseRule: baseRule{name: "b"}}, notifiers: []Notifier{shared, failing}},
+	}}
+
+	failures := engine.Test(context.Background())
+	if len(shared.sent) != 1 || len(failing.sent) != 1 {
+		t.Errorf("sent %d and %d test alerts, want one each", len(shared.sent), len(failing.sent))
+	}
+	if len(failures) != 1 || failures["pager"] == nil {
+		t.Errorf("failures = %v, want only pager", failures)
+	}
+}
@@ -0,0 +1,225 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+// Notifier types
+const (
+	NotifierSMTP     = "smtp"
+	NotifierWebhook  = "webhook"
+	NotifierSlack    = "slack"
+	NotifierWhatsApp = "whatsapp"
+)
+
+// NotifierTypes lists the supported notifier types
+var NotifierTypes = []string{NotifierSMTP, NotifierWebhook, NotifierSlack, NotifierWhatsApp}
+
+// httpClient posts webhook and Slack alerts
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// smtpTimeout bounds connecting to and talking with the mail server
+const smtpTimeout = 15 * time.Second
+
+// NewNotifier builds a notifier from its settings; WhatsApp alerts are
+// sent through sender
+func NewNotifier(nc config.NotifierConfig, sender *zapi.Client) (Notifier, error) {
+	name := nc.Name
+	if name == "" {
+		name = nc.Type
+	}
+
+	switch nc.Type {
+	case NotifierSMTP:
+		if nc.Host == "" || nc.From == "" || len(nc.To) == 0 {
+			return nil, fmt.Errorf("notifier %s: smtp needs host, from and to", name)
+		}
+		port := nc.Port
+		if port == 0 {
+			port = 587
+		}
+		return &smtpNotifier{name: name, host: nc.Host, port: port, username: nc.Username,
+			password: nc.Password, from: nc.From, to: nc.To}, nil
+
+	case NotifierWebhook, NotifierSlack:
+		if nc.URL == "" {
+			return nil, fmt.Errorf("notifier %s: %s needs a url", name, nc.Type)
+		}
+		return &webhookNotifier{name: name, url: nc.URL, headers: nc.Headers, slack: nc.Type == NotifierSlack}, nil
+
+	case NotifierWhatsApp:
+		if nc.Phone == "" {
+			return nil, fmt.Errorf("notifier %s: whatsapp needs the phone to alert", name)
+		}
+		if !sender.Configured() {
+			return nil, fmt.Errorf("notifier %s: whatsapp needs zapi.instance_id and zapi.instance_token", name)
+		}
+		return &whatsAppNotifier{name: name, phone: nc.Phone, sender: sender}, nil
+	}
+	return nil, fmt.Errorf("notifier %s: unknown type %q (use %v)", name, nc.Type, NotifierTypes)
+}
+
+// smtpNotifier emails alerts. Port 465 uses implicit TLS; other ports
+// upgrade with STARTTLS when the server offers it.
+type smtpNotifier struct {
+	name     string
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+func (n *smtpNotifier) Name() string { return n.name }
+
+func (n *smtpNotifier) Notify(ctx context.Context, alert Alert) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", alert.Title()))
+	fmt.Fprintf(&msg, "Date: %s\r\n", alert.Time.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(alert.Text(), "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	addr := net.JoinHostPort(n.host, strconv.Itoa(n.port))
+	netDialer := &net.Dialer{Timeout: smtpTimeout}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if n.port == 465 {
+		dialer := &tls.Dialer{NetDialer: netDialer, Config: &tls.Config{ServerName: n.host}}
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = netDialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+
+	// Bound the whole session, and end it early when ctx is cancelled
+	deadline := time.Now().Add(smtpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if n.port != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+				return fmt.Errorf("failed to start TLS: %w", err)
+			}
+		}
+	}
+	if n.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.username, n.password, n.host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+	if err := client.Mail(n.from); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	for _, to := range n.to {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("failed to add recipient %s: %w", to, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if _, err := w.Write(msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return client.Quit()
+}
+
+// webhookNotifier posts alerts as JSON, or as a Slack-compatible
+// {"text": ...} message
+type webhookNotifier struct {
+	name    string
+	url     string
+	headers map[string]string
+	slack   bool
+}
+
+func (n *webhookNotifier) Name() string { return n.name }
+
+func (n *webhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	var payload interface{} = alert
+	if n.slack {
+		payload = map[string]string{"text": fmt.Sprintf("*%s*\n%s", alert.Title(), alert.Summary)}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range n.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
+
+// whatsAppNotifier messages a monitor number through Z-API. It cannot
+// report Z-API itself being down, so pair it with another notifier.
+type whatsAppNotifier struct {
+	name   string
+	phone  string
+	sender *zapi.Client
+}
+
+func (n *whatsAppNotifier) Name() string { return n.name }
+
+func (n *whatsAppNotifier) Notify(ctx context.Context, alert Alert) error {
+	if _, err := n.sender.SendText(n.phone, alert.Text()); err != nil {
+		return fmt.Errorf("failed to send WhatsApp alert: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package alert
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+)
+
+var testAlert = Alert{
+	Rule:    "zapi_down",
+	Type:    RuleZAPIDown,
+	State:   StateFiring,
+	Summary: "Z-API has been disconnected for 6m 0s",
+	Since:   time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+	Time:    time.Date(2026, 3, 10, 12, 6, 0, 0, time.UTC),
+}
+
+func TestNewNotifierValidates(t *testing.T) {
+	for _, nc := range []config.NotifierConfig{
+		{Type: NotifierSMTP, Host: "smtp.example.com", From: "ops@example.com"},
+		{Type: NotifierWebhook},
+		{Type: NotifierWhatsApp, Phone: "5511999990001"},
+		{Type: "pager"},
+	} {
+		if _, err := NewNotifier(nc, unconfigured); err == nil {
+			t.Errorf("NewNotifier(%+v) succeeded", nc)
+		}
+	}
+
+	n, err := NewNotifier(config.NotifierConfig{Type: NotifierSMTP, Host: "smtp.example.com", From: "a@example.com", To: []string{"b@example.com"}}, unconfigured)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Name() != NotifierSMTP || n.(*smtpNotifier).port != 587 {
+		t.Errorf("smtp notifier = %+v, want it named after its type on port 587", n)
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var bodies []map[string]interface{}
+	var auth string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		auth = r.Header.Get("Authorization")
+		w.WriteHeader(status)
+		io.WriteString(w, "nope")
+	}))
+	defer server.Close()
+
+	webhook, err := NewNotifier(config.NotifierConfig{Type: NotifierWebhook, URL: server.URL, Headers: map[string]string{"Authorization": "Bearer x"}}, unconfigured)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slack, err := NewNotifier(config.NotifierConfig{Type: NotifierSlack, URL: server.URL}, unconfigured)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := webhook.Notify(context.Background(), testAlert); err != nil {
+		t.Fatal(err)
+	}
+	if bodies[0]["rule"] != "zapi_down" || bodies[0]["state"] != StateFiring || auth != "Bearer x" {
+		t.Errorf("webhook posted %v with Authorization %q", bodies[0], auth)
+	}
+	if err := slack.Notify(context.Background(), testAlert); err != nil {
+		t.Fatal(err)
+	}
+	if text, _ := bodies[1]["text"].(string); text != "*🚨 Firing: zapi_down*\n"+testAlert.Summary || len(bodies[1]) != 1 {
+		t.Errorf("slack posted %v, want only a text message", bodies[1])
+	}
+
+	status = http.StatusForbidden
+	if err := webhook.Notify(context.Background(), testAlert); err == nil || !strings.Contains(err.Error(), "403: nope") {
+		t.Errorf("Notify on a 403 = %v, want the status and body", err)
+	}
+}
+
+// fakeSMTP accepts one session on a local port and returns the message it
+// received on the channel
+func fakeSMTP(t *testing.T) (int, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+		reply("220 fake ESMTP")
+		var data strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"):
+				reply("250 fake")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				received <- data.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSMTPNotifierEncodesTheSubject(t *testing.T) {
+	port, received := fakeSMTP(t)
+	n := &smtpNotifier{name: "mail", host: "127.0.0.1", port: port, from: "ops@example.com", to: []string{"a@example.com", "b@example.com"}}
+
+	if err := n.Notify(context.Background(), testAlert); err != nil {
+		t.Fatal(err)
+	}
+	msg := <-received
+	if !strings.Contains(msg, "Subject: =?utf-8?q?") || strings.Contains(msg, "Subject: 🚨") {
+		t.Errorf("subject not Q-encoded:\n%s", msg)
+	}
+	if !strings.Contains(msg, "To: a@example.com, b@example.com\r\n") || !strings.Contains(msg, testAlert.Summary+"\r\n") {
+		t.Errorf("message missing recipients or summary:\n%s", msg)
+	}
+}
+
+func TestSMTPNotifierStopsWithTheContext(t *testing.T) {
+	// A server that accepts but never greets
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		if conn, err := ln.Accept(); err == nil {
+			defer conn.Close()
+			io.Copy(io.Discard, conn)
+		}
+	}()
+	port := ln.Addr().(*net.TCPAddr).Port
+	n := &smtpNotifier{name: "mail", host: "127.0.0.1", port: port, from: "ops@example.com", to: []string{"a@example.com"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := n.Notify(ctx, testAlert); err == nil {
+		t.Fatal("Notify succeeded against a silent server")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Notify took %v, want it to give up with the context", elapsed)
+	}
+}
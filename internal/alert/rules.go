@@ -0,0 +1,216 @@
+package alert
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/monitor"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+// Rule types
+const (
+	RuleZAPIDown      = "zapi_down"
+	RuleFailureRate   = "failure_rate"
+	RuleNoSubmissions = "no_submissions"
+	RuleD1Unreachable = "d1_unreachable"
+)
+
+// RuleTypes lists the supported rule types
+var RuleTypes = []string{RuleZAPIDown, RuleFailureRate, RuleNoSubmissions, RuleD1Unreachable}
+
+// NewRule builds a rule from its settings, filling in defaults
+func NewRule(rc config.AlertRule, db *database.Client, sender *zapi.Client) (Rule, error) {
+	base := baseRule{name: rc.Name, kind: rc.Type}
+	if base.name == "" {
+		base.name = rc.Type
+		if rc.FormID != "" {
+			base.name += ":" + rc.FormID
+		}
+	}
+
+	switch rc.Type {
+	case RuleZAPIDown:
+		after := rc.For
+		if after <= 0 {
+			after = 5 * time.Minute
+		}
+		return &zapiDownRule{baseRule: base, after: after, db: db, sender: sender}, nil
+
+	case RuleFailureRate:
+		rule := &failureRateRule{
+			baseRule:      base,
+			window:        rc.For,
+			threshold:     rc.Threshold,
+			minDeliveries: rc.MinDeliveries,
+			formID:        rc.FormID,
+			db:            db,
+		}
+		if rule.window <= 0 {
+			rule.window = time.Hour
+		}
+		if rule.threshold <= 0 {
+			rule.threshold = 20
+		}
+		if rule.minDeliveries <= 0 {
+			rule.minDeliveries = 5
+		}
+		if rule.threshold > 100 {
+			return nil, fmt.Errorf("rule %s: threshold is a percentage and must be at most 100", base.name)
+		}
+		return rule, nil
+
+	case RuleNoSubmissions:
+		if rc.For <= 0 {
+			return nil, fmt.Errorf("rule %s: set for to how long the form may go without submissions", base.name)
+		}
+		return &noSubmissionsRule{baseRule: base, after: rc.For, formID: rc.FormID, db: db}, nil
+
+	case RuleD1Unreachable:
+		return &d1Rule{baseRule: base, db: db}, nil
+	}
+	return nil, fmt.Errorf("rule %s: unknown type %q (use %v)", base.name, rc.Type, RuleTypes)
+}
+
+type baseRule struct {
+	name string
+	kind string
+}
+
+func (r baseRule) Name() string { return r.name }
+func (r baseRule) Type() string { return r.kind }
+
+// zapiDownRule fires when Z-API has been disconnected for a while. It asks
+// Z-API directly when credentials are configured and otherwise reads the
+// state the worker's cron records.
+type zapiDownRule struct {
+	baseRule
+	after  time.Duration
+	db     *database.Client
+	sender *zapi.Client
+	// downSince is when a direct check first saw the instance down
+	downSince time.Time
+}
+
+func (r *zapiDownRule) Check(now time.Time) (Result, error) {
+	var since time.Time
+	var reason string
+
+	if r.sender.Configured() {
+		status, err := r.sender.Status()
+		switch {
+		case err != nil:
+			reason = err.Error()
+		case !status.Connected:
+			reason = status.Error
+		default:
+			r.downSince = time.Time{}
+			return Result{Summary: "Z-API is connected"}, nil
+		}
+		if r.downSince.IsZero() {
+			r.downSince = now
+		}
+		since = r.downSince
+	} else {
+		state, err := r.db.GetMonitoringState(database.MonitorZAPI)
+		if err != nil {
+			return Result{}, err
+		}
+		if state == nil || state.Connected {
+			return Result{Summary: "Z-API is connected"}, nil
+		}
+		since = state.LastChanged
+		reason = database.StatusError(state.Status)
+	}
+
+	down := now.Sub(since)
+	summary := fmt.Sprintf("Z-API has been disconnected for %s (since %s)",
+		monitor.FormatDuration(down), since.Local().Format("2006-01-02 15:04"))
+	if reason != "" {
+		summary += ": " + reason
+	}
+	return Result{Firing: down >= r.after, Summary: summary}, nil
+}
+
+// failureRateRule fires when too many recent deliveries did not reach
+// every recipient
+type failureRateRule struct {
+	baseRule
+	window        time.Duration
+	threshold     float64
+	minDeliveries int
+	formID        string
+	db            *database.Client
+}
+
+func (r *failureRateRule) Check(now time.Time) (Result, error) {
+	counts, err := r.db.GetDeliveriesByHour(now.Add(-r.window), now, r.formID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	total, success := 0, 0
+	for _, count := range counts {
+		total += count.Count
+		success += count.Success
+	}
+	if total == 0 {
+		return Result{Summary: "No deliveries in the last " + monitor.FormatDuration(r.window)}, nil
+	}
+
+	failed := total - success
+	rate := float64(failed) / float64(total) * 100
+	summary := fmt.Sprintf("Delivery failure rate is %.1f%% (%d of %d) over the last %s",
+		rate, failed, total, monitor.FormatDuration(r.window))
+	if r.formID != "" {
+		summary += " on form " + r.formID
+	}
+	return Result{Firing: total >= r.minDeliveries && rate > r.threshold, Summary: summary}, nil
+}
+
+// noSubmissionsRule fires when a form, or every form, has gone quiet
+type noSubmissionsRule struct {
+	baseRule
+	after  time.Duration
+	formID string
+	db     *database.Client
+}
+
+func (r *noSubmissionsRule) Check(now time.Time) (Result, error) {
+	last, err := r.db.GetLastSubmissionTime(r.formID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if last == nil {
+		summary := "No form has ever received a submission"
+		if r.formID != "" {
+			summary = "Form " + r.formID + " has never received a submission"
+		}
+		return Result{Firing: true, Summary: summary}, nil
+	}
+
+	quiet := now.Sub(*last)
+	summary := fmt.Sprintf("No form has received a submission for %s (last at %s)",
+		monitor.FormatDuration(quiet), last.Local().Format("2006-01-02 15:04"))
+	if r.formID != "" {
+		summary = fmt.Sprintf("Form %s has had no submissions for %s (last at %s)",
+			r.formID, monitor.FormatDuration(quiet), last.Local().Format("2006-01-02 15:04"))
+	}
+	return Result{Firing: quiet >= r.after, Summary: summary}, nil
+}
+
+// d1Rule fires when the D1 database cannot be queried
+type d1Rule struct {
+	baseRule
+	db *database.Client
+}
+
+func (r *d1Rule) Check(now time.Time) (Result, error) {
+	if err := r.db.Ping(); err != nil {
+		return Result{Firing: true, Summary: err.Error()}, nil
+	}
+	return Result{Summary: "D1 is reachable"}, nil
+}
@@ -0,0 +1,176 @@
+package alert
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database/d1test"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+var unconfigured = zapi.NewClient("", "", "")
+
+func newDB(t *testing.T) *database.Client {
+	t.Helper()
+	db, err := database.NewClient(d1test.New(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.InitSchema(); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func exec(t *testing.T, db *database.Client, sql string, params ...interface{}) {
+	t.Helper()
+	if _, err := db.Query(sql, params...); err != nil {
+		t.Fatalf("%s: %v", sql, err)
+	}
+}
+
+func sqlTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+func check(t *testing.T, rule Rule, now time.Time) Result {
+	t.Helper()
+	result, err := rule.Check(now)
+	if err != nil {
+		t.Fatalf("%s: %v", rule.Name(), err)
+	}
+	return result
+}
+
+func TestNewRuleDefaultsAndErrors(t *testing.T) {
+	rule, err := NewRule(config.AlertRule{Type: RuleFailureRate}, nil, unconfigured)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr := rule.(*failureRateRule)
+	if fr.window != time.Hour || fr.threshold != 20 || fr.minDeliveries != 5 {
+		t.Errorf("failure rate defaults = %v, %v%%, %d", fr.window, fr.threshold, fr.minDeliveries)
+	}
+
+	for _, rc := range []config.AlertRule{
+		{Type: RuleFailureRate, Threshold: 150},
+		{Type: RuleNoSubmissions},
+		{Type: "disk_full"},
+	} {
+		if _, err := NewRule(rc, nil, unconfigured); err == nil {
+			t.Errorf("NewRule(%+v) succeeded", rc)
+		}
+	}
+}
+
+func TestFailureRateRule(t *testing.T) {
+	db := newDB(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	exec(t, db, "INSERT INTO forms (id, name) VALUES ('contact', 'Contact'), ('quote', 'Quote')")
+	for i, status := range []string{"success", "resent", "failed", "partial"} {
+		exec(t, db, "INSERT INTO webhook_logs (form_id, status, created_at) VALUES ('contact', ?, ?)",
+			status, sqlTime(now.Add(-time.Duration(i+1)*time.Minute)))
+	}
+	// Outside the window, and on another form
+	exec(t, db, "INSERT INTO webhook_logs (form_id, status, created_at) VALUES ('contact', 'failed', ?)", sqlTime(now.Add(-2*time.Hour)))
+	exec(t, db, "INSERT INTO webhook_logs (form_id, status, created_at) VALUES ('quote', 'failed', ?)", sqlTime(now.Add(-time.Minute)))
+
+	rule, err := NewRule(config.AlertRule{Type: RuleFailureRate, FormID: "contact", Threshold: 40, MinDeliveries: 4}, db, unconfigured)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := check(t, rule, now)
+	if !result.Firing || !strings.Contains(result.Summary, "50.0% (2 of 4)") {
+		t.Errorf("result = %+v, want firing at 50%% of the form's 4 recent deliveries", result)
+	}
+
+	rule, _ = NewRule(config.AlertRule{Type: RuleFailureRate, FormID: "contact", Threshold: 40, MinDeliveries: 5}, db, unconfigured)
+	if check(t, rule, now).Firing {
+		t.Error("fired below the minimum number of deliveries")
+	}
+}
+
+func TestNoSubmissionsRule(t *testing.T) {
+	db := newDB(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	rule, err := NewRule(config.AlertRule{Type: RuleNoSubmissions, FormID: "contact", For: time.Hour}, db, unconfigured)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result := check(t, rule, now); !result.Firing || !strings.Contains(result.Summary, "never") {
+		t.Errorf("result with no submissions = %+v, want firing", result)
+	}
+
+	exec(t, db, "INSERT INTO submissions (form_id, fields, created_at) VALUES ('contact', '{}', ?)", sqlTime(now.Add(-30*time.Minute)))
+	if check(t, rule, now).Firing {
+		t.Error("fired 30m after the last submission with a 1h limit")
+	}
+	if result := check(t, rule, now.Add(time.Hour)); !result.Firing || !strings.Contains(result.Summary, "1h 30m") {
+		t.Errorf("result = %+v, want firing after 1h 30m of quiet", result)
+	}
+}
+
+func TestZAPIDownRuleReadsWorkerState(t *testing.T) {
+	db := newDB(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	rule, err := NewRule(config.AlertRule{Type: RuleZAPIDown, For: 10 * time.Minute}, db, unconfigured)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check(t, rule, now).Firing {
+		t.Error("fired before the worker recorded any state")
+	}
+	exec(t, db, "INSERT INTO monitoring_state (key, connected, status_json, last_changed) VALUES (?, 0, ?, ?)",
+		database.MonitorZAPI, `{"error":"phone offline"}`, sqlTime(now.Add(-5*time.Minute)))
+	if check(t, rule, now).Firing {
+		t.Error("fired 5m into an outage with a 10m limit")
+	}
+	if result := check(t, rule, now.Add(5*time.Minute)); !result.Firing || !strings.HasSuffix(result.Summary, ": phone offline") {
+		t.Errorf("result = %+v, want firing with the recorded error", result)
+	}
+}
+
+func TestZAPIDownRuleAsksZAPIWhenConfigured(t *testing.T) {
+	var connected atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/status") {
+			http.NotFound(w, r)
+			return
+		}
+		if connected.Load() {
+			w.Write([]byte(`{"connected":true}`))
+		} else {
+			w.Write([]byte(`{"connected":false,"error":"You are not connected."}`))
+		}
+	}))
+	defer server.Close()
+	sender := zapi.NewClient("instance", "token", "").WithBaseURL(server.URL)
+
+	rule, err := NewRule(config.AlertRule{Type: RuleZAPIDown, For: 10 * time.Minute}, nil, sender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if check(t, rule, start).Firing {
+		t.Error("fired on the first check that saw Z-API down")
+	}
+	if result := check(t, rule, start.Add(10*time.Minute)); !result.Firing || !strings.Contains(result.Summary, "You are not connected.") {
+		t.Errorf("result = %+v, want firing 10m after the first failed check", result)
+	}
+
+	// Reconnecting resets when the outage started
+	connected.Store(true)
+	check(t, rule, start.Add(11*time.Minute))
+	connected.Store(false)
+	if check(t, rule, start.Add(12*time.Minute)).Firing {
+		t.Error("a new outage fired immediately")
+	}
+}
@@ -0,0 +1,250 @@
+// Package analytics turns submission and delivery logs into time series,
+// success rates, latency percentiles and volume breakdowns
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+// Bucket is the width of one point in a series
+type Bucket string
+
+const (
+	Hour Bucket = "hour"
+	Day  Bucket = "day"
+	Week Bucket = "week"
+)
+
+// Buckets lists the supported bucket widths
+var Buckets = []Bucket{Hour, Day, Week}
+
+// ParseBucket reads a bucket width by name
+func ParseBucket(name string) (Bucket, error) {
+	for _, b := range Buckets {
+		if string(b) == name {
+			return b, nil
+		}
+	}
+	return "", fmt.Errorf("unknown bucket %q (use hour, day or week)", name)
+}
+
+// Start returns the local start of the bucket containing t. Weeks start
+// on Monday.
+func (b Bucket) Start(t time.Time) time.Time {
+	t = t.Local()
+	y, m, d := t.Date()
+	switch b {
+	case Hour:
+		// Truncate would round in UTC, which is off in half-hour zones
+		return time.Date(y, m, d, t.Hour(), 0, 0, 0, t.Location())
+	case Week:
+		day := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// Next returns the start of the bucket after the one starting at t
+func (b Bucket) Next(t time.Time) time.Time {
+	switch b {
+	case Hour:
+		return t.Add(time.Hour)
+	case Week:
+		return t.AddDate(0, 0, 7)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// Options select what a report covers
+type Options struct {
+	Since  time.Time
+	Until  time.Time
+	Bucket Bucket
+	// FormID narrows the report to one form; empty covers every form
+	FormID string
+}
+
+// Point is one bucket of a series
+type Point struct {
+	Start       time.Time `json:"start"`
+	Submissions int       `json:"submissions"`
+	Deliveries  int       `json:"deliveries"`
+	Successful  int       `json:"successful"`
+}
+
+// SuccessRate is the share of the bucket's deliveries that reached every
+// recipient, or -1 when there were none
+func (p Point) SuccessRate() float64 {
+	return rate(p.Successful, p.Deliveries)
+}
+
+// Latency summarizes how long deliveries took, in milliseconds
+type Latency struct {
+	Samples int `json:"samples"`
+	P50     int `json:"p50_ms"`
+	P90     int `json:"p90_ms"`
+	P95     int `json:"p95_ms"`
+	P99     int `json:"p99_ms"`
+	Max     int `json:"max_ms"`
+}
+
+// Volume is how many deliveries one form handled
+type Volume struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Report is everything computed for one range
+type Report struct {
+	Since       time.Time                  `json:"since"`
+	Until       time.Time                  `json:"until"`
+	Bucket      Bucket                     `json:"bucket"`
+	FormID      string                     `json:"form_id,omitempty"`
+	Series      []Point                    `json:"series"`
+	Submissions int                        `json:"submissions"`
+	Deliveries  int                        `json:"deliveries"`
+	Successful  int                        `json:"successful"`
+	SuccessRate float64                    `json:"success_rate"`
+	Latency     Latency                    `json:"latency"`
+	ByForm      []Volume                   `json:"by_form"`
+	ByRecipient []database.RecipientVolume `json:"by_recipient"`
+}
+
+// Compute builds a report from the database
+func Compute(db *database.Client, opts Options) (*Report, error) {
+	if opts.Until.IsZero() {
+		opts.Until = time.Now()
+	}
+	if opts.Bucket == "" {
+		opts.Bucket = Day
+	}
+
+	submissions, err := db.GetSubmissionsByHour(opts.Since, opts.Until, opts.FormID)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := db.GetDeliveriesByHour(opts.Since, opts.Until, opts.FormID)
+	if err != nil {
+		return nil, err
+	}
+	durations, err := db.GetDeliveryDurations(opts.Since, opts.Until, opts.FormID)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := db.GetDeliveriesByRecipient(opts.Since, opts.Until, opts.FormID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Since:       opts.Since,
+		Until:       opts.Until,
+		Bucket:      opts.Bucket,
+		FormID:      opts.FormID,
+		Series:      Series(opts.Bucket, opts.Since, opts.Until, submissions, deliveries),
+		Latency:     Percentiles(durations),
+		ByRecipient: recipients,
+	}
+	for _, point := range report.Series {
+		report.Submissions += point.Submissions
+		report.Deliveries += point.Deliveries
+		report.Successful += point.Successful
+	}
+	report.SuccessRate = rate(report.Successful, report.Deliveries)
+
+	if opts.FormID == "" {
+		byForm, err := db.GetDeliveriesByForm(opts.Since, opts.Until)
+		if err != nil {
+			return nil, err
+		}
+		report.ByForm = sortVolumes(byForm)
+	} else {
+		report.ByForm = []Volume{{Name: opts.FormID, Count: report.Deliveries}}
+	}
+
+	return report, nil
+}
+
+// Series folds hourly counts into consecutive buckets covering
+// [since, until), including empty ones. A zero since starts at the first
+// count.
+func Series(bucket Bucket, since, until time.Time, submissions, deliveries []database.HourlyCount) []Point {
+	if since.IsZero() {
+		for _, counts := range [][]database.HourlyCount{submissions, deliveries} {
+			if len(counts) > 0 && (since.IsZero() || counts[0].Hour.Before(since)) {
+				since = counts[0].Hour
+			}
+		}
+		if since.IsZero() {
+			return nil
+		}
+	}
+
+	var points []Point
+	index := make(map[time.Time]int)
+	for start := bucket.Start(since); start.Before(until); start = bucket.Next(start) {
+		index[start] = len(points)
+		points = append(points, Point{Start: start})
+	}
+
+	for _, count := range submissions {
+		if i, ok := index[bucket.Start(count.Hour)]; ok {
+			points[i].Submissions += count.Count
+		}
+	}
+	for _, count := range deliveries {
+		if i, ok := index[bucket.Start(count.Hour)]; ok {
+			points[i].Deliveries += count.Count
+			points[i].Successful += count.Success
+		}
+	}
+	return points
+}
+
+// Percentiles summarizes durations sorted shortest first, using the
+// nearest-rank method
+func Percentiles(sorted []int) Latency {
+	latency := Latency{Samples: len(sorted)}
+	if len(sorted) == 0 {
+		return latency
+	}
+
+	rank := func(p float64) int {
+		i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		return sorted[max(i, 0)]
+	}
+	latency.P50 = rank(50)
+	latency.P90 = rank(90)
+	latency.P95 = rank(95)
+	latency.P99 = rank(99)
+	latency.Max = sorted[len(sorted)-1]
+	return latency
+}
+
+func rate(part, total int) float64 {
+	if total == 0 {
+		return -1
+	}
+	return float64(part) / float64(total)
+}
+
+func sortVolumes(counts map[string]int) []Volume {
+	volumes := make([]Volume, 0, len(counts))
+	for name, count := range counts {
+		volumes = append(volumes, Volume{Name: name, Count: count})
+	}
+	sort.Slice(volumes, func(i, j int) bool {
+		if volumes[i].Count != volumes[j].Count {
+			return volumes[i].Count > volumes[j].Count
+		}
+		return volumes[i].Name < volumes[j].Name
+	})
+	return volumes
+}
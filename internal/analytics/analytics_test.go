@@ -0,0 +1,166 @@
+package analytics
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+// at is a local time on January 2026; the 5th is a Monday
+func at(day, hour int) time.Time {
+	return time.Date(2026, 1, day, hour, 0, 0, 0, time.Local)
+}
+
+// points renders a series as "start submissions/deliveries/successful"
+func points(series []Point) []string {
+	var lines []string
+	for _, p := range series {
+		lines = append(lines, fmt.Sprintf("%s %d/%d/%d", p.Start.Format("01-02 15h"), p.Submissions, p.Deliveries, p.Successful))
+	}
+	return lines
+}
+
+func TestBucketStart(t *testing.T) {
+	// A half-hour zone catches rounding to the hour in UTC
+	local := time.Local
+	time.Local = time.FixedZone("IST", 5*3600+1800)
+	t.Cleanup(func() { time.Local = local })
+
+	tests := []struct {
+		bucket Bucket
+		t      time.Time
+		want   time.Time
+	}{
+		{Hour, time.Date(2026, 1, 7, 10, 45, 12, 0, time.Local), time.Date(2026, 1, 7, 10, 0, 0, 0, time.Local)},
+		{Day, time.Date(2026, 1, 7, 10, 45, 0, 0, time.Local), time.Date(2026, 1, 7, 0, 0, 0, 0, time.Local)},
+		{Week, time.Date(2026, 1, 7, 10, 45, 0, 0, time.Local), time.Date(2026, 1, 5, 0, 0, 0, 0, time.Local)},
+		{Week, time.Date(2026, 1, 11, 23, 0, 0, 0, time.Local), time.Date(2026, 1, 5, 0, 0, 0, 0, time.Local)},
+		{Week, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 5, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s", tt.bucket, tt.t.Format(time.RFC3339)), func(t *testing.T) {
+			if got := tt.bucket.Start(tt.t); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries(t *testing.T) {
+	tests := []struct {
+		name         string
+		bucket       Bucket
+		since, until time.Time
+		submissions  []database.HourlyCount
+		deliveries   []database.HourlyCount
+		want         []string
+	}{
+		{
+			name:   "days include empty ones",
+			bucket: Day,
+			since:  at(5, 9),
+			until:  at(8, 0),
+			submissions: []database.HourlyCount{
+				{Hour: at(5, 9), Count: 2},
+				{Hour: at(5, 17), Count: 1},
+				{Hour: at(7, 3), Count: 4},
+			},
+			deliveries: []database.HourlyCount{
+				{Hour: at(5, 9), Count: 3, Success: 2},
+				{Hour: at(7, 3), Count: 4, Success: 4},
+			},
+			want: []string{"01-05 00h 3/3/2", "01-06 00h 0/0/0", "01-07 00h 4/4/4"},
+		},
+		{
+			name:        "hours",
+			bucket:      Hour,
+			since:       at(5, 10),
+			until:       at(5, 13),
+			submissions: []database.HourlyCount{{Hour: at(5, 12), Count: 5}},
+			want:        []string{"01-05 10h 0/0/0", "01-05 11h 0/0/0", "01-05 12h 5/0/0"},
+		},
+		{
+			name:        "weeks start on monday",
+			bucket:      Week,
+			since:       at(7, 0),
+			until:       at(13, 0),
+			submissions: []database.HourlyCount{{Hour: at(7, 8), Count: 1}, {Hour: at(12, 8), Count: 2}},
+			want:        []string{"01-05 00h 1/0/0", "01-12 00h 2/0/0"},
+		},
+		{
+			name:        "counts outside the range are dropped",
+			bucket:      Day,
+			since:       at(6, 0),
+			until:       at(7, 0),
+			submissions: []database.HourlyCount{{Hour: at(5, 23), Count: 1}, {Hour: at(6, 1), Count: 2}, {Hour: at(7, 0), Count: 3}},
+			want:        []string{"01-06 00h 2/0/0"},
+		},
+		{
+			name:        "zero since starts at the first count",
+			bucket:      Day,
+			until:       at(8, 0),
+			submissions: []database.HourlyCount{{Hour: at(6, 4), Count: 1}},
+			deliveries:  []database.HourlyCount{{Hour: at(5, 20), Count: 1, Success: 1}},
+			want:        []string{"01-05 00h 0/1/1", "01-06 00h 1/0/0", "01-07 00h 0/0/0"},
+		},
+		{
+			name:   "zero since without counts is empty",
+			bucket: Day,
+			until:  at(8, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := points(Series(tt.bucket, tt.since, tt.until, tt.submissions, tt.deliveries))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	hundred := make([]int, 100)
+	for i := range hundred {
+		hundred[i] = i + 1
+	}
+
+	tests := []struct {
+		name   string
+		sorted []int
+		want   Latency
+	}{
+		{
+			name: "no samples",
+			want: Latency{},
+		},
+		{
+			name:   "one sample",
+			sorted: []int{120},
+			want:   Latency{Samples: 1, P50: 120, P90: 120, P95: 120, P99: 120, Max: 120},
+		},
+		{
+			name:   "nearest rank",
+			sorted: []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 1000},
+			want:   Latency{Samples: 10, P50: 50, P90: 90, P95: 1000, P99: 1000, Max: 1000},
+		},
+		{
+			name:   "hundred samples",
+			sorted: hundred,
+			want:   Latency{Samples: 100, P50: 50, P90: 90, P95: 95, P99: 99, Max: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Percentiles(tt.sorted); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package components
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HighlightWindow is how long rows changed by a refresh stay highlighted
+const HighlightWindow = 3 * time.Second
+
+// Changes compares each load of a list with the one before so that rows
+// added or changed by a background refresh can be highlighted briefly.
+// Rows are identified by a key, such as a form ID, and compared by a
+// fingerprint, such as the text of their table row.
+type Changes struct {
+	previous map[string]string
+	changed  map[string]bool
+	until    time.Time
+}
+
+// Track records the rows of a load. When highlight is set, rows that are
+// new or differ from the previous load are highlighted and the returned
+// command redraws once the highlight is over. Loads the user asked for,
+// such as a new filter, pass false so that every row does not light up.
+func (c *Changes) Track(rows map[string]string, highlight bool) tea.Cmd {
+	previous := c.previous
+	c.previous = rows
+	if !highlight || previous == nil {
+		return nil
+	}
+
+	changed := make(map[string]bool)
+	for key, fingerprint := range rows {
+		if old, ok := previous[key]; !ok || old != fingerprint {
+			changed[key] = true
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	c.changed = changed
+	c.until = time.Now().Add(HighlightWindow)
+	return tea.Tick(HighlightWindow, func(time.Time) tea.Msg {
+		return HighlightExpiredMsg{}
+	})
+}
+
+// Active reports whether any rows are highlighted
+func (c *Changes) Active() bool {
+	return len(c.changed) > 0 && time.Now().Before(c.until)
+}
+
+// Changed reports whether the row with key is highlighted
+func (c *Changes) Changed(key string) bool {
+	return c.Active() && c.changed[key]
+}
+
+// Message types
+
+// HighlightExpiredMsg redraws the view once changed rows stop being
+// highlighted
+type HighlightExpiredMsg struct{}
@@ -0,0 +1,28 @@
+package components
+
+import "testing"
+
+func TestChangesTrack(t *testing.T) {
+	var c Changes
+	if cmd := c.Track(map[string]string{"contact": "Contact 2", "quote": "Quote 1"}, true); cmd != nil || c.Active() {
+		t.Error("the first load highlighted rows")
+	}
+
+	// A load the user asked for records the rows without highlighting
+	if cmd := c.Track(map[string]string{"contact": "Contact 3", "quote": "Quote 1"}, false); cmd != nil || c.Active() {
+		t.Error("a user load highlighted rows")
+	}
+
+	if cmd := c.Track(map[string]string{"contact": "Contact 3", "quote": "Quote 2", "new": "New 0"}, true); cmd == nil {
+		t.Fatal("no redraw scheduled for the end of the highlight")
+	}
+	for key, want := range map[string]bool{"contact": false, "quote": true, "new": true} {
+		if c.Changed(key) != want {
+			t.Errorf("Changed(%s) = %v, want %v", key, !want, want)
+		}
+	}
+
+	if cmd := c.Track(map[string]string{"contact": "Contact 3", "quote": "Quote 2", "new": "New 0"}, true); cmd != nil {
+		t.Error("an unchanged refresh scheduled a redraw")
+	}
+}
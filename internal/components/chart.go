@@ -0,0 +1,63 @@
+package components
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Sparkline draws values as a row of block characters scaled to the
+// largest one
+func Sparkline(values []float64) string {
+	blocks := []rune("▁▂▃▄▅▆▇█")
+
+	top := 0.0
+	for _, v := range values {
+		top = math.Max(top, v)
+	}
+
+	var line strings.Builder
+	for _, v := range values {
+		switch {
+		case v < 0:
+			line.WriteRune(' ')
+		case top == 0:
+			line.WriteRune(blocks[0])
+		default:
+			line.WriteRune(blocks[int(math.Round(v/top*float64(len(blocks)-1)))])
+		}
+	}
+	return line.String()
+}
+
+// Bar draws a horizontal bar of width cells for value out of top
+func Bar(value, top, width int) string {
+	if top <= 0 || width <= 0 {
+		return ""
+	}
+	cells := value * width / top
+	if value > 0 && cells == 0 {
+		cells = 1
+	}
+	return strings.Repeat("█", cells)
+}
+
+// Timeline draws one cell per uptime fraction: fully up, fully down, mixed,
+// or unknown for negative fractions
+func Timeline(fractions []float64, up, down, mixed, unknown lipgloss.Style) string {
+	var line strings.Builder
+	for _, f := range fractions {
+		switch {
+		case f < 0:
+			line.WriteString(unknown.Render("·"))
+		case f >= 1:
+			line.WriteString(up.Render("█"))
+		case f <= 0:
+			line.WriteString(down.Render("█"))
+		default:
+			line.WriteString(mixed.Render("▓"))
+		}
+	}
+	return line.String()
+}
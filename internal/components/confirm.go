@@ -0,0 +1,112 @@
+package components
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+)
+
+// Confirm is a modal yes/no dialog for destructive actions. Views keep
+// one as a field, call Ask instead of running the action, and route key
+// presses to Update while Active reports true.
+type Confirm struct {
+	styles    *styles.Styles
+	active    bool
+	prompt    Prompt
+	yes       bool
+	onConfirm tea.Cmd
+}
+
+// Prompt describes what is about to happen
+type Prompt struct {
+	Title string
+	// Action labels the confirm button, e.g. "Delete"
+	Action string
+	// Details lists what will be affected
+	Details []string
+}
+
+// NewConfirm creates an inactive confirmation dialog
+func NewConfirm(s *styles.Styles) Confirm {
+	return Confirm{styles: s}
+}
+
+// Ask opens the dialog. When enabled is false (ui.confirm_destructive
+// turned off) the action runs immediately instead.
+func (c *Confirm) Ask(enabled bool, prompt Prompt, onConfirm tea.Cmd) tea.Cmd {
+	if !enabled {
+		return onConfirm
+	}
+	if prompt.Action == "" {
+		prompt.Action = "Confirm"
+	}
+	c.active = true
+	c.prompt = prompt
+	c.yes = false
+	c.onConfirm = onConfirm
+	return nil
+}
+
+// Active reports whether the dialog is open and capturing keys
+func (c *Confirm) Active() bool {
+	return c.active
+}
+
+// Update handles a key press while the dialog is open and returns the
+// confirmed action, if any
+func (c *Confirm) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y", "Y":
+		return c.close(true)
+	case "n", "N", "esc", "q":
+		return c.close(false)
+	case "left", "right", "h", "l", "tab", "shift+tab":
+		c.yes = !c.yes
+	case "enter":
+		return c.close(c.yes)
+	}
+	return nil
+}
+
+func (c *Confirm) close(confirmed bool) tea.Cmd {
+	c.active = false
+	cmd := c.onConfirm
+	c.onConfirm = nil
+	if confirmed {
+		return cmd
+	}
+	return nil
+}
+
+// View renders the dialog box
+func (c *Confirm) View() string {
+	if !c.active {
+		return ""
+	}
+
+	lines := []string{c.styles.Warning.Render("⚠ " + c.prompt.Title)}
+	if len(c.prompt.Details) > 0 {
+		lines = append(lines, "")
+		for _, detail := range c.prompt.Details {
+			lines = append(lines, c.styles.Text.Render("• "+detail))
+		}
+	}
+
+	yes := c.styles.Button.Copy().Background(c.styles.Colors.BgSecondary)
+	no := c.styles.Button.Copy().Background(c.styles.Colors.BgSecondary)
+	if c.yes {
+		yes = yes.Background(c.styles.Colors.Error)
+	} else {
+		no = no.Background(c.styles.Colors.Primary)
+	}
+	buttons := lipgloss.JoinHorizontal(lipgloss.Top, yes.Render(c.prompt.Action), no.Render("Cancel"))
+
+	lines = append(lines, "", buttons, "", c.styles.Help.Render("y: Confirm • n/Esc: Cancel • ←→: Choose"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(c.styles.Colors.Error).
+		Padding(1, 3).
+		Width(64).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
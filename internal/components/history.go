@@ -0,0 +1,121 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+// History renders an entity's audit trail as a scrollable list
+type History struct {
+	styles  *styles.Styles
+	keys    *keys.Map
+	entries []database.AuditEntry
+	err     error
+	offset  int
+	height  int
+}
+
+// NewHistory creates an empty history pane
+func NewHistory(s *styles.Styles, km *keys.Map) History {
+	return History{styles: s, keys: km, height: 15}
+}
+
+// SetEntries replaces the entries shown, newest first
+func (h *History) SetEntries(entries []database.AuditEntry, err error) {
+	h.entries = entries
+	h.err = err
+	h.offset = 0
+}
+
+// SetHeight sets how many lines the pane shows at once
+func (h *History) SetHeight(height int) {
+	if height > 3 {
+		h.height = height
+	}
+}
+
+// Update scrolls the pane
+func (h *History) Update(msg tea.KeyMsg) {
+	lines := len(h.lines())
+	switch {
+	case key.Matches(msg, h.keys.List.Up):
+		if h.offset > 0 {
+			h.offset--
+		}
+	case key.Matches(msg, h.keys.List.Down):
+		if h.offset < lines-h.height {
+			h.offset++
+		}
+	case key.Matches(msg, h.keys.List.PageUp):
+		h.offset -= h.height
+		if h.offset < 0 {
+			h.offset = 0
+		}
+	case key.Matches(msg, h.keys.List.PageDown):
+		h.offset += h.height
+		if h.offset > lines-h.height {
+			h.offset = max(lines-h.height, 0)
+		}
+	}
+}
+
+// Scroll moves the history by step lines, e.g. for the mouse wheel
+func (h *History) Scroll(step int) {
+	h.offset = max(0, min(len(h.lines())-h.height, h.offset+step))
+}
+
+// View renders the visible part of the history
+func (h History) View() string {
+	if h.err != nil {
+		return h.styles.Error.Render(fmt.Sprintf("Failed to load history: %v", h.err))
+	}
+	if len(h.entries) == 0 {
+		return h.styles.Muted.Render("No recorded changes yet")
+	}
+
+	lines := h.lines()
+	end := min(h.offset+h.height, len(lines))
+	view := lipgloss.JoinVertical(lipgloss.Left, lines[h.offset:end]...)
+	if len(lines) > h.height {
+		view = lipgloss.JoinVertical(lipgloss.Left, view,
+			h.styles.Muted.Render(fmt.Sprintf("%d-%d of %d lines • ↑/↓ to scroll", h.offset+1, end, len(lines))))
+	}
+	return view
+}
+
+func (h History) lines() []string {
+	var lines []string
+	for _, entry := range h.entries {
+		header := fmt.Sprintf("%s  %s  %s",
+			entry.CreatedAt.Local().Format("2006-01-02 15:04"),
+			strings.ToUpper(entry.Action),
+			entry.Actor,
+		)
+		if entry.Profile != "" && entry.Profile != "default" {
+			header += " @" + entry.Profile
+		}
+		lines = append(lines, h.styles.Label.Render(header))
+
+		for _, change := range entry.Changes() {
+			if change.Added != nil || change.Removed != nil {
+				for _, item := range change.Removed {
+					lines = append(lines, h.styles.Error.Render(fmt.Sprintf("  - %s: %s", change.Field, item)))
+				}
+				for _, item := range change.Added {
+					lines = append(lines, h.styles.Success.Render(fmt.Sprintf("  + %s: %s", change.Field, item)))
+				}
+				continue
+			}
+			lines = append(lines, h.styles.Text.Render(fmt.Sprintf("  %s: %q → %q", change.Field, change.Before, change.After)))
+		}
+		lines = append(lines, "")
+	}
+	return lines
+}
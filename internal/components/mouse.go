@@ -0,0 +1,115 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+)
+
+// Views receive mouse events relative to their own View output: the app
+// subtracts the header and content padding before passing them on.
+
+// Wheel returns -1 for the wheel scrolling up, 1 for down and 0 for any
+// other mouse event
+func Wheel(msg tea.MouseMsg) int {
+	if msg.Action != tea.MouseActionPress {
+		return 0
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return -1
+	case tea.MouseButtonWheelDown:
+		return 1
+	}
+	return 0
+}
+
+// Clicked reports whether msg is a left click
+func Clicked(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
+
+// TableRowAt returns the index of the row of t drawn at line y of view,
+// the rendered view t is part of, or -1 when y is not on a row
+func TableRowAt(view string, t table.Model, y int) int {
+	rows := t.Rows()
+	if len(rows) == 0 {
+		return -1
+	}
+	lines := strings.Split(ansi.Strip(view), "\n")
+	tableLines := strings.Split(ansi.Strip(t.View()), "\n")
+	top := findLine(lines, tableLines[0])
+	header, first := visibleRows(t)
+	if top < 0 || y < top+header || y >= top+len(tableLines) {
+		return -1
+	}
+	row := first + y - top - header
+	if row >= len(rows) {
+		return -1
+	}
+	return row
+}
+
+// TableMouse scrolls t with the wheel and moves its cursor to a clicked
+// row. view is the rendered view t is part of.
+func TableMouse(t *table.Model, view string, msg tea.MouseMsg) {
+	if step := Wheel(msg); step != 0 {
+		if step < 0 {
+			t.MoveUp(1)
+		} else {
+			t.MoveDown(1)
+		}
+		return
+	}
+	if !Clicked(msg) {
+		return
+	}
+	// Move the way the arrow keys would, so the table scrolls as little
+	// as possible
+	row := TableRowAt(view, *t, msg.Y)
+	if cursor := t.Cursor(); row > cursor {
+		t.MoveDown(row - cursor)
+	} else if row >= 0 && row < cursor {
+		t.MoveUp(cursor - row)
+	}
+}
+
+// TabAt returns the tab under x, y when view contains the tabs drawn by
+// RenderTabs with the same labels and active tab, or -1
+func TabAt(view string, s *styles.Styles, labels []string, active, x, y int) int {
+	lines := strings.Split(ansi.Strip(view), "\n")
+	if y < 0 || y >= len(lines) {
+		return -1
+	}
+	idx := strings.Index(lines[y], ansi.Strip(RenderTabs(s, labels, active)))
+	if idx < 0 {
+		return -1
+	}
+	left := ansi.StringWidth(lines[y][:idx])
+	for i, label := range labels {
+		style := s.MenuItem
+		if i == active {
+			style = s.ActiveItem
+		}
+		width := ansi.StringWidth(style.Render(label))
+		if x >= left && x < left+width {
+			return i
+		}
+		left += width
+	}
+	return -1
+}
+
+// findLine returns the index of the first line containing text, or -1
+func findLine(lines []string, text string) int {
+	text = strings.TrimSpace(text)
+	for i, line := range lines {
+		if text != "" && strings.Contains(line, text) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,59 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestTable returns a table of five rows showing two at a time under
+// a one-line header
+func newTestTable() table.Model {
+	return table.New(
+		table.WithColumns([]table.Column{{Title: "ID", Width: 4}, {Title: "Name", Width: 8}}),
+		table.WithRows([]table.Row{{"1", "Ana"}, {"2", "Bruno"}, {"3", "Caio"}, {"4", "Dora"}, {"5", "Eva"}}),
+		table.WithHeight(3),
+		table.WithFocused(true),
+	)
+}
+
+func press(button tea.MouseButton, y int) tea.MouseMsg {
+	return tea.MouseMsg{Action: tea.MouseActionPress, Button: button, Y: y}
+}
+
+func TestWheelAndClicked(t *testing.T) {
+	if Wheel(press(tea.MouseButtonWheelUp, 0)) != -1 || Wheel(press(tea.MouseButtonWheelDown, 0)) != 1 {
+		t.Error("wheel presses not reported as scrolling")
+	}
+	release := tea.MouseMsg{Action: tea.MouseActionRelease, Button: tea.MouseButtonWheelDown}
+	if Wheel(release) != 0 || Wheel(press(tea.MouseButtonLeft, 0)) != 0 {
+		t.Error("non-wheel events reported as scrolling")
+	}
+	if !Clicked(press(tea.MouseButtonLeft, 0)) || Clicked(press(tea.MouseButtonRight, 0)) {
+		t.Error("only left presses are clicks")
+	}
+}
+
+func TestTableMouse(t *testing.T) {
+	tbl := newTestTable()
+	// The table sits under a title and a blank line, so its header is on
+	// line 2 and its first row on line 3
+	view := func() string { return "Contacts\n\n" + tbl.View() }
+
+	TableMouse(&tbl, view(), press(tea.MouseButtonLeft, 4))
+	if tbl.Cursor() != 1 {
+		t.Errorf("clicking the second row moved the cursor to %d", tbl.Cursor())
+	}
+	TableMouse(&tbl, view(), press(tea.MouseButtonLeft, 2))
+	TableMouse(&tbl, view(), press(tea.MouseButtonLeft, 9))
+	if tbl.Cursor() != 1 {
+		t.Errorf("clicks on the header and below the table moved the cursor to %d", tbl.Cursor())
+	}
+
+	TableMouse(&tbl, view(), press(tea.MouseButtonWheelDown, 0))
+	TableMouse(&tbl, view(), press(tea.MouseButtonWheelDown, 0))
+	if tbl.Cursor() != 3 {
+		t.Errorf("two wheel steps down left the cursor at %d, want 3", tbl.Cursor())
+	}
+}
@@ -0,0 +1,28 @@
+package components
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+)
+
+// still is shown in place of a spinner when animations are off. Its
+// frame rate is low enough that the spinner stops causing redraws.
+var still = spinner.Spinner{
+	Frames: []string{"…"},
+	FPS:    time.Hour,
+}
+
+// NewSpinner creates the loading spinner, animated only when the UI
+// config allows motion
+func NewSpinner(cfg *config.Config, s *styles.Styles) spinner.Model {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	if !cfg.UI.Motion() {
+		sp.Spinner = still
+	}
+	sp.Style = s.Spinner
+	return sp
+}
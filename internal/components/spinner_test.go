@@ -0,0 +1,21 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+)
+
+func TestNewSpinnerHonorsMotion(t *testing.T) {
+	cfg := config.DefaultConfig()
+	s := styles.NewStyles("dark")
+
+	if sp := NewSpinner(cfg, s); len(sp.Spinner.Frames) < 2 {
+		t.Error("spinner is still with animations on")
+	}
+	cfg.UI.ReducedMotion = true
+	if sp := NewSpinner(cfg, s); len(sp.Spinner.Frames) != 1 {
+		t.Errorf("spinner has %d frames with reduced motion, want a still one", len(sp.Spinner.Frames))
+	}
+}
@@ -0,0 +1,58 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// rowMarker, a private use character, fills the cells of the cursor row
+// when visibleRows looks for where a table has scrolled to
+const rowMarker = "\ue000"
+
+// visibleRows returns how many lines the header of t takes and the index
+// of the first row it shows. The table does not expose how far it has
+// scrolled, so it is rendered with the cursor row marked to see where
+// that row lands.
+func visibleRows(t table.Model) (header, first int) {
+	header = lipgloss.Height(t.View()) - t.Height()
+	rows := t.Rows()
+	cursor := t.Cursor()
+	if cursor < 0 || cursor >= len(rows) {
+		return header, 0
+	}
+
+	marked := make(table.Row, len(rows[cursor]))
+	for i := range marked {
+		marked[i] = rowMarker
+	}
+	probeRows := append([]table.Row(nil), rows...)
+	probeRows[cursor] = marked
+	probe := t
+	probe.SetRows(probeRows)
+	for i, line := range strings.Split(ansi.Strip(probe.View()), "\n")[header:] {
+		if strings.Contains(line, rowMarker) {
+			return header, cursor - i
+		}
+	}
+	return header, 0
+}
+
+// HighlightTable renders t with the rows changed reports drawn in style.
+// The cursor row keeps the table's selected style.
+func HighlightTable(t table.Model, style lipgloss.Style, changed func(row int) bool) string {
+	lines := strings.Split(t.View(), "\n")
+	header, first := visibleRows(t)
+	for i := header; i < len(lines); i++ {
+		row := first + i - header
+		if row >= len(t.Rows()) {
+			break
+		}
+		if row != t.Cursor() && changed(row) {
+			lines[i] = style.Render(ansi.Strip(lines[i]))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
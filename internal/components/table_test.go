@@ -0,0 +1,47 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestVisibleRowsFollowsScrolling(t *testing.T) {
+	tbl := newTestTable()
+	if header, first := visibleRows(tbl); header != 1 || first != 0 {
+		t.Errorf("visibleRows = %d, %d; want a one-line header and the first row on top", header, first)
+	}
+
+	tbl.MoveDown(4)
+	if _, first := visibleRows(tbl); first != 3 {
+		t.Errorf("first visible row after scrolling to the end = %d, want 3", first)
+	}
+	// The first line under the header is now the fourth row
+	if row := TableRowAt(tbl.View(), tbl, 1); row != 3 {
+		t.Errorf("TableRowAt(line 1) = %d after scrolling, want 3", row)
+	}
+}
+
+func TestHighlightTable(t *testing.T) {
+	tbl := newTestTable()
+	tbl.MoveDown(4)
+	marker := lipgloss.NewStyle().Transform(func(s string) string { return ">" + s })
+
+	var asked []int
+	lines := strings.Split(HighlightTable(tbl, marker, func(row int) bool {
+		asked = append(asked, row)
+		return true
+	}), "\n")
+
+	if len(asked) != 1 || asked[0] != 3 {
+		t.Errorf("changed asked about rows %v, want only the visible row off the cursor", asked)
+	}
+	if !strings.HasPrefix(lines[1], ">") || !strings.Contains(ansi.Strip(lines[1]), "Dora") {
+		t.Errorf("row 3 not highlighted: %q", lines[1])
+	}
+	if strings.HasPrefix(lines[2], ">") {
+		t.Error("the cursor row lost its selected style")
+	}
+}
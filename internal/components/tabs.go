@@ -0,0 +1,19 @@
+package components
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+)
+
+// RenderTabs draws a row of tab labels with the active one highlighted
+func RenderTabs(s *styles.Styles, labels []string, active int) string {
+	tabs := make([]string, len(labels))
+	for i, label := range labels {
+		if i == active {
+			tabs[i] = s.ActiveItem.Render(label)
+		} else {
+			tabs[i] = s.MenuItem.Render(label)
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
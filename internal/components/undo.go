@@ -0,0 +1,88 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+// UndoWindow is how long a destructive action can be undone
+const UndoWindow = 10 * time.Second
+
+// Undo offers a short-lived chance to reverse the last destructive
+// action. The restore function is captured when the action runs, so it
+// restores from a snapshot taken before the data was removed.
+type Undo struct {
+	styles  *styles.Styles
+	keys    *keys.Map
+	label   string
+	restore func() error
+	expires time.Time
+	id      int
+}
+
+// NewUndo creates an empty undo slot
+func NewUndo(s *styles.Styles, km *keys.Map) Undo {
+	return Undo{styles: s, keys: km}
+}
+
+// Offer replaces any pending undo with a new one and schedules its expiry
+func (u *Undo) Offer(label string, restore func() error) tea.Cmd {
+	u.id++
+	u.label = label
+	u.restore = restore
+	u.expires = time.Now().Add(UndoWindow)
+
+	id := u.id
+	return tea.Tick(UndoWindow, func(time.Time) tea.Msg {
+		return UndoExpiredMsg{ID: id}
+	})
+}
+
+// Active reports whether there is an action that can still be undone
+func (u *Undo) Active() bool {
+	return u.restore != nil && time.Now().Before(u.expires)
+}
+
+// Expire clears the pending undo if msg belongs to it
+func (u *Undo) Expire(msg UndoExpiredMsg) {
+	if msg.ID == u.id {
+		u.restore = nil
+	}
+}
+
+// Trigger runs the restore and reports the outcome as UndoCompletedMsg
+func (u *Undo) Trigger() tea.Cmd {
+	if !u.Active() {
+		return nil
+	}
+	restore := u.restore
+	label := u.label
+	u.restore = nil
+
+	return func() tea.Msg {
+		return UndoCompletedMsg{Label: label, Error: restore()}
+	}
+}
+
+// View renders the undo hint, or nothing when no undo is pending
+func (u *Undo) View() string {
+	if !u.Active() {
+		return ""
+	}
+	left := time.Until(u.expires).Round(time.Second)
+	return u.styles.Info.Render(fmt.Sprintf("%s • %s (%s)", u.label, keys.Help(u.keys.Item.Undo), left))
+}
+
+// Message types
+type UndoExpiredMsg struct {
+	ID int
+}
+
+type UndoCompletedMsg struct {
+	Label string
+	Error error
+}
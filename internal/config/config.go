@@ -1,22 +1,44 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"os/user"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/viper"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/secrets"
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Cloudflare CloudflareConfig `yaml:"cloudflare" mapstructure:"cloudflare"`
-	ZAPI       ZAPIConfig       `yaml:"zapi" mapstructure:"zapi"`
-	UI         UIConfig         `yaml:"ui" mapstructure:"ui"`
+	Cloudflare CloudflareConfig   `yaml:"cloudflare" mapstructure:"cloudflare"`
+	ZAPI       ZAPIConfig         `yaml:"zapi" mapstructure:"zapi"`
+	UI         UIConfig           `yaml:"ui" mapstructure:"ui"`
+	Queue      QueueConfig        `yaml:"queue" mapstructure:"queue"`
+	Alerts     AlertsConfig       `yaml:"alerts" mapstructure:"alerts"`
 	Profiles   map[string]Profile `yaml:"profiles,omitempty" mapstructure:"profiles"`
+	// Profile selects an entry of Profiles; empty uses the top-level settings
+	Profile string `yaml:"profile,omitempty" mapstructure:"profile"`
+	// Actor is recorded in the audit log; defaults to the OS user
+	Actor string `yaml:"actor,omitempty" mapstructure:"actor"`
+	// SecretsFile is the age-encrypted file age: references read; defaults
+	// to secrets.age next to config.yaml
+	SecretsFile string `yaml:"secrets_file,omitempty" mapstructure:"secrets_file"`
+
+	// path is the file the config was loaded from
+	path string
+	// workerURL is the top-level worker URL before the profile's overlay
+	workerURL string
+	// secrets records the references credentials were resolved from
+	secrets  map[string]secret
+	resolver *secrets.Resolver
 }
 
 type CloudflareConfig struct {
@@ -24,21 +46,92 @@ type CloudflareConfig struct {
 	APIToken   string `yaml:"api_token" mapstructure:"api_token"`
 	DatabaseID string `yaml:"database_id" mapstructure:"database_id"`
 	WorkerURL  string `yaml:"worker_url" mapstructure:"worker_url"`
+	// APIURL replaces the Cloudflare API endpoint, e.g. with a mock
+	APIURL string `yaml:"api_url,omitempty" mapstructure:"api_url"`
 }
 
 type ZAPIConfig struct {
 	InstanceID    string `yaml:"instance_id" mapstructure:"instance_id"`
 	InstanceToken string `yaml:"instance_token" mapstructure:"instance_token"`
 	ClientToken   string `yaml:"client_token" mapstructure:"client_token"`
+	// BaseURL replaces the Z-API endpoint, e.g. with a mock
+	BaseURL string `yaml:"base_url,omitempty" mapstructure:"base_url"`
 }
 
 type UIConfig struct {
 	Theme              string        `yaml:"theme" mapstructure:"theme"`
 	Mouse              bool          `yaml:"mouse" mapstructure:"mouse"`
 	Animations         bool          `yaml:"animations" mapstructure:"animations"`
+	// ReducedMotion turns animations off and caps the redraw rate, for
+	// slow SSH connections
+	ReducedMotion      bool          `yaml:"reduced_motion" mapstructure:"reduced_motion"`
 	VimBindings        bool          `yaml:"vim_bindings" mapstructure:"vim_bindings"`
 	AutoRefresh        time.Duration `yaml:"auto_refresh" mapstructure:"auto_refresh"`
 	ConfirmDestructive bool          `yaml:"confirm_destructive" mapstructure:"confirm_destructive"`
+	// Keys overrides key bindings by section and name, e.g. keys.item.delete
+	Keys map[string]map[string][]string `yaml:"keys,omitempty" mapstructure:"keys"`
+}
+
+// Motion reports whether spinners and transitions animate
+func (u UIConfig) Motion() bool {
+	return u.Animations && !u.ReducedMotion
+}
+
+// QueueConfig is the retry policy of the outbound message queue
+type QueueConfig struct {
+	MaxAttempts  int           `yaml:"max_attempts" mapstructure:"max_attempts"`
+	BaseDelay    time.Duration `yaml:"base_delay" mapstructure:"base_delay"`
+	MaxDelay     time.Duration `yaml:"max_delay" mapstructure:"max_delay"`
+	PollInterval time.Duration `yaml:"poll_interval" mapstructure:"poll_interval"`
+	BatchSize    int           `yaml:"batch_size" mapstructure:"batch_size"`
+}
+
+// AlertsConfig holds the rules ewctl monitor run evaluates and where it
+// sends alerts
+type AlertsConfig struct {
+	// Interval is how often the rules are evaluated
+	Interval time.Duration `yaml:"interval" mapstructure:"interval"`
+	// Repeat re-sends a still firing alert this often; zero only notifies
+	// when an alert fires and resolves
+	Repeat    time.Duration    `yaml:"repeat,omitempty" mapstructure:"repeat"`
+	Rules     []AlertRule      `yaml:"rules,omitempty" mapstructure:"rules"`
+	Notifiers []NotifierConfig `yaml:"notifiers,omitempty" mapstructure:"notifiers"`
+}
+
+// AlertRule is one condition to alert on. Type is zapi_down, failure_rate,
+// no_submissions or d1_unreachable.
+type AlertRule struct {
+	Name string `yaml:"name" mapstructure:"name"`
+	Type string `yaml:"type" mapstructure:"type"`
+	// For is how long Z-API must be down, the window the failure rate is
+	// measured over, or how long a form may go without submissions
+	For time.Duration `yaml:"for,omitempty" mapstructure:"for"`
+	// Threshold is the failure rate, in percent, above which the rule fires
+	Threshold float64 `yaml:"threshold,omitempty" mapstructure:"threshold"`
+	// MinDeliveries keeps a handful of failures from firing the failure rate
+	MinDeliveries int    `yaml:"min_deliveries,omitempty" mapstructure:"min_deliveries"`
+	FormID        string `yaml:"form_id,omitempty" mapstructure:"form_id"`
+	// Notify names the notifiers to use; empty uses every notifier
+	Notify []string `yaml:"notify,omitempty" mapstructure:"notify"`
+}
+
+// NotifierConfig is one place alerts are sent. Type is smtp, webhook,
+// slack or whatsapp; only the fields of that type are read.
+type NotifierConfig struct {
+	Name string `yaml:"name" mapstructure:"name"`
+	Type string `yaml:"type" mapstructure:"type"`
+	// URL is the webhook or Slack-compatible incoming webhook to post to
+	URL     string            `yaml:"url,omitempty" mapstructure:"url"`
+	Headers map[string]string `yaml:"headers,omitempty" mapstructure:"headers"`
+	// SMTP settings
+	Host     string   `yaml:"host,omitempty" mapstructure:"host"`
+	Port     int      `yaml:"port,omitempty" mapstructure:"port"`
+	Username string   `yaml:"username,omitempty" mapstructure:"username"`
+	Password string   `yaml:"password,omitempty" mapstructure:"password"`
+	From     string   `yaml:"from,omitempty" mapstructure:"from"`
+	To       []string `yaml:"to,omitempty" mapstructure:"to"`
+	// Phone is the monitor number WhatsApp alerts are sent to
+	Phone string `yaml:"phone,omitempty" mapstructure:"phone"`
 }
 
 type Profile struct {
@@ -51,13 +144,23 @@ func DefaultConfig() *Config {
 			WorkerURL: "https://elementor-whatsapp.workers.dev",
 		},
 		UI: UIConfig{
-			Theme:              "charm",
+			Theme:              "auto",
 			Mouse:              true,
 			Animations:         true,
 			VimBindings:        false,
 			AutoRefresh:        30 * time.Second,
 			ConfirmDestructive: true,
 		},
+		Queue: QueueConfig{
+			MaxAttempts:  5,
+			BaseDelay:    30 * time.Second,
+			MaxDelay:     time.Hour,
+			PollInterval: 10 * time.Second,
+			BatchSize:    20,
+		},
+		Alerts: AlertsConfig{
+			Interval: time.Minute,
+		},
 		Profiles: map[string]Profile{
 			"dev": {
 				WorkerURL: "http://localhost:8787",
@@ -98,8 +201,14 @@ func Load(configFile string) (*Config, error) {
 	v.AutomaticEnv()
 
 	// Read config file
-	if err := v.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+	err := v.ReadInConfig()
+	cfg.path = v.ConfigFileUsed()
+	if cfg.path == "" {
+		cfg.path = configFile
+	}
+	if err != nil {
+		// A --config file that does not exist yet is created on save
+		if _, ok := err.(viper.ConfigFileNotFoundError); ok || os.IsNotExist(err) {
 			log.Debug("Config file not found, using defaults")
 			// Check for legacy .env file in manager directory
 			if err := loadLegacyEnv(cfg); err != nil {
@@ -114,13 +223,15 @@ func Load(configFile string) (*Config, error) {
 		}
 	}
 
+	// Credentials may be references to the keyring, an encrypted file or
+	// a command
+	cfg.resolveSecrets()
+
 	// Override with environment variables
 	if accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID"); accountID != "" {
 		cfg.Cloudflare.AccountID = accountID
 	}
-	if apiToken := os.Getenv("CLOUDFLARE_API_TOKEN"); apiToken != "" {
-		cfg.Cloudflare.APIToken = apiToken
-	}
+	cfg.overrideSecret("cloudflare.api_token", "CLOUDFLARE_API_TOKEN")
 	if databaseID := os.Getenv("DATABASE_ID"); databaseID != "" {
 		cfg.Cloudflare.DatabaseID = databaseID
 	}
@@ -130,20 +241,33 @@ func Load(configFile string) (*Config, error) {
 	if instanceID := os.Getenv("ZAPI_INSTANCE_ID"); instanceID != "" {
 		cfg.ZAPI.InstanceID = instanceID
 	}
-	if instanceToken := os.Getenv("ZAPI_INSTANCE_TOKEN"); instanceToken != "" {
-		cfg.ZAPI.InstanceToken = instanceToken
+	cfg.overrideSecret("zapi.instance_token", "ZAPI_INSTANCE_TOKEN")
+	cfg.overrideSecret("zapi.client_token", "ZAPI_CLIENT_TOKEN")
+	if profile := os.Getenv("EWCTL_PROFILE"); profile != "" {
+		cfg.Profile = profile
+	}
+	if reduced, err := strconv.ParseBool(os.Getenv("EWCTL_REDUCED_MOTION")); err == nil {
+		cfg.UI.ReducedMotion = reduced
 	}
-	if clientToken := os.Getenv("ZAPI_CLIENT_TOKEN"); clientToken != "" {
-		cfg.ZAPI.ClientToken = clientToken
+
+	// An unknown profile is left for Validate to report
+	if err := cfg.applyProfile(); err != nil {
+		log.Debug("Profile not applied", "error", err)
 	}
 
 	return cfg, nil
 }
 
+// Save writes the config to configFile, or to the file it was loaded from,
+// or to the default location, readable only by the user. An existing file
+// keeps its comments, key order and any keys ewctl does not know about, and
+// credentials loaded from references are saved as those references.
 func Save(cfg *Config, configFile string) error {
 	var configPath string
 	if configFile != "" {
 		configPath = configFile
+	} else if cfg.path != "" {
+		configPath = cfg.path
 	} else {
 		configDir, err := getConfigDir()
 		if err != nil {
@@ -154,36 +278,71 @@ func Save(cfg *Config, configFile string) error {
 
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(configPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := os.MkdirAll(dir, 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Marshal config to YAML
-	data, err := yaml.Marshal(cfg)
+	// Keep the profile's overlay out of the top-level settings
+	out := *cfg
+	if profile, ok := cfg.Profiles[cfg.Profile]; ok && cfg.Profile != "" &&
+		profile.WorkerURL != "" && cfg.Cloudflare.WorkerURL == profile.WorkerURL {
+		out.Cloudflare.WorkerURL = cfg.workerURL
+	}
+	cfg.restoreSecrets(&out)
+
+	node, err := encodeNode(&out)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	// Write to file
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	// Merge into the existing file to keep its comments and unknown keys
+	if existing, err := os.ReadFile(configPath); err == nil {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(existing, &doc); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", configPath, err)
+		}
+		if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+			mergeNode(doc.Content[0], node, reflect.TypeOf(out))
+			node = &doc
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	data := buf.Bytes()
+
+	// Write to file; WriteFile keeps the mode of an existing file
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
+	if err := os.Chmod(configPath, 0600); err != nil {
+		return fmt.Errorf("failed to restrict config file permissions: %w", err)
+	}
 
+	cfg.path = configPath
 	log.Info("Configuration saved", "path", configPath)
 	return nil
 }
 
 func Print(cfg *Config) error {
-	// Mask sensitive data
+	// Mask sensitive data; references are shown as they are
 	masked := *cfg
-	if masked.Cloudflare.APIToken != "" {
-		masked.Cloudflare.APIToken = maskString(masked.Cloudflare.APIToken)
-	}
-	if masked.ZAPI.InstanceToken != "" {
-		masked.ZAPI.InstanceToken = maskString(masked.ZAPI.InstanceToken)
-	}
-	if masked.ZAPI.ClientToken != "" {
-		masked.ZAPI.ClientToken = maskString(masked.ZAPI.ClientToken)
+	masked.Alerts.Notifiers = append([]NotifierConfig(nil), cfg.Alerts.Notifiers...)
+	fields := masked.secretFields()
+	for _, key := range cfg.SecretKeys() {
+		field := fields[key]
+		if ref := cfg.SecretRef(key); ref != "" {
+			*field = ref
+		} else if *field != "" && (!strings.HasSuffix(key, ".url") || isSlack(cfg, key)) {
+			*field = maskString(*field)
+		}
 	}
 
 	data, err := yaml.Marshal(masked)
@@ -192,9 +351,103 @@ func Print(cfg *Config) error {
 	}
 
 	fmt.Println(string(data))
+	fmt.Println("# Secret sources:")
+	for _, key := range cfg.SecretKeys() {
+		// Notifier URLs are only secrets when they are references
+		if strings.HasPrefix(key, "alerts.") && (cfg.SecretSource(key) == "not set" ||
+			strings.HasSuffix(key, ".url") && cfg.SecretRef(key) == "") {
+			continue
+		}
+		fmt.Printf("#   %-32s %s\n", key, cfg.SecretSource(key))
+	}
 	return nil
 }
 
+// isSlack reports whether a notifier key belongs to a Slack notifier, whose
+// URL embeds its token
+func isSlack(cfg *Config, key string) bool {
+	var index int
+	if _, err := fmt.Sscanf(key, "alerts.notifiers.%d.", &index); err != nil || index >= len(cfg.Alerts.Notifiers) {
+		return false
+	}
+	return cfg.Alerts.Notifiers[index].Type == "slack"
+}
+
+// Path returns the file the config was loaded from or last saved to; empty
+// when it came from defaults and the environment only
+func (c *Config) Path() string {
+	return c.path
+}
+
+// ProfileName returns the active profile, or "default" when none is selected
+func (c *Config) ProfileName() string {
+	if c.Profile == "" {
+		return "default"
+	}
+	return c.Profile
+}
+
+// AuditActor returns who changes are attributed to: the configured actor,
+// or the OS user running ewctl
+func (c *Config) AuditActor() string {
+	if c.Actor != "" {
+		return c.Actor
+	}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
+// SetProfile switches to another profile, or back to the top-level
+// settings when name is empty
+func (c *Config) SetProfile(name string) error {
+	if profile, ok := c.Profiles[c.Profile]; ok && c.Profile != "" &&
+		profile.WorkerURL != "" && c.Cloudflare.WorkerURL == profile.WorkerURL {
+		c.Cloudflare.WorkerURL = c.workerURL
+	}
+	c.Profile = name
+	return c.applyProfile()
+}
+
+// applyProfile overlays the selected profile onto the top-level settings
+func (c *Config) applyProfile() error {
+	if c.Profile == "" {
+		return nil
+	}
+	profile, ok := c.Profiles[c.Profile]
+	if !ok {
+		return fmt.Errorf("unknown profile %q", c.Profile)
+	}
+	c.workerURL = c.Cloudflare.WorkerURL
+	if profile.WorkerURL != "" {
+		c.Cloudflare.WorkerURL = profile.WorkerURL
+	}
+	return nil
+}
+
+// DefaultPath returns where the config is read from and saved to when no
+// --config file is given
+func DefaultPath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config dir: %w", err)
+	}
+	return filepath.Join(configDir, "config.yaml"), nil
+}
+
+// ThemesDir returns the directory user theme files are read from
+func ThemesDir() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config dir: %w", err)
+	}
+	return filepath.Join(configDir, "themes"), nil
+}
+
 func getConfigDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -232,19 +485,3 @@ func maskString(s string) string {
 	}
 	return s[:4] + "****" + s[len(s)-4:]
 }
-
-func (c *Config) Validate() error {
-	if c.Cloudflare.AccountID == "" {
-		return fmt.Errorf("cloudflare.account_id is required")
-	}
-	if c.Cloudflare.APIToken == "" {
-		return fmt.Errorf("cloudflare.api_token is required")
-	}
-	if c.Cloudflare.DatabaseID == "" {
-		return fmt.Errorf("cloudflare.database_id is required")
-	}
-	if c.Cloudflare.WorkerURL == "" {
-		return fmt.Errorf("cloudflare.worker_url is required")
-	}
-	return nil
-}
\ No newline at end of file
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/secrets"
+)
+
+// secret records how a credential was loaded, so unchanged secrets are
+// saved back as the reference or plaintext the file had
+type secret struct {
+	// raw is the config file's value, a reference or plaintext
+	raw string
+	// value is what raw resolved to, or the environment override
+	value string
+	// env names the environment variable that overrode the file
+	env string
+	err error
+}
+
+// secretFields returns the settings that hold credentials, by key
+func (c *Config) secretFields() map[string]*string {
+	fields := map[string]*string{
+		"cloudflare.api_token": &c.Cloudflare.APIToken,
+		"zapi.instance_token":  &c.ZAPI.InstanceToken,
+		"zapi.client_token":    &c.ZAPI.ClientToken,
+	}
+	for i := range c.Alerts.Notifiers {
+		fields[fmt.Sprintf("alerts.notifiers.%d.password", i)] = &c.Alerts.Notifiers[i].Password
+		fields[fmt.Sprintf("alerts.notifiers.%d.url", i)] = &c.Alerts.Notifiers[i].URL
+	}
+	return fields
+}
+
+// SecretKeys returns the keys of the settings that hold credentials
+func (c *Config) SecretKeys() []string {
+	keys := make([]string, 0)
+	for key := range c.secretFields() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Resolver returns the secret resolver for this config's references
+func (c *Config) Resolver() *secrets.Resolver {
+	if c.resolver == nil {
+		c.resolver = secrets.NewResolver(c.secretsFile())
+	}
+	return c.resolver
+}
+
+// secretsFile is the age-encrypted file age: references read
+func (c *Config) secretsFile() string {
+	if path := os.Getenv("EWCTL_SECRETS_FILE"); path != "" {
+		return path
+	}
+	if c.SecretsFile != "" {
+		return expandHome(c.SecretsFile)
+	}
+	dir := filepath.Dir(c.path)
+	if c.path == "" {
+		var err error
+		if dir, err = getConfigDir(); err != nil {
+			dir = "."
+		}
+	}
+	return filepath.Join(dir, "secrets.age")
+}
+
+// resolveSecrets replaces secret references with the secrets they point
+// to. Failures are kept for Validate so commands that need no credentials
+// still work.
+func (c *Config) resolveSecrets() {
+	resolver := c.Resolver()
+	c.secrets = make(map[string]secret)
+	for key, field := range c.secretFields() {
+		if *field == "" {
+			continue
+		}
+		s := secret{raw: *field}
+		s.value, s.err = resolver.Resolve(*field)
+		*field = s.value
+		c.secrets[key] = s
+	}
+}
+
+// overrideSecret sets a credential from an environment variable
+func (c *Config) overrideSecret(key, env string) {
+	value := os.Getenv(env)
+	if value == "" {
+		return
+	}
+	*c.secretFields()[key] = value
+	s := c.secrets[key]
+	s.value, s.env, s.err = value, env, nil
+	c.secrets[key] = s
+}
+
+// SetSecret sets a credential to a plaintext value or to a reference,
+// which is resolved now and saved as the reference
+func (c *Config) SetSecret(key, value string) error {
+	field, ok := c.secretFields()[key]
+	if !ok {
+		return fmt.Errorf("%s is not a secret setting", key)
+	}
+	resolved, err := c.Resolver().Resolve(value)
+	if err != nil {
+		return err
+	}
+
+	// Copy so configs copied from this one keep their own record
+	updated := make(map[string]secret, len(c.secrets)+1)
+	for k, s := range c.secrets {
+		updated[k] = s
+	}
+	updated[key] = secret{raw: value, value: resolved}
+	c.secrets = updated
+	*field = resolved
+	return nil
+}
+
+// SecretSource says where a credential comes from, e.g.
+// "keyring ewctl/cloudflare" or "plaintext in config.yaml"
+func (c *Config) SecretSource(key string) string {
+	field, ok := c.secretFields()[key]
+	if !ok {
+		return ""
+	}
+	s, recorded := c.secrets[key]
+	switch {
+	case recorded && s.err != nil:
+		return "unresolved " + s.raw
+	case *field == "":
+		return "not set"
+	case !recorded || *field != s.value:
+		return "plaintext, unsaved"
+	case s.env != "":
+		return "environment $" + s.env
+	case c.Resolver().IsRef(s.raw):
+		return c.Resolver().Describe(s.raw)
+	}
+	return "plaintext in " + filepath.Base(c.path)
+}
+
+// SecretRef returns the reference a credential was loaded from, or empty
+// when it is plaintext or overridden by the environment
+func (c *Config) SecretRef(key string) string {
+	if s, ok := c.secrets[key]; ok && s.env == "" && c.Resolver().IsRef(s.raw) {
+		return s.raw
+	}
+	return ""
+}
+
+// restoreSecrets puts the file's references back into a copy of the config
+// for saving; changed credentials are saved as they are
+func (c *Config) restoreSecrets(out *Config) {
+	out.Alerts.Notifiers = append([]NotifierConfig(nil), c.Alerts.Notifiers...)
+	fields := out.secretFields()
+	for key, s := range c.secrets {
+		field, ok := fields[key]
+		if !ok || s.err != nil {
+			if ok && *field == "" {
+				*field = s.raw
+			}
+			continue
+		}
+		if *field == s.value && s.raw != "" {
+			*field = s.raw
+		}
+	}
+}
+
+func expandHome(path string) string {
+	if len(path) > 1 && path[:2] == "~/" {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
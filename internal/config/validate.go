@@ -0,0 +1,192 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// Cloudflare account IDs are 32 hex characters
+	accountIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	// D1 database IDs are UUIDs
+	databaseIDPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	// Z-API instance IDs and tokens are 32 hex characters, usually uppercase
+	zapiIDPattern = regexp.MustCompile(`^[0-9A-Fa-f]{32}$`)
+)
+
+// Problem is one thing wrong with the config
+type Problem struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+	// Warning marks problems ewctl works around, such as an unknown theme,
+	// an ID in an unexpected format or a queue setting it falls back on
+	Warning bool `json:"warning,omitempty"`
+}
+
+func (p Problem) Error() string {
+	return p.Key + " " + p.Message
+}
+
+// problems collects the findings of Check
+type problems []Problem
+
+func (ps *problems) add(key, format string, args ...interface{}) {
+	*ps = append(*ps, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+func (ps *problems) warn(key, format string, args ...interface{}) {
+	*ps = append(*ps, Problem{Key: key, Message: fmt.Sprintf(format, args...), Warning: true})
+}
+
+// Validate reports the first required setting that is missing or whose
+// secret reference cannot be resolved. It gates connecting to Cloudflare,
+// so anything else Check finds is left to ewctl config validate.
+func (c *Config) Validate() error {
+	var ps problems
+	for _, req := range c.required() {
+		c.checkRequired(&ps, req.key, req.value)
+	}
+	if len(ps) > 0 {
+		return ps[0]
+	}
+	return nil
+}
+
+// required lists the settings nothing works without
+func (c *Config) required() []struct{ key, value string } {
+	return []struct{ key, value string }{
+		{"cloudflare.account_id", c.Cloudflare.AccountID},
+		{"cloudflare.api_token", c.Cloudflare.APIToken},
+		{"cloudflare.database_id", c.Cloudflare.DatabaseID},
+		{"cloudflare.worker_url", c.Cloudflare.WorkerURL},
+	}
+}
+
+// Check returns every problem with the config, errors and warnings, in the
+// order of the file
+func (c *Config) Check() []Problem {
+	var ps problems
+
+	// Cloudflare
+	for _, req := range c.required() {
+		c.checkRequired(&ps, req.key, req.value)
+		switch id := req.value; {
+		case id == "":
+		case req.key == "cloudflare.account_id" && !accountIDPattern.MatchString(strings.ToLower(id)):
+			ps.warn(req.key, "%q does not look like a Cloudflare account ID (32 hex characters)", id)
+		case req.key == "cloudflare.database_id" && !databaseIDPattern.MatchString(strings.ToLower(id)):
+			ps.warn(req.key, "%q does not look like a D1 database ID (a UUID)", id)
+		}
+	}
+	checkURL(&ps, "cloudflare.worker_url", c.Cloudflare.WorkerURL)
+	checkURL(&ps, "cloudflare.api_url", c.Cloudflare.APIURL)
+
+	// Z-API is optional; without both the instance ID and token the
+	// features that need it are disabled
+	zapi := c.ZAPI
+	if err := c.secrets["zapi.instance_token"].err; err != nil {
+		ps.add("zapi.instance_token", "%v", err)
+	} else if zapi.InstanceID == "" && zapi.InstanceToken != "" {
+		ps.warn("zapi.instance_id", "is required with zapi.instance_token; Z-API features are disabled")
+	} else if zapi.InstanceID != "" && zapi.InstanceToken == "" {
+		ps.warn("zapi.instance_token", "is required with zapi.instance_id; Z-API features are disabled")
+	} else if zapi.InstanceID == "" && zapi.ClientToken != "" {
+		ps.warn("zapi.client_token", "is ignored without zapi.instance_id and zapi.instance_token")
+	}
+	if id := zapi.InstanceID; id != "" && !zapiIDPattern.MatchString(id) {
+		ps.warn("zapi.instance_id", "%q does not look like a Z-API instance ID (32 hex characters)", id)
+	}
+	if token := zapi.InstanceToken; token != "" && !zapiIDPattern.MatchString(token) {
+		ps.warn("zapi.instance_token", "does not look like a Z-API instance token (32 hex characters)")
+	}
+	if err := c.secrets["zapi.client_token"].err; err != nil {
+		ps.add("zapi.client_token", "%v", err)
+	}
+	checkURL(&ps, "zapi.base_url", zapi.BaseURL)
+
+	// UI; themes and key bindings are defined by the TUI, which checks them
+	if c.UI.AutoRefresh < 0 {
+		ps.add("ui.auto_refresh", "cannot be negative")
+	}
+
+	// Queue; the runner falls back on defaults for unusable values
+	if c.Queue.MaxAttempts < 1 {
+		ps.warn("queue.max_attempts", "must be at least 1; jobs get 5 attempts")
+	}
+	if c.Queue.BaseDelay < 0 {
+		ps.warn("queue.base_delay", "cannot be negative; failed jobs are retried right away")
+	}
+	if c.Queue.MaxDelay < 0 {
+		ps.warn("queue.max_delay", "cannot be negative; retries are not capped")
+	}
+	if c.Queue.MaxDelay > 0 && c.Queue.MaxDelay < c.Queue.BaseDelay {
+		ps.warn("queue.max_delay", "is shorter than queue.base_delay, which caps every retry at it")
+	}
+	if c.Queue.PollInterval < 0 {
+		ps.warn("queue.poll_interval", "cannot be negative; the queue is polled every 10s")
+	}
+	if c.Queue.BatchSize < 1 {
+		ps.warn("queue.batch_size", "must be at least 1; jobs are claimed one at a time")
+	}
+
+	// Alerts
+	if c.Alerts.Interval < 0 {
+		ps.add("alerts.interval", "cannot be negative")
+	}
+	if c.Alerts.Repeat < 0 {
+		ps.add("alerts.repeat", "cannot be negative")
+	}
+	for i, notifier := range c.Alerts.Notifiers {
+		for _, field := range []string{"password", "url"} {
+			key := fmt.Sprintf("alerts.notifiers.%d.%s", i, field)
+			if err := c.secrets[key].err; err != nil {
+				ps.add(key, "%v", err)
+			}
+		}
+		if notifier.Type == "webhook" || notifier.Type == "slack" {
+			checkURL(&ps, fmt.Sprintf("alerts.notifiers.%d.url", i), notifier.URL)
+		}
+	}
+
+	// Profiles
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		checkURL(&ps, "profiles."+name+".worker_url", c.Profiles[name].WorkerURL)
+	}
+	if c.Profile != "" {
+		if _, ok := c.Profiles[c.Profile]; !ok {
+			ps.add("profile", "%q is not defined under profiles (have %s)", c.Profile, strings.Join(names, ", "))
+		}
+	}
+	return ps
+}
+
+// checkRequired reports a missing value, or why a secret reference for it
+// could not be resolved
+func (c *Config) checkRequired(ps *problems, key, value string) {
+	if err := c.secrets[key].err; err != nil {
+		ps.add(key, "%v", err)
+		return
+	}
+	if value == "" {
+		ps.add(key, "is required")
+	}
+}
+
+// checkURL reports a set URL that is not an absolute http(s) URL
+func checkURL(ps *problems, key, value string) {
+	if value == "" {
+		return
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		ps.add(key, "must be an http:// or https:// URL, got %q", value)
+	}
+}
@@ -0,0 +1,68 @@
+package config
+
+import "testing"
+
+func validConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.Cloudflare.AccountID = "0123456789abcdef0123456789abcdef"
+	cfg.Cloudflare.APIToken = "token"
+	cfg.Cloudflare.DatabaseID = "01234567-89ab-cdef-0123-456789abcdef"
+	cfg.Cloudflare.WorkerURL = "https://forms.example.workers.dev"
+	return cfg
+}
+
+func TestValidateOnlyRequiresValues(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("Validate(valid) = %v", err)
+	}
+
+	cfg := validConfig()
+	cfg.Cloudflare.DatabaseID = ""
+	if err := cfg.Validate(); err == nil || err.(Problem).Key != "cloudflare.database_id" {
+		t.Errorf("Validate(no database) = %v", err)
+	}
+
+	// Format problems and queue settings are reported but don't block
+	cfg = validConfig()
+	cfg.Cloudflare.AccountID = "not-hex"
+	cfg.Cloudflare.DatabaseID = "legacy"
+	cfg.Cloudflare.APIURL = "localhost:8787"
+	cfg.Queue.BatchSize = 0
+	cfg.Queue.MaxAttempts = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate = %v, want format problems ignored", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	cfg := validConfig()
+	cfg.Cloudflare.AccountID = "not-hex"
+	cfg.Cloudflare.DatabaseID = "legacy"
+	cfg.Cloudflare.APIURL = "localhost:8787"
+	cfg.Queue.BatchSize = 0
+	cfg.Queue.MaxDelay = cfg.Queue.BaseDelay / 2
+
+	want := map[string]bool{
+		"cloudflare.account_id":  true,
+		"cloudflare.database_id": true,
+		"cloudflare.api_url":     false,
+		"queue.max_delay":        true,
+		"queue.batch_size":       true,
+	}
+	problems := cfg.Check()
+	if len(problems) != len(want) {
+		t.Errorf("Check = %v, want %d problems", problems, len(want))
+	}
+	for _, p := range problems {
+		warning, ok := want[p.Key]
+		if !ok {
+			t.Errorf("unexpected problem %v", p)
+		} else if p.Warning != warning {
+			t.Errorf("%v: Warning = %v, want %v", p, p.Warning, warning)
+		}
+	}
+
+	if problems := validConfig().Check(); len(problems) != 0 {
+		t.Errorf("Check(valid) = %v", problems)
+	}
+}
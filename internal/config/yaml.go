@@ -0,0 +1,158 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// encodeNode marshals v into a YAML node, writing durations as "30s"
+// rather than nanoseconds
+func encodeNode(v interface{}) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return nil, err
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = *node.Content[0]
+	}
+	formatDurations(&node, reflect.ValueOf(v))
+	return &node, nil
+}
+
+// formatDurations walks node alongside v and rewrites duration values
+func formatDurations(node *yaml.Node, v reflect.Value) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Type() == durationType:
+		node.Kind = yaml.ScalarNode
+		node.Tag = "!!str"
+		node.Style = 0
+		node.Value = time.Duration(v.Int()).String()
+
+	case v.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := yamlFields(v.Type())
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if index, ok := fields[node.Content[i].Value]; ok {
+				formatDurations(node.Content[i+1], v.Field(index))
+			}
+		}
+
+	case v.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := reflect.ValueOf(node.Content[i].Value)
+			if key.Type().ConvertibleTo(v.Type().Key()) {
+				if value := v.MapIndex(key.Convert(v.Type().Key())); value.IsValid() {
+					formatDurations(node.Content[i+1], value)
+				}
+			}
+		}
+
+	case v.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for i := 0; i < len(node.Content) && i < v.Len(); i++ {
+			formatDurations(node.Content[i], v.Index(i))
+		}
+	}
+}
+
+// mergeNode writes src's values into dst, where t is the Go type src was
+// encoded from. dst keeps its comments, key order and any keys t does not
+// know about; known keys that src left out (empty omitempty fields) are
+// removed.
+func mergeNode(dst, src *yaml.Node, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode ||
+		(t.Kind() != reflect.Struct && t.Kind() != reflect.Map) {
+		replaceNode(dst, src)
+		return
+	}
+
+	// known returns the type of a key, and whether t declares it
+	known := func(key string) (reflect.Type, bool) {
+		if t.Kind() == reflect.Map {
+			return t.Elem(), true
+		}
+		index, ok := yamlFields(t)[key]
+		if !ok {
+			return nil, false
+		}
+		return t.Field(index).Type, true
+	}
+
+	seen := make(map[string]bool)
+	content := make([]*yaml.Node, 0, len(dst.Content))
+	for i := 0; i+1 < len(dst.Content); i += 2 {
+		key, value := dst.Content[i], dst.Content[i+1]
+		fieldType, isKnown := known(key.Value)
+		if update := mappingValue(src, key.Value); update != nil {
+			seen[key.Value] = true
+			mergeNode(value, update, fieldType)
+		} else if isKnown {
+			continue
+		}
+		content = append(content, key, value)
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		if !seen[src.Content[i].Value] {
+			content = append(content, src.Content[i], src.Content[i+1])
+		}
+	}
+	dst.Content = content
+}
+
+// replaceNode overwrites dst with src but keeps dst's comments
+func replaceNode(dst, src *yaml.Node) {
+	head, line, foot := dst.HeadComment, dst.LineComment, dst.FootComment
+	*dst = *src
+	if dst.HeadComment == "" {
+		dst.HeadComment = head
+	}
+	if dst.LineComment == "" {
+		dst.LineComment = line
+	}
+	if dst.FootComment == "" {
+		dst.FootComment = foot
+	}
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlFields maps the YAML keys of a struct to its field indexes
+func yamlFields(t reflect.Type) map[string]int {
+	fields := make(map[string]int)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = i
+	}
+	return fields
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type testSettings struct {
+	Name    string            `yaml:"name"`
+	Note    string            `yaml:"note,omitempty"`
+	Timeout time.Duration     `yaml:"timeout"`
+	Retry   testRetry         `yaml:"retry"`
+	Delays  []time.Duration   `yaml:"delays,omitempty"`
+	Labels  map[string]string `yaml:"labels,omitempty"`
+}
+
+type testRetry struct {
+	Attempts int           `yaml:"attempts"`
+	Delay    time.Duration `yaml:"delay"`
+}
+
+func renderNode(t *testing.T, node *yaml.Node) string {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	return buf.String()
+}
+
+func TestMergeNode(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		v    testSettings
+		want string
+	}{
+		{
+			name: "keeps comments",
+			file: "# Settings\nname: old # who\ntimeout: 10s\nretry:\n  # how often\n  attempts: 1\n  delay: 1s\n",
+			v:    testSettings{Name: "new", Timeout: 10 * time.Second, Retry: testRetry{Attempts: 3, Delay: time.Second}},
+			want: "# Settings\nname: new # who\ntimeout: 10s\nretry:\n  # how often\n  attempts: 3\n  delay: 1s\n",
+		},
+		{
+			name: "keeps unknown keys",
+			file: "name: a\nextra: 1\ntimeout: 0s\nretry:\n  attempts: 1\n  delay: 0s\n  jitter: true\n",
+			v:    testSettings{Name: "b", Retry: testRetry{Attempts: 1}},
+			want: "name: b\nextra: 1\ntimeout: 0s\nretry:\n  attempts: 1\n  delay: 0s\n  jitter: true\n",
+		},
+		{
+			name: "removes empty omitempty keys",
+			file: "name: a\nnote: gone\ntimeout: 0s\nretry:\n  attempts: 1\n  delay: 0s\nlabels:\n  env: prod\n",
+			v:    testSettings{Name: "a", Retry: testRetry{Attempts: 1}},
+			want: "name: a\ntimeout: 0s\nretry:\n  attempts: 1\n  delay: 0s\n",
+		},
+		{
+			name: "appends new keys",
+			file: "name: a\n",
+			v:    testSettings{Name: "a", Note: "hi", Timeout: time.Minute, Retry: testRetry{Attempts: 2}},
+			want: "name: a\nnote: hi\ntimeout: 1m0s\nretry:\n  attempts: 2\n  delay: 0s\n",
+		},
+		{
+			name: "renders durations",
+			file: "name: a\ntimeout: 1m\nretry:\n  attempts: 1\n  delay: 5s\n",
+			v:    testSettings{Name: "a", Timeout: 30 * time.Second, Retry: testRetry{Attempts: 1, Delay: 1500 * time.Millisecond}},
+			want: "name: a\ntimeout: 30s\nretry:\n  attempts: 1\n  delay: 1.5s\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.file), &doc); err != nil {
+				t.Fatalf("failed to parse file: %v", err)
+			}
+			src, err := encodeNode(tt.v)
+			if err != nil {
+				t.Fatalf("failed to encode: %v", err)
+			}
+			mergeNode(doc.Content[0], src, reflect.TypeOf(tt.v))
+
+			if got := renderNode(t, &doc); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDurations(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{
+			name: "struct fields",
+			v:    testRetry{Attempts: 3, Delay: 30 * time.Second},
+			want: "attempts: 3\ndelay: 30s\n",
+		},
+		{
+			name: "pointer",
+			v:    &testRetry{Delay: 2 * time.Hour},
+			want: "attempts: 0\ndelay: 2h0m0s\n",
+		},
+		{
+			name: "slice",
+			v:    testSettings{Delays: []time.Duration{time.Second, 90 * time.Second}},
+			want: "name: \"\"\ntimeout: 0s\nretry:\n  attempts: 0\n  delay: 0s\ndelays:\n  - 1s\n  - 1m30s\n",
+		},
+		{
+			name: "map values",
+			v:    map[string]time.Duration{"poll": 10 * time.Second},
+			want: "poll: 10s\n",
+		},
+		{
+			name: "other values untouched",
+			v:    map[string]int{"batch": 30},
+			want: "batch: 30\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := encodeNode(tt.v)
+			if err != nil {
+				t.Fatalf("failed to encode: %v", err)
+			}
+			if got := renderNode(t, node); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
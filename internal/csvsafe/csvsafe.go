@@ -0,0 +1,30 @@
+// Package csvsafe escapes CSV cells that spreadsheets would evaluate as
+// formulas, for exports of data anyone filling a form can write
+package csvsafe
+
+import "strings"
+
+// formulaPrefixes start cells spreadsheets evaluate as formulas
+const formulaPrefixes = "=+-@\t\r"
+
+// EscapeCells quotes cells a spreadsheet would run as a formula with a
+// leading '
+func EscapeCells(cells []string) []string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		if cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+			cell = "'" + cell
+		}
+		escaped[i] = cell
+	}
+	return escaped
+}
+
+// Unescape reverses EscapeCells for one cell, so exports read back as
+// they were written
+func Unescape(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(cell[1])) {
+		return cell[1:]
+	}
+	return cell
+}
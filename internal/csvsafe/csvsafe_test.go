@@ -0,0 +1,18 @@
+package csvsafe
+
+import "testing"
+
+func TestEscapeCells(t *testing.T) {
+	cells := []string{"", "Alice", "=HYPERLINK(\"x\")", "+5511999990001", "-1", "@SUM(A1)", "\tx", "a=b", "'quoted"}
+	want := []string{"", "Alice", "'=HYPERLINK(\"x\")", "'+5511999990001", "'-1", "'@SUM(A1)", "'\tx", "a=b", "'quoted"}
+
+	got := EscapeCells(cells)
+	for i := range cells {
+		if got[i] != want[i] {
+			t.Errorf("EscapeCells(%q) = %q, want %q", cells[i], got[i], want[i])
+		}
+		if back := Unescape(got[i]); back != cells[i] {
+			t.Errorf("Unescape(%q) = %q, want %q", got[i], back, cells[i])
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HourlyCount is how many rows fell in one UTC hour; Success counts the
+// deliveries among them that reached every recipient
+type HourlyCount struct {
+	Hour    time.Time
+	Count   int
+	Success int
+}
+
+// RecipientVolume is how many messages one phone number was sent
+type RecipientVolume struct {
+	Phone  string `json:"phone"`
+	Sent   int    `json:"sent"`
+	Failed int    `json:"failed"`
+}
+
+// GetSubmissionsByHour counts submissions per UTC hour in [since, until)
+func (c *Client) GetSubmissionsByHour(since, until time.Time, formID string) ([]HourlyCount, error) {
+	where, params := rangeWhere("", since, until, formID)
+	query := `
+		SELECT strftime('%Y-%m-%d %H:00:00', created_at) as hour, COUNT(*) as count
+		FROM submissions` + where + `
+		GROUP BY hour ORDER BY hour`
+
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count submissions: %w", err)
+	}
+	return hourlyCounts(result.Results), nil
+}
+
+// GetDeliveriesByHour counts deliveries per UTC hour in [since, until)
+func (c *Client) GetDeliveriesByHour(since, until time.Time, formID string) ([]HourlyCount, error) {
+	where, params := rangeWhere("", since, until, formID)
+	query := `
+		SELECT strftime('%Y-%m-%d %H:00:00', created_at) as hour, COUNT(*) as count,
+			SUM(CASE WHEN status IN (?, ?) THEN 1 ELSE 0 END) as success
+		FROM webhook_logs` + where + `
+		GROUP BY hour ORDER BY hour`
+
+	params = append([]interface{}{DeliverySuccess, DeliveryResent}, params...)
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count deliveries: %w", err)
+	}
+	return hourlyCounts(result.Results), nil
+}
+
+// GetDeliveryDurations retrieves how long each delivery in [since, until)
+// took, in milliseconds, shortest first
+func (c *Client) GetDeliveryDurations(since, until time.Time, formID string) ([]int, error) {
+	where, params := rangeWhere("", since, until, formID)
+	if where == "" {
+		where = " WHERE duration_ms IS NOT NULL"
+	} else {
+		where += " AND duration_ms IS NOT NULL"
+	}
+
+	result, err := c.Query("SELECT duration_ms FROM webhook_logs"+where+" ORDER BY duration_ms", params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delivery durations: %w", err)
+	}
+
+	durations := make([]int, 0, len(result.Results))
+	for _, row := range result.Results {
+		if ms, ok := row["duration_ms"].(float64); ok {
+			durations = append(durations, int(ms))
+		}
+	}
+	return durations, nil
+}
+
+// GetDeliveriesByForm counts deliveries per form in [since, until)
+func (c *Client) GetDeliveriesByForm(since, until time.Time) (map[string]int, error) {
+	where, params := rangeWhere("", since, until, "")
+	query := "SELECT form_id, COUNT(*) as count FROM webhook_logs" + where + " GROUP BY form_id"
+
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count deliveries by form: %w", err)
+	}
+
+	counts := make(map[string]int, len(result.Results))
+	for _, row := range result.Results {
+		formID, _ := row["form_id"].(string)
+		if count, ok := row["count"].(float64); ok {
+			counts[formID] = int(count)
+		}
+	}
+	return counts, nil
+}
+
+// GetDeliveriesByRecipient counts sent and failed messages per phone number
+// for deliveries in [since, until), busiest first
+func (c *Client) GetDeliveriesByRecipient(since, until time.Time, formID string) ([]RecipientVolume, error) {
+	where, params := rangeWhere("l.", since, until, formID)
+	query := `
+		SELECT r.phone,
+			SUM(CASE WHEN r.status = ? THEN 1 ELSE 0 END) as sent,
+			SUM(CASE WHEN r.status = ? THEN 1 ELSE 0 END) as failed
+		FROM delivery_recipients r
+		JOIN webhook_logs l ON l.id = r.log_id` + where + `
+		GROUP BY r.phone
+		ORDER BY sent + failed DESC`
+
+	params = append([]interface{}{RecipientSent, RecipientFailed}, params...)
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count deliveries by recipient: %w", err)
+	}
+
+	var volumes []RecipientVolume
+	for _, row := range result.Results {
+		volume := RecipientVolume{}
+		volume.Phone, _ = row["phone"].(string)
+		if sent, ok := row["sent"].(float64); ok {
+			volume.Sent = int(sent)
+		}
+		if failed, ok := row["failed"].(float64); ok {
+			volume.Failed = int(failed)
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes, nil
+}
+
+// rangeWhere filters created_at to [since, until) and, when set, one form;
+// prefix qualifies the columns with a table alias
+func rangeWhere(prefix string, since, until time.Time, formID string) (string, []interface{}) {
+	var conditions []string
+	var params []interface{}
+
+	if !since.IsZero() {
+		conditions = append(conditions, prefix+"created_at >= ?")
+		params = append(params, sqlTime(since))
+	}
+	if !until.IsZero() {
+		conditions = append(conditions, prefix+"created_at < ?")
+		params = append(params, sqlTime(until))
+	}
+	if formID != "" {
+		conditions = append(conditions, prefix+"form_id = ?")
+		params = append(params, formID)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), params
+}
+
+func hourlyCounts(rows []map[string]interface{}) []HourlyCount {
+	counts := make([]HourlyCount, 0, len(rows))
+	for _, row := range rows {
+		raw, _ := row["hour"].(string)
+		hour, err := time.ParseInLocation("2006-01-02 15:04:05", raw, time.UTC)
+		if err != nil {
+			continue
+		}
+		count := HourlyCount{Hour: hour}
+		if n, ok := row["count"].(float64); ok {
+			count.Count = int(n)
+		}
+		if n, ok := row["success"].(float64); ok {
+			count.Success = int(n)
+		}
+		counts = append(counts, count)
+	}
+	return counts
+}
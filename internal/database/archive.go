@@ -0,0 +1,280 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// ArchiveForm stops a form from receiving submissions while keeping its configuration
+func (c *Client) ArchiveForm(id string) error {
+	before := c.formAuditState(id)
+
+	result, err := c.Query(
+		"UPDATE forms SET archived_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND archived_at IS NULL",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to archive form: %w", err)
+	}
+	if result.Meta.Changes == 0 {
+		return fmt.Errorf("form %s not found or already archived", id)
+	}
+	c.auditForm("archive", id, before)
+	return nil
+}
+
+// UnarchiveForm puts an archived form back into service
+func (c *Client) UnarchiveForm(id string) error {
+	before := c.formAuditState(id)
+
+	result, err := c.Query(
+		"UPDATE forms SET archived_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND archived_at IS NOT NULL",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to restore form: %w", err)
+	}
+	if result.Meta.Changes == 0 {
+		return fmt.Errorf("form %s not found or not archived", id)
+	}
+	c.auditForm("restore", id, before)
+	return nil
+}
+
+// GetArchivedForms retrieves archived forms, most recently archived first
+func (c *Client) GetArchivedForms() ([]FormWithStats, error) {
+	query := `
+		SELECT
+			f.id,
+			f.name,
+			f.description,
+			f.created_at,
+			f.updated_at,
+			f.archived_at,
+			f.enabled,
+			f.paused_until,
+			COUNT(DISTINCT ff.id) as field_count,
+			COUNT(DISTINCT fn.id) as number_count
+		FROM forms f
+		LEFT JOIN form_fields ff ON f.id = ff.form_id
+		LEFT JOIN form_numbers fn ON f.id = fn.form_id
+		WHERE f.archived_at IS NOT NULL
+		GROUP BY f.id
+		ORDER BY f.archived_at DESC
+	`
+
+	result, err := c.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived forms: %w", err)
+	}
+
+	var forms []FormWithStats
+	for _, row := range result.Results {
+		form := FormWithStats{}
+
+		if id, ok := row["id"].(string); ok {
+			form.ID = id
+		}
+		if name, ok := row["name"].(string); ok {
+			form.Name = name
+		}
+		if desc, ok := row["description"].(string); ok {
+			form.Description = desc
+		}
+		form.CreatedAt = parseTime(row["created_at"])
+		form.UpdatedAt = parseTime(row["updated_at"])
+		form.ArchivedAt = parseTimePtr(row["archived_at"])
+		applyPauseState(&form.Form, row)
+		if count, ok := row["field_count"].(float64); ok {
+			form.FieldCount = int(count)
+		}
+		if count, ok := row["number_count"].(float64); ok {
+			form.NumberCount = int(count)
+		}
+
+		forms = append(forms, form)
+	}
+
+	return forms, nil
+}
+
+// PurgeArchivedForms permanently deletes forms archived longer than retention
+func (c *Client) PurgeArchivedForms(retention time.Duration) ([]string, error) {
+	cutoff := sqlTime(time.Now().Add(-retention))
+	result, err := c.Query("SELECT id FROM forms WHERE archived_at IS NOT NULL AND archived_at <= ?", cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find forms to purge: %w", err)
+	}
+
+	var purged []string
+	for _, row := range result.Results {
+		id, ok := row["id"].(string)
+		if !ok {
+			continue
+		}
+		before := c.formAuditState(id)
+		if err := c.deleteForm(id); err != nil {
+			log.Error("Failed to purge form", "id", id, "error", err)
+			continue
+		}
+		if _, err := c.Query("DELETE FROM form_versions WHERE form_id = ?", id); err != nil {
+			log.Warn("Failed to purge form versions", "id", id, "error", err)
+		}
+		c.auditForm("purge", id, before)
+		purged = append(purged, id)
+	}
+
+	return purged, nil
+}
+
+// ArchiveContact hides a contact and stops the worker from messaging it,
+// keeping its form links so it can be restored
+func (c *Client) ArchiveContact(id int) error {
+	before := c.contactAuditState(id)
+
+	result, err := c.Query(
+		"UPDATE contacts SET archived_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND archived_at IS NULL",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to archive contact: %w", err)
+	}
+	if result.Meta.Changes == 0 {
+		return fmt.Errorf("contact %d not found or already archived", id)
+	}
+	c.auditContact("archive", id, before)
+	return nil
+}
+
+// UnarchiveContact puts an archived contact back on its forms
+func (c *Client) UnarchiveContact(id int) error {
+	before := c.contactAuditState(id)
+
+	result, err := c.Query(
+		"UPDATE contacts SET archived_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND archived_at IS NOT NULL",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to restore contact: %w", err)
+	}
+	if result.Meta.Changes == 0 {
+		return fmt.Errorf("contact %d not found or not archived", id)
+	}
+	c.auditContact("restore", id, before)
+	return nil
+}
+
+// GetArchivedContacts retrieves archived contacts with the forms they are still linked to
+func (c *Client) GetArchivedContacts() ([]ContactWithStats, error) {
+	query := `
+		SELECT
+			c.*,
+			COUNT(DISTINCT fn.form_id) as form_count,
+			GROUP_CONCAT(DISTINCT fn.form_id) as form_ids
+		FROM contacts c
+		LEFT JOIN form_numbers fn ON c.id = fn.contact_id
+		WHERE c.archived_at IS NOT NULL
+		GROUP BY c.id
+		ORDER BY c.archived_at DESC
+	`
+
+	result, err := c.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived contacts: %w", err)
+	}
+
+	var contacts []ContactWithStats
+	for _, row := range result.Results {
+		contact := ContactWithStats{Contact: contactFromRow(row)}
+		if count, ok := row["form_count"].(float64); ok {
+			contact.FormCount = int(count)
+		}
+		if formIDs, ok := row["form_ids"].(string); ok && formIDs != "" {
+			contact.FormIDs = strings.Split(formIDs, ",")
+		}
+		contacts = append(contacts, contact)
+	}
+
+	return contacts, nil
+}
+
+// PurgeArchivedContacts permanently deletes contacts archived longer than retention
+func (c *Client) PurgeArchivedContacts(retention time.Duration) ([]int, error) {
+	cutoff := sqlTime(time.Now().Add(-retention))
+	result, err := c.Query("SELECT id FROM contacts WHERE archived_at IS NOT NULL AND archived_at <= ?", cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find contacts to purge: %w", err)
+	}
+
+	var purged []int
+	for _, row := range result.Results {
+		id, ok := row["id"].(float64)
+		if !ok {
+			continue
+		}
+		before := c.contactAuditState(int(id))
+		if err := c.deleteContact(int(id)); err != nil {
+			log.Error("Failed to purge contact", "id", int(id), "error", err)
+			continue
+		}
+		c.auditContact("purge", int(id), before)
+		purged = append(purged, int(id))
+	}
+
+	return purged, nil
+}
+
+func contactFromRow(row map[string]interface{}) Contact {
+	contact := Contact{}
+
+	if id, ok := row["id"].(float64); ok {
+		contact.ID = int(id)
+	}
+	if phone, ok := row["phone_number"].(string); ok {
+		contact.PhoneNumber = phone
+	}
+	if name, ok := row["name"].(string); ok {
+		contact.Name = name
+	}
+	if company, ok := row["company"].(string); ok {
+		contact.Company = company
+	}
+	if role, ok := row["role"].(string); ok {
+		contact.Role = role
+	}
+	if notes, ok := row["notes"].(string); ok {
+		contact.Notes = notes
+	}
+	contact.CreatedAt = parseTime(row["created_at"])
+	contact.UpdatedAt = parseTime(row["updated_at"])
+	contact.ArchivedAt = parseTimePtr(row["archived_at"])
+
+	return contact
+}
+
+// parseTime reads a timestamp column. D1 returns CURRENT_TIMESTAMP
+// values as "2006-01-02 15:04:05" in UTC, while imported rows may use RFC3339.
+func parseTime(value interface{}) time.Time {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t
+	}
+	if t, err := time.Parse("2006-01-02 15:04:05", s); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+func parseTimePtr(value interface{}) *time.Time {
+	t := parseTime(value)
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
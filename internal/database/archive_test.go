@@ -0,0 +1,54 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPurgeArchivedContacts(t *testing.T) {
+	c := newTestClient(t)
+	createTestForm(t, c, "contact")
+	old := createTestContact(t, c, "5511999990001")
+	recent := createTestContact(t, c, "5511999990002")
+	for _, id := range []int{old, recent} {
+		if err := c.createFormNumber(&Number{FormID: "contact", PhoneNumber: "x", ContactID: &id}); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.ArchiveContact(id); err != nil {
+			t.Fatalf("ArchiveContact: %v", err)
+		}
+	}
+	if err := c.ArchiveContact(old); err == nil {
+		t.Error("archiving an archived contact should fail")
+	}
+	if _, err := c.Query("UPDATE contacts SET archived_at = datetime('now', '-40 days') WHERE id = ?", old); err != nil {
+		t.Fatal(err)
+	}
+
+	purged, err := c.PurgeArchivedContacts(30 * 24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(purged) != 1 || purged[0] != old {
+		t.Fatalf("purged %v, want [%d]", purged, old)
+	}
+	if contact, _ := c.GetContactByID(old); contact != nil {
+		t.Error("purged contact still exists")
+	}
+	// A purged contact's numbers must go too, or the worker would
+	// message them again once they are no longer linked to an archived
+	// contact
+	if n := count(t, c, "SELECT COUNT(*) FROM form_numbers WHERE contact_id IS NULL OR contact_id = ?", old); n != 0 {
+		t.Errorf("%d numbers of the purged contact left", n)
+	}
+	if n := count(t, c, "SELECT COUNT(*) FROM form_numbers WHERE contact_id = ?", recent); n != 1 {
+		t.Error("numbers of the contact archived recently were removed")
+	}
+
+	if err := c.UnarchiveContact(recent); err != nil {
+		t.Fatalf("UnarchiveContact: %v", err)
+	}
+	if archived, _ := c.GetArchivedContacts(); len(archived) != 0 {
+		t.Errorf("%d archived contacts left", len(archived))
+	}
+}
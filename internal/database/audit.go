@@ -0,0 +1,314 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// Entity types recorded in the audit log
+const (
+	AuditForm    = "form"
+	AuditContact = "contact"
+)
+
+// AuditFilter narrows GetAuditEntries; zero values match everything
+type AuditFilter struct {
+	EntityType string
+	EntityID   string
+	Actor      string
+	Action     string
+	Since      time.Time
+	Limit      int
+}
+
+// AuditChange is one top-level difference between an entry's before and
+// after states. List values report the items added and removed instead.
+type AuditChange struct {
+	Field   string
+	Before  string
+	After   string
+	Added   []string
+	Removed []string
+}
+
+// formAuditState is what the audit log records about a form. Fields and
+// recipients are flattened to strings so row IDs, which change on every
+// save, don't show up as differences.
+type formAuditState struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Enabled     bool     `json:"enabled"`
+	PausedUntil string   `json:"paused_until,omitempty"`
+	ArchivedAt  string   `json:"archived_at,omitempty"`
+	Fields      []string `json:"fields"`
+	Recipients  []string `json:"recipients"`
+}
+
+// contactAuditState is what the audit log records about a contact
+type contactAuditState struct {
+	Name        string   `json:"name"`
+	PhoneNumber string   `json:"phone_number"`
+	Company     string   `json:"company,omitempty"`
+	Role        string   `json:"role,omitempty"`
+	Notes       string   `json:"notes,omitempty"`
+	ArchivedAt  string   `json:"archived_at,omitempty"`
+	Forms       []string `json:"forms"`
+}
+
+// GetAuditEntries retrieves audit entries, newest first
+func (c *Client) GetAuditEntries(filter AuditFilter) ([]AuditEntry, error) {
+	var conditions []string
+	var params []interface{}
+
+	if filter.EntityType != "" {
+		conditions = append(conditions, "entity_type = ?")
+		params = append(params, filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		conditions = append(conditions, "entity_id = ?")
+		params = append(params, filter.EntityID)
+	}
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor = ?")
+		params = append(params, filter.Actor)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		params = append(params, filter.Action)
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		params = append(params, sqlTime(filter.Since))
+	}
+
+	query := "SELECT * FROM audit_log"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+
+	var entries []AuditEntry
+	for _, row := range result.Results {
+		entry := AuditEntry{}
+
+		if id, ok := row["id"].(float64); ok {
+			entry.ID = int(id)
+		}
+		entry.Actor, _ = row["actor"].(string)
+		entry.Profile, _ = row["profile"].(string)
+		entry.Action, _ = row["action"].(string)
+		entry.EntityType, _ = row["entity_type"].(string)
+		entry.EntityID, _ = row["entity_id"].(string)
+		if before, ok := row["before_json"].(string); ok && before != "" {
+			entry.Before = json.RawMessage(before)
+		}
+		if after, ok := row["after_json"].(string); ok && after != "" {
+			entry.After = json.RawMessage(after)
+		}
+		entry.CreatedAt = parseTime(row["created_at"])
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Changes lists what differs between the entry's before and after states
+func (e AuditEntry) Changes() []AuditChange {
+	before := decodeAuditState(e.Before)
+	after := decodeAuditState(e.After)
+
+	keys := make(map[string]bool)
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+	var fields []string
+	for key := range keys {
+		fields = append(fields, key)
+	}
+	sort.Strings(fields)
+
+	var changes []AuditChange
+	for _, field := range fields {
+		if bytes.Equal(before[field], after[field]) {
+			continue
+		}
+
+		change := AuditChange{Field: field}
+		beforeList, beforeIsList := decodeAuditList(before[field])
+		afterList, afterIsList := decodeAuditList(after[field])
+		if beforeIsList || afterIsList {
+			change.Added = listDifference(afterList, beforeList)
+			change.Removed = listDifference(beforeList, afterList)
+			if len(change.Added) == 0 && len(change.Removed) == 0 {
+				continue
+			}
+		} else {
+			change.Before = auditValue(before[field])
+			change.After = auditValue(after[field])
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// audit records a change under the actor and profile active when it is
+// made. Failures are logged rather than returned so an older database
+// without the audit table never blocks the change itself.
+func (c *Client) audit(action, entityType, entityID string, before, after interface{}) {
+	query := `
+		INSERT INTO audit_log (actor, profile, action, entity_type, entity_id, before_json, after_json)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := c.Query(query, c.config.AuditActor(), c.config.ProfileName(), action, entityType, entityID, auditJSON(before), auditJSON(after))
+	if err != nil {
+		log.Warn("Failed to write audit entry", "action", action, "entity", entityType, "id", entityID, "error", err)
+	}
+}
+
+// auditForm records a form change, reading the after state from the database
+func (c *Client) auditForm(action, id string, before *formAuditState) {
+	c.audit(action, AuditForm, id, before, c.formAuditState(id))
+}
+
+// auditContact records a contact change, reading the after state from the database
+func (c *Client) auditContact(action string, id int, before *contactAuditState) {
+	c.audit(action, AuditContact, strconv.Itoa(id), before, c.contactAuditState(id))
+}
+
+// formAuditState captures a form for the audit log, or nil if it does not exist
+func (c *Client) formAuditState(id string) *formAuditState {
+	form, err := c.GetForm(id)
+	if err != nil {
+		return nil
+	}
+
+	state := &formAuditState{
+		Name:        form.Name,
+		Description: form.Description,
+		Enabled:     form.Enabled,
+		PausedUntil: auditTime(form.PausedUntil),
+		ArchivedAt:  auditTime(form.ArchivedAt),
+		Fields:      []string{},
+		Recipients:  []string{},
+	}
+	for _, field := range form.Fields {
+		entry := fmt.Sprintf("%s → %s (%s)", field.ElementorID, field.Label, field.Type)
+		if field.Required {
+			entry += " *"
+		}
+		state.Fields = append(state.Fields, entry)
+	}
+	for _, number := range form.Numbers {
+		entry := number.PhoneNumber
+		if number.Label != "" {
+			entry = fmt.Sprintf("%s (%s)", number.PhoneNumber, number.Label)
+		}
+		state.Recipients = append(state.Recipients, entry)
+	}
+	return state
+}
+
+// contactAuditState captures a contact for the audit log, or nil if it does not exist
+func (c *Client) contactAuditState(id int) *contactAuditState {
+	contact, err := c.GetContactByID(id)
+	if err != nil {
+		return nil
+	}
+
+	state := &contactAuditState{
+		Name:        contact.Name,
+		PhoneNumber: contact.PhoneNumber,
+		Company:     contact.Company,
+		Role:        contact.Role,
+		Notes:       contact.Notes,
+		ArchivedAt:  auditTime(contact.ArchivedAt),
+		Forms:       []string{},
+	}
+
+	result, err := c.Query("SELECT DISTINCT form_id FROM form_numbers WHERE contact_id = ? ORDER BY form_id", id)
+	if err == nil {
+		for _, row := range result.Results {
+			if formID, ok := row["form_id"].(string); ok {
+				state.Forms = append(state.Forms, formID)
+			}
+		}
+	}
+	return state
+}
+
+func auditJSON(state interface{}) interface{} {
+	data, err := json.Marshal(state)
+	if err != nil || string(data) == "null" {
+		return nil
+	}
+	return string(data)
+}
+
+func auditTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func decodeAuditState(data json.RawMessage) map[string]json.RawMessage {
+	state := make(map[string]json.RawMessage)
+	if len(data) > 0 {
+		_ = json.Unmarshal(data, &state)
+	}
+	return state
+}
+
+func decodeAuditList(data json.RawMessage) ([]string, bool) {
+	var list []string
+	if len(data) == 0 || json.Unmarshal(data, &list) != nil {
+		return nil, false
+	}
+	return list, true
+}
+
+func auditValue(data json.RawMessage) string {
+	if len(data) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		return s
+	}
+	return string(data)
+}
+
+// listDifference returns the items of a that are not in b
+func listDifference(a, b []string) []string {
+	seen := make(map[string]bool)
+	for _, item := range b {
+		seen[item] = true
+	}
+	var diff []string
+	for _, item := range a {
+		if !seen[item] {
+			diff = append(diff, item)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+)
+
+func TestAuditRecordsTheActiveProfile(t *testing.T) {
+	c := newTestClient(t)
+	c.config.Profiles = map[string]config.Profile{"staging": {}}
+	createTestForm(t, c, "contact")
+
+	if err := c.config.SetProfile("staging"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ArchiveForm("contact"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := c.GetAuditEntries(AuditFilter{EntityType: AuditForm, EntityID: "contact"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("%d audit entries, want create and archive", len(entries))
+	}
+	for _, want := range []struct{ action, profile string }{{"archive", "staging"}, {"create", "default"}} {
+		entry := entries[0]
+		entries = entries[1:]
+		if entry.Action != want.action || entry.Profile != want.profile || entry.Actor != "test" {
+			t.Errorf("entry = %s by %s on %s, want %s by test on %s", entry.Action, entry.Actor, entry.Profile, want.action, want.profile)
+		}
+	}
+}
@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
 )
 
+// DefaultAPIURL is the Cloudflare API endpoint
+const DefaultAPIURL = "https://api.cloudflare.com/client/v4"
+
 // Client represents a Cloudflare D1 database client
 type Client struct {
 	config     *config.Config
@@ -24,9 +28,19 @@ func NewClient(cfg *config.Config) (*Client, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
+	return NewUncheckedClient(cfg), nil
+}
 
+// NewUncheckedClient creates a client without validating the config, for
+// trying the Cloudflare credentials before the rest is filled in
+func NewUncheckedClient(cfg *config.Config) *Client {
+	apiURL := DefaultAPIURL
+	if cfg.Cloudflare.APIURL != "" {
+		apiURL = strings.TrimRight(cfg.Cloudflare.APIURL, "/")
+	}
 	baseURL := fmt.Sprintf(
-		"https://api.cloudflare.com/client/v4/accounts/%s/d1/database/%s",
+		"%s/accounts/%s/d1/database/%s",
+		apiURL,
 		cfg.Cloudflare.AccountID,
 		cfg.Cloudflare.DatabaseID,
 	)
@@ -37,7 +51,7 @@ func NewClient(cfg *config.Config) (*Client, error) {
 			Timeout: 30 * time.Second,
 		},
 		baseURL: baseURL,
-	}, nil
+	}
 }
 
 // Query executes a SQL query against the D1 database
@@ -90,23 +104,45 @@ func (c *Client) Query(sql string, params ...interface{}) (*D1Result, error) {
 	return &d1Resp.Result[0], nil
 }
 
+// Ping checks that the D1 database answers queries
+func (c *Client) Ping() error {
+	if _, err := c.Query("SELECT 1"); err != nil {
+		return fmt.Errorf("failed to reach D1: %w", err)
+	}
+	return nil
+}
+
 // GetStats retrieves dashboard statistics
 func (c *Client) GetStats() (*Stats, error) {
 	stats := &Stats{}
 
-	// Get form counts
-	result, err := c.Query("SELECT COUNT(*) as count FROM forms")
+	// Get form counts; archived forms no longer receive submissions and
+	// paused forms buffer them
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(*) as count,
+			SUM(CASE WHEN archived_at IS NULL AND %[1]s THEN 1 ELSE 0 END) as paused,
+			SUM(CASE WHEN archived_at IS NULL AND NOT (%[1]s) THEN 1 ELSE 0 END) as active
+		FROM forms
+	`, pausedCondition)
+	now := sqlTime(time.Now())
+	result, err := c.Query(query, now, now)
 	if err != nil {
 		log.Error("Failed to get form count", "error", err)
 	} else if len(result.Results) > 0 {
 		if count, ok := result.Results[0]["count"].(float64); ok {
 			stats.TotalForms = int(count)
-			stats.ActiveForms = int(count) // TODO: Add active status field
+		}
+		if active, ok := result.Results[0]["active"].(float64); ok {
+			stats.ActiveForms = int(active)
+		}
+		if paused, ok := result.Results[0]["paused"].(float64); ok {
+			stats.PausedForms = int(paused)
 		}
 	}
 
 	// Get contact count
-	result, err = c.Query("SELECT COUNT(*) as count FROM contacts")
+	result, err = c.Query("SELECT COUNT(*) as count FROM contacts WHERE archived_at IS NULL")
 	if err != nil {
 		log.Error("Failed to get contact count", "error", err)
 	} else if len(result.Results) > 0 {
@@ -129,6 +165,26 @@ func (c *Client) GetStats() (*Stats, error) {
 		}
 	}
 
+	// Get webhook count since Monday
+	y, m, d := time.Now().Date()
+	weekStart := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	weekStart = weekStart.AddDate(0, 0, -((int(weekStart.Weekday()) + 6) % 7))
+	result, err = c.Query("SELECT COUNT(*) as count FROM webhook_logs WHERE created_at >= ?", sqlTime(weekStart))
+	if err != nil {
+		log.Error("Failed to get weekly webhook count", "error", err)
+	} else if len(result.Results) > 0 {
+		if count, ok := result.Results[0]["count"].(float64); ok {
+			stats.WebhooksThisWeek = int(count)
+		}
+	}
+
+	// Older databases have no lead tracking yet
+	if leads, err := c.GetLeadCounts(""); err != nil {
+		log.Warn("Failed to get lead counts", "error", err)
+	} else {
+		stats.Leads = leads
+	}
+
 	// Get last webhook time
 	result, err = c.Query("SELECT created_at FROM webhook_logs ORDER BY created_at DESC LIMIT 1")
 	if err != nil {
@@ -158,7 +214,10 @@ func (c *Client) InitSchema() error {
 			name TEXT NOT NULL,
 			description TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			archived_at DATETIME,
+			enabled BOOLEAN DEFAULT 1,
+			paused_until DATETIME
 		)`,
 		`CREATE TABLE IF NOT EXISTS form_fields (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -178,7 +237,8 @@ func (c *Client) InitSchema() error {
 			role TEXT,
 			notes TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			archived_at DATETIME
 		)`,
 		`CREATE TABLE IF NOT EXISTS form_numbers (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -199,6 +259,115 @@ func (c *Client) InitSchema() error {
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (form_id) REFERENCES forms(id) ON DELETE CASCADE
 		)`,
+		`CREATE TABLE IF NOT EXISTS delivery_recipients (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			log_id INTEGER NOT NULL,
+			phone TEXT NOT NULL,
+			status TEXT NOT NULL,
+			attempts INTEGER DEFAULT 1,
+			last_error TEXT,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (log_id) REFERENCES webhook_logs(id) ON DELETE CASCADE,
+			UNIQUE(log_id, phone)
+		)`,
+		`CREATE TABLE IF NOT EXISTS outbound_jobs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			form_id TEXT,
+			log_id INTEGER,
+			phone TEXT NOT NULL,
+			message TEXT NOT NULL,
+			status TEXT NOT NULL DEFAULT 'pending',
+			attempts INTEGER DEFAULT 0,
+			max_attempts INTEGER DEFAULT 5,
+			next_attempt_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			locked_by TEXT,
+			locked_until DATETIME,
+			last_error TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS form_versions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			form_id TEXT NOT NULL,
+			version INTEGER NOT NULL,
+			snapshot TEXT NOT NULL,
+			note TEXT,
+			actor TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(form_id, version)
+		)`,
+		`CREATE TABLE IF NOT EXISTS audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			actor TEXT NOT NULL,
+			profile TEXT,
+			action TEXT NOT NULL,
+			entity_type TEXT NOT NULL,
+			entity_id TEXT NOT NULL,
+			before_json TEXT,
+			after_json TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS buffered_submissions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			form_id TEXT NOT NULL,
+			message TEXT NOT NULL,
+			payload TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			delivered_at DATETIME,
+			submission_id INTEGER,
+			claimed_at DATETIME,
+			FOREIGN KEY (form_id) REFERENCES forms(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS submissions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			form_id TEXT NOT NULL,
+			fields TEXT,
+			payload TEXT,
+			country TEXT,
+			city TEXT,
+			user_agent TEXT,
+			ip TEXT,
+			referer TEXT,
+			delivery_status TEXT,
+			log_id INTEGER,
+			lead_status TEXT DEFAULT 'new',
+			owner_contact_id INTEGER,
+			follow_up_at DATETIME,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (owner_contact_id) REFERENCES contacts(id) ON DELETE SET NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS lead_status_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			submission_id INTEGER NOT NULL,
+			from_status TEXT,
+			to_status TEXT NOT NULL,
+			actor TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (submission_id) REFERENCES submissions(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS lead_notes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			submission_id INTEGER NOT NULL,
+			body TEXT NOT NULL,
+			actor TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (submission_id) REFERENCES submissions(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS monitoring_state (
+			key TEXT PRIMARY KEY,
+			connected INTEGER NOT NULL,
+			session INTEGER,
+			status_json TEXT,
+			last_changed DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS monitoring_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			key TEXT NOT NULL,
+			connected INTEGER NOT NULL,
+			session INTEGER,
+			status_json TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
 	}
 
 	for _, stmt := range statements {
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database/d1test"
+)
+
+// newTestClient returns a client on an empty database with the full schema
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	c, err := NewClient(d1test.New(t))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if err := c.InitSchema(); err != nil {
+		t.Fatalf("InitSchema: %v", err)
+	}
+	return c
+}
+
+// count returns the single integer a query selects
+func count(t *testing.T, c *Client, sql string, params ...interface{}) int {
+	t.Helper()
+	result, err := c.Query(sql, params...)
+	if err != nil {
+		t.Fatalf("%s: %v", sql, err)
+	}
+	for _, v := range result.Results[0] {
+		return int(v.(float64))
+	}
+	return 0
+}
+
+func TestQueryReportsChanges(t *testing.T) {
+	c := newTestClient(t)
+	for _, id := range []string{"a", "b"} {
+		if err := c.CreateForm(&Form{ID: id, Name: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := c.Query("UPDATE forms SET name = 'x'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Meta.Changes != 2 {
+		t.Errorf("Changes = %d, want 2", result.Meta.Changes)
+	}
+}
@@ -7,12 +7,14 @@ import (
 	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/csvsafe"
 )
 
 // GetAllContacts retrieves all contacts
 func (c *Client) GetAllContacts() ([]Contact, error) {
 	query := `
 		SELECT * FROM contacts
+		WHERE archived_at IS NULL
 		ORDER BY name ASC
 	`
 
@@ -95,6 +97,7 @@ func (c *Client) GetContactByID(id int) (*Contact, error) {
 	if updatedAt, ok := row["updated_at"].(string); ok {
 		contact.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
 	}
+	contact.ArchivedAt = parseTimePtr(row["archived_at"])
 
 	return contact, nil
 }
@@ -108,6 +111,7 @@ func (c *Client) GetContactsWithStats() ([]ContactWithStats, error) {
 			GROUP_CONCAT(DISTINCT fn.form_id) as form_ids
 		FROM contacts c
 		LEFT JOIN form_numbers fn ON c.id = fn.contact_id
+		WHERE c.archived_at IS NULL
 		GROUP BY c.id
 		ORDER BY c.name ASC
 	`
@@ -197,12 +201,22 @@ func (c *Client) GetContact(id int) (*Contact, error) {
 	if updatedAt, ok := row["updated_at"].(string); ok {
 		contact.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
 	}
+	contact.ArchivedAt = parseTimePtr(row["archived_at"])
 
 	return contact, nil
 }
 
 // CreateContact creates a new contact
 func (c *Client) CreateContact(contact *Contact) (int, error) {
+	id, err := c.createContact(contact)
+	if err != nil {
+		return 0, err
+	}
+	c.auditContact("create", id, nil)
+	return id, nil
+}
+
+func (c *Client) createContact(contact *Contact) (int, error) {
 	query := `
 		INSERT INTO contacts (phone_number, name, company, role, notes, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
@@ -218,6 +232,8 @@ func (c *Client) CreateContact(contact *Contact) (int, error) {
 
 // UpdateContact updates an existing contact
 func (c *Client) UpdateContact(contact *Contact) error {
+	before := c.contactAuditState(contact.ID)
+
 	query := `
 		UPDATE contacts 
 		SET phone_number = ?, name = ?, company = ?, role = ?, notes = ?, 
@@ -230,16 +246,31 @@ func (c *Client) UpdateContact(contact *Contact) error {
 		return fmt.Errorf("failed to update contact: %w", err)
 	}
 
+	c.auditContact("update", contact.ID, before)
 	return nil
 }
 
 // DeleteContact deletes a contact
 func (c *Client) DeleteContact(id int) error {
-	// First, remove references from form_numbers
-	_, err := c.Query("UPDATE form_numbers SET contact_id = NULL WHERE contact_id = ?", id)
+	before := c.contactAuditState(id)
+	if err := c.deleteContact(id); err != nil {
+		return err
+	}
+	c.auditContact("delete", id, before)
+	return nil
+}
+
+func (c *Client) deleteContact(id int) error {
+	// First, remove the contact from every form it receives. Leaving the
+	// numbers unlinked would keep messaging them, and doctor would recreate
+	// the contact from them.
+	_, err := c.Query("DELETE FROM form_numbers WHERE contact_id = ?", id)
 	if err != nil {
 		log.Warn("Failed to remove contact references", "error", err)
 	}
+	if _, err := c.Query("UPDATE submissions SET owner_contact_id = NULL WHERE owner_contact_id = ?", id); err != nil {
+		log.Warn("Failed to unassign contact's leads", "error", err)
+	}
 
 	// Delete the contact
 	query := "DELETE FROM contacts WHERE id = ?"
@@ -260,7 +291,7 @@ func (c *Client) SearchContacts(searchTerm string) ([]ContactWithStats, error) {
 			GROUP_CONCAT(DISTINCT fn.form_id) as form_ids
 		FROM contacts c
 		LEFT JOIN form_numbers fn ON c.id = fn.contact_id
-		WHERE c.name LIKE ? OR c.company LIKE ? OR c.phone_number LIKE ?
+		WHERE (c.name LIKE ? OR c.company LIKE ? OR c.phone_number LIKE ?) AND c.archived_at IS NULL
 		GROUP BY c.id
 		ORDER BY c.name ASC
 	`
@@ -369,38 +400,7 @@ func (c *Client) ExportContactsCSV() ([]byte, error) {
 		return nil, err
 	}
 
-	var buf strings.Builder
-	writer := csv.NewWriter(&buf)
-
-	// Write header
-	header := []string{"ID", "Name", "Phone Number", "Company", "Role", "Notes", "Form Count", "Created At"}
-	if err := writer.Write(header); err != nil {
-		return nil, fmt.Errorf("failed to write CSV header: %w", err)
-	}
-
-	// Write data
-	for _, contact := range contacts {
-		record := []string{
-			fmt.Sprintf("%d", contact.ID),
-			contact.Name,
-			contact.PhoneNumber,
-			contact.Company,
-			contact.Role,
-			contact.Notes,
-			fmt.Sprintf("%d", contact.FormCount),
-			contact.CreatedAt.Format("2006-01-02 15:04:05"),
-		}
-		if err := writer.Write(record); err != nil {
-			return nil, fmt.Errorf("failed to write CSV record: %w", err)
-		}
-	}
-
-	writer.Flush()
-	if err := writer.Error(); err != nil {
-		return nil, fmt.Errorf("CSV writer error: %w", err)
-	}
-
-	return []byte(buf.String()), nil
+	return ContactsCSV(contacts)
 }
 
 // ImportContactsCSV imports contacts from CSV data
@@ -429,23 +429,24 @@ func (c *Client) ImportContactsCSV(data []byte) (int, error) {
 
 		contact := Contact{}
 
-		// Map CSV fields to contact fields
+		// Map CSV fields to contact fields, undoing the formula escaping
+		// of ExportContactsCSV
 		if idx, ok := headerMap["name"]; ok && idx < len(record) {
-			contact.Name = record[idx]
+			contact.Name = csvsafe.Unescape(record[idx])
 		}
 		if idx, ok := headerMap["phone number"]; ok && idx < len(record) {
-			contact.PhoneNumber = record[idx]
+			contact.PhoneNumber = csvsafe.Unescape(record[idx])
 		} else if idx, ok := headerMap["phone"]; ok && idx < len(record) {
-			contact.PhoneNumber = record[idx]
+			contact.PhoneNumber = csvsafe.Unescape(record[idx])
 		}
 		if idx, ok := headerMap["company"]; ok && idx < len(record) {
-			contact.Company = record[idx]
+			contact.Company = csvsafe.Unescape(record[idx])
 		}
 		if idx, ok := headerMap["role"]; ok && idx < len(record) {
-			contact.Role = record[idx]
+			contact.Role = csvsafe.Unescape(record[idx])
 		}
 		if idx, ok := headerMap["notes"]; ok && idx < len(record) {
-			contact.Notes = record[idx]
+			contact.Notes = csvsafe.Unescape(record[idx])
 		}
 
 		// Skip if required fields are missing
@@ -454,10 +455,12 @@ func (c *Client) ImportContactsCSV(data []byte) (int, error) {
 		}
 
 		// Try to create the contact
-		if _, err := c.CreateContact(&contact); err != nil {
+		id, err := c.createContact(&contact)
+		if err != nil {
 			log.Warn("Failed to import contact", "name", contact.Name, "error", err)
 			continue
 		}
+		c.auditContact("import", id, nil)
 
 		imported++
 	}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestContactsCSVRoundTrip(t *testing.T) {
+	c := newTestClient(t)
+	if _, err := c.CreateContact(&Contact{PhoneNumber: "+55 11 99999-0001", Name: "=HYPERLINK(\"http://x\")", Notes: "@SUM(1)"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := c.ExportContactsCSV()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cell := range records[1] {
+		if cell != "" && strings.ContainsAny(cell[:1], "=+-@") {
+			t.Errorf("export has an unescaped cell %q", cell)
+		}
+	}
+
+	if _, err := c.Query("DELETE FROM contacts"); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := c.ImportContactsCSV(data); err != nil || n != 1 {
+		t.Fatalf("ImportContactsCSV = %d, %v", n, err)
+	}
+	contacts, err := c.GetAllContacts()
+	if err != nil || len(contacts) != 1 {
+		t.Fatalf("GetAllContacts = %v, %v", contacts, err)
+	}
+	got := contacts[0]
+	if got.PhoneNumber != "+55 11 99999-0001" || got.Name != "=HYPERLINK(\"http://x\")" || got.Notes != "@SUM(1)" {
+		t.Errorf("imported %+v, want the exported values back", got)
+	}
+}
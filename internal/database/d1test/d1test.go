@@ -0,0 +1,160 @@
+// Package d1test serves the D1 query API from a local SQLite database so
+// database code can be tested without Cloudflare. Queries run through the
+// sqlite3 command; tests are skipped where it is not installed.
+package d1test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+)
+
+// New starts a fake D1 with an empty database for the test and returns a
+// config pointing at it
+func New(t testing.TB) *config.Config {
+	t.Helper()
+	sqlite, err := exec.LookPath("sqlite3")
+	if err != nil {
+		t.Skip("sqlite3 is not installed")
+	}
+
+	db := &database{sqlite: sqlite, path: filepath.Join(t.TempDir(), "d1.sqlite")}
+	server := httptest.NewServer(db)
+	t.Cleanup(server.Close)
+
+	cfg := config.DefaultConfig()
+	cfg.Cloudflare.AccountID = "0123456789abcdef0123456789abcdef"
+	cfg.Cloudflare.DatabaseID = "01234567-89ab-cdef-0123-456789abcdef"
+	cfg.Cloudflare.APIToken = "test"
+	cfg.Cloudflare.APIURL = server.URL
+	cfg.Actor = "test"
+	return cfg
+}
+
+type database struct {
+	mu     sync.Mutex
+	sqlite string
+	path   string
+}
+
+type queryRequest struct {
+	SQL    string        `json:"sql"`
+	Params []interface{} `json:"params"`
+}
+
+func (d *database) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req queryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	results, meta, err := d.query(req.SQL, req.Params)
+	resp := map[string]interface{}{"success": err == nil, "errors": []interface{}{}}
+	if err != nil {
+		resp["errors"] = []map[string]interface{}{{"code": 7500, "message": err.Error()}}
+	} else {
+		resp["result"] = []map[string]interface{}{{"results": results, "success": true, "meta": meta}}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// query runs one statement with D1's foreign key enforcement and reads
+// the changes and last row ID the way D1 reports them in meta
+func (d *database) query(sql string, params []interface{}) ([]map[string]interface{}, map[string]interface{}, error) {
+	stmt, err := bind(strings.TrimRight(strings.TrimSpace(sql), ";"), params)
+	if err != nil {
+		return nil, nil, err
+	}
+	script := "PRAGMA foreign_keys = ON;\n" + stmt + ";\nSELECT changes() AS changes, last_insert_rowid() AS last_row_id;\n"
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	cmd := exec.Command(d.sqlite, "-bail", "-json", d.path)
+	cmd.Stdin = strings.NewReader(script)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, nil, fmt.Errorf("%s", strings.TrimSpace(stderr.String()))
+	}
+
+	// Every statement that returns rows prints one array; the last is meta
+	var sets [][]map[string]interface{}
+	dec := json.NewDecoder(&stdout)
+	for {
+		var rows []map[string]interface{}
+		if err := dec.Decode(&rows); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("failed to read sqlite3 output: %w", err)
+		}
+		sets = append(sets, rows)
+	}
+	if len(sets) == 0 || len(sets[len(sets)-1]) == 0 {
+		return nil, nil, fmt.Errorf("sqlite3 printed no meta")
+	}
+	meta := sets[len(sets)-1][0]
+	results := []map[string]interface{}{}
+	if len(sets) > 1 {
+		results = sets[0]
+	}
+	return results, meta, nil
+}
+
+// bind replaces each ? outside a string literal with its parameter
+func bind(sql string, params []interface{}) (string, error) {
+	var b strings.Builder
+	next := 0
+	quote := rune(0)
+	for _, r := range sql {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '?':
+			if next == len(params) {
+				return "", fmt.Errorf("not enough parameters for %q", sql)
+			}
+			b.WriteString(literal(params[next]))
+			next++
+			continue
+		}
+		b.WriteRune(r)
+	}
+	if next != len(params) {
+		return "", fmt.Errorf("%d parameters for %d placeholders in %q", len(params), next, sql)
+	}
+	return b.String(), nil
+}
+
+func literal(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return "'" + strings.ReplaceAll(fmt.Sprint(v), "'", "''") + "'"
+}
@@ -0,0 +1,231 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Delivery statuses written to webhook_logs
+const (
+	DeliverySuccess = "success"
+	DeliveryPartial = "partial"
+	DeliveryFailed  = "failed"
+	// DeliveryResent marks a failed delivery every recipient later received
+	DeliveryResent = "resent"
+)
+
+// Recipient statuses in delivery_recipients
+const (
+	RecipientSent    = "sent"
+	RecipientFailed  = "failed"
+	RecipientSending = "sending"
+)
+
+// recordAttempts is how many times RecordDeliveryAttempt writes an outcome
+// before giving up
+const recordAttempts = 3
+
+// DeliveryFilter narrows GetFailedDeliveries; zero values match everything
+type DeliveryFilter struct {
+	FormID string
+	Since  time.Time
+	IDs    []int
+	Limit  int
+}
+
+// GetFailedDeliveries retrieves failed and partial deliveries with their
+// per-recipient outcomes, newest first
+func (c *Client) GetFailedDeliveries(filter DeliveryFilter) ([]Delivery, error) {
+	conditions := []string{"status IN (?, ?)"}
+	params := []interface{}{DeliveryPartial, DeliveryFailed}
+
+	if filter.FormID != "" {
+		conditions = append(conditions, "form_id = ?")
+		params = append(params, filter.FormID)
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		params = append(params, sqlTime(filter.Since))
+	}
+	if len(filter.IDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf("id IN (%s)", placeholders(len(filter.IDs))))
+		for _, id := range filter.IDs {
+			params = append(params, id)
+		}
+	}
+
+	query := "SELECT * FROM webhook_logs WHERE " + strings.Join(conditions, " AND ") + " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failed deliveries: %w", err)
+	}
+
+	var deliveries []Delivery
+	for _, row := range result.Results {
+		deliveries = append(deliveries, deliveryFromRow(row))
+	}
+
+	if err := c.loadDeliveryRecipients(deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDeliveryRecipient marks a recipient as being resent. It returns
+// false when the recipient already got the message or another resend
+// holds the claim, so nobody receives the same lead twice. Claims older
+// than ten minutes are considered abandoned.
+func (c *Client) ClaimDeliveryRecipient(logID int, phone string) (bool, error) {
+	query := `
+		INSERT INTO delivery_recipients (log_id, phone, status, attempts, updated_at)
+		VALUES (?, ?, ?, 0, CURRENT_TIMESTAMP)
+		ON CONFLICT(log_id, phone) DO UPDATE SET status = excluded.status, updated_at = CURRENT_TIMESTAMP
+		WHERE delivery_recipients.status = ?
+			OR (delivery_recipients.status = ? AND delivery_recipients.updated_at < datetime('now', '-10 minutes'))
+	`
+	result, err := c.Query(query, logID, phone, RecipientSending, RecipientFailed, RecipientSending)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim recipient %s: %w", phone, err)
+	}
+	return result.Meta.Changes > 0, nil
+}
+
+// RecordDeliveryAttempt stores the outcome of a resend to one recipient
+// and marks the delivery resent once every recipient has the message
+func (c *Client) RecordDeliveryAttempt(logID int, phone string, sendErr error) error {
+	status := RecipientSent
+	var lastError interface{}
+	if sendErr != nil {
+		status = RecipientFailed
+		lastError = sendErr.Error()
+	}
+
+	// A recipient left 'sending' is claimed again once the claim goes
+	// stale, so a message that went out would be sent twice: try harder
+	// than usual to record the outcome
+	query := `
+		UPDATE delivery_recipients
+		SET status = ?, attempts = attempts + 1, last_error = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE log_id = ? AND phone = ?
+	`
+	var err error
+	for attempt := 0; attempt < recordAttempts; attempt++ {
+		if _, err = c.Query(query, status, lastError, logID, phone); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record delivery attempt: %w", err)
+	}
+
+	query = `
+		UPDATE webhook_logs SET status = ?
+		WHERE id = ? AND NOT EXISTS (
+			SELECT 1 FROM delivery_recipients WHERE log_id = ? AND status != ?
+		)
+	`
+	if _, err := c.Query(query, DeliveryResent, logID, logID, RecipientSent); err != nil {
+		return fmt.Errorf("failed to update delivery status: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) loadDeliveryRecipients(deliveries []Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	index := make(map[int]int)
+	var params []interface{}
+	for i, d := range deliveries {
+		index[d.ID] = i
+		params = append(params, d.ID)
+	}
+	query := fmt.Sprintf("SELECT * FROM delivery_recipients WHERE log_id IN (%s) ORDER BY id", placeholders(len(params)))
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to get delivery recipients: %w", err)
+	}
+
+	for _, row := range result.Results {
+		logID, _ := row["log_id"].(float64)
+		i, ok := index[int(logID)]
+		if !ok {
+			continue
+		}
+
+		recipient := DeliveryRecipient{}
+		recipient.Phone, _ = row["phone"].(string)
+		recipient.Status, _ = row["status"].(string)
+		if attempts, ok := row["attempts"].(float64); ok {
+			recipient.Attempts = int(attempts)
+		}
+		recipient.LastError, _ = row["last_error"].(string)
+		recipient.UpdatedAt = parseTime(row["updated_at"])
+
+		deliveries[i].Recipients = append(deliveries[i].Recipients, recipient)
+	}
+	return nil
+}
+
+// placeholders returns n comma-separated query parameters for an IN list
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func deliveryFromRow(row map[string]interface{}) Delivery {
+	d := Delivery{}
+
+	if id, ok := row["id"].(float64); ok {
+		d.ID = int(id)
+	}
+	d.FormID, _ = row["form_id"].(string)
+	d.Status, _ = row["status"].(string)
+	if duration, ok := row["duration_ms"].(float64); ok {
+		d.Duration = int(duration)
+	}
+	d.CreatedAt = parseTime(row["created_at"])
+
+	// The worker stores the formatted message and the submitted fields
+	var request struct {
+		Message string                 `json:"message"`
+		Fields  map[string]interface{} `json:"fields"`
+	}
+	if raw, ok := row["request"].(string); ok && json.Unmarshal([]byte(raw), &request) == nil {
+		d.Message = request.Message
+		d.Fields = make(map[string]string, len(request.Fields))
+		for key, value := range request.Fields {
+			d.Fields[key] = fmt.Sprint(value)
+		}
+	}
+
+	return d
+}
+
+// Failed returns the recipients that have not received the message
+func (d Delivery) Failed() []DeliveryRecipient {
+	var failed []DeliveryRecipient
+	for _, r := range d.Recipients {
+		if r.Status != RecipientSent {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// SentTo reports whether the phone already received this delivery
+func (d Delivery) SentTo(phone string) bool {
+	normalized := NormalizePhone(phone)
+	for _, r := range d.Recipients {
+		if r.Status == RecipientSent && NormalizePhone(r.Phone) == normalized {
+			return true
+		}
+	}
+	return false
+}
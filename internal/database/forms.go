@@ -17,11 +17,14 @@ func (c *Client) GetAllForms() ([]FormWithStats, error) {
 			f.description,
 			f.created_at,
 			f.updated_at,
+			f.enabled,
+			f.paused_until,
 			COUNT(DISTINCT ff.id) as field_count,
 			COUNT(DISTINCT fn.id) as number_count
 		FROM forms f
 		LEFT JOIN form_fields ff ON f.id = ff.form_id
 		LEFT JOIN form_numbers fn ON f.id = fn.form_id
+		WHERE f.archived_at IS NULL
 		GROUP BY f.id
 		ORDER BY f.created_at DESC
 	`
@@ -50,6 +53,7 @@ func (c *Client) GetAllForms() ([]FormWithStats, error) {
 		if updatedAt, ok := row["updated_at"].(string); ok {
 			form.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
 		}
+		applyPauseState(&form.Form, row)
 		if count, ok := row["field_count"].(float64); ok {
 			form.FieldCount = int(count)
 		}
@@ -94,6 +98,8 @@ func (c *Client) GetForm(id string) (*Form, error) {
 	if updatedAt, ok := row["updated_at"].(string); ok {
 		form.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
 	}
+	form.ArchivedAt = parseTimePtr(row["archived_at"])
+	applyPauseState(form, row)
 
 	// Get fields
 	fields, err := c.getFormFields(id)
@@ -119,6 +125,15 @@ func (c *Client) GetFormByID(id string) (*Form, error) {
 
 // CreateForm creates a new form with its fields and numbers
 func (c *Client) CreateForm(form *Form) error {
+	if err := c.createForm(form); err != nil {
+		return err
+	}
+	c.saveFormVersion(form.ID, "created")
+	c.auditForm("create", form.ID, nil)
+	return nil
+}
+
+func (c *Client) createForm(form *Form) error {
 	// Insert form
 	query := `
 		INSERT INTO forms (id, name, description, created_at, updated_at)
@@ -152,6 +167,18 @@ func (c *Client) CreateForm(form *Form) error {
 
 // UpdateForm updates an existing form
 func (c *Client) UpdateForm(form *Form) error {
+	before := c.formAuditState(form.ID)
+	// Forms created before versioning have nothing to roll back to yet
+	c.saveBaselineVersion(form.ID)
+	if err := c.updateForm(form); err != nil {
+		return err
+	}
+	c.saveFormVersion(form.ID, "")
+	c.auditForm("update", form.ID, before)
+	return nil
+}
+
+func (c *Client) updateForm(form *Form) error {
 	// Update form
 	query := `
 		UPDATE forms 
@@ -190,6 +217,22 @@ func (c *Client) UpdateForm(form *Form) error {
 
 // DeleteForm deletes a form and all its related data
 func (c *Client) DeleteForm(id string) error {
+	before := c.formAuditState(id)
+	if err := c.deleteForm(id); err != nil {
+		return err
+	}
+	c.auditForm("delete", id, before)
+	return nil
+}
+
+func (c *Client) deleteForm(id string) error {
+	// Remove child rows explicitly; D1 does not always enforce the cascade
+	for _, table := range []string{"form_fields", "form_numbers", "buffered_submissions", "outbound_jobs"} {
+		if _, err := c.Query(fmt.Sprintf("DELETE FROM %s WHERE form_id = ?", table), id); err != nil {
+			return fmt.Errorf("failed to delete form %s: %w", table, err)
+		}
+	}
+
 	query := "DELETE FROM forms WHERE id = ?"
 	_, err := c.Query(query, id)
 	if err != nil {
@@ -214,33 +257,56 @@ func (c *Client) getFormFields(formID string) ([]Field, error) {
 
 	var fields []Field
 	for _, row := range result.Results {
-		field := Field{FormID: formID}
-		
-		if id, ok := row["id"].(float64); ok {
-			field.ID = fmt.Sprintf("%d", int(id))
-		}
-		if elementorID, ok := row["elementor_id"].(string); ok {
-			field.ElementorID = elementorID
-		}
-		if label, ok := row["label"].(string); ok {
-			field.Label = label
-		}
-		if fieldType, ok := row["type"].(string); ok {
-			field.Type = fieldType
-		}
-		if required, ok := row["required"].(float64); ok {
-			field.Required = required > 0
-		}
-		if position, ok := row["position"].(float64); ok {
-			field.Position = int(position)
-		}
-
+		field := fieldFromRow(row)
+		field.FormID = formID
 		fields = append(fields, field)
 	}
 
 	return fields, nil
 }
 
+// GetFieldsByForm retrieves the fields of every form, keyed by form ID
+// and ordered by position
+func (c *Client) GetFieldsByForm() (map[string][]Field, error) {
+	result, err := c.Query("SELECT * FROM form_fields ORDER BY form_id, position")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get form fields: %w", err)
+	}
+
+	fields := make(map[string][]Field)
+	for _, row := range result.Results {
+		field := fieldFromRow(row)
+		fields[field.FormID] = append(fields[field.FormID], field)
+	}
+	return fields, nil
+}
+
+func fieldFromRow(row map[string]interface{}) Field {
+	field := Field{}
+
+	if id, ok := row["id"].(float64); ok {
+		field.ID = fmt.Sprintf("%d", int(id))
+	}
+	field.FormID, _ = row["form_id"].(string)
+	if elementorID, ok := row["elementor_id"].(string); ok {
+		field.ElementorID = elementorID
+	}
+	if label, ok := row["label"].(string); ok {
+		field.Label = label
+	}
+	if fieldType, ok := row["type"].(string); ok {
+		field.Type = fieldType
+	}
+	if required, ok := row["required"].(float64); ok {
+		field.Required = required > 0
+	}
+	if position, ok := row["position"].(float64); ok {
+		field.Position = int(position)
+	}
+
+	return field
+}
+
 func (c *Client) getFormNumbers(formID string) ([]Number, error) {
 	query := `
 		SELECT * FROM form_numbers 
@@ -310,12 +376,14 @@ func (c *Client) SearchForms(searchTerm string) ([]FormWithStats, error) {
 			f.description,
 			f.created_at,
 			f.updated_at,
+			f.enabled,
+			f.paused_until,
 			COUNT(DISTINCT ff.id) as field_count,
 			COUNT(DISTINCT fn.id) as number_count
 		FROM forms f
 		LEFT JOIN form_fields ff ON f.id = ff.form_id
 		LEFT JOIN form_numbers fn ON f.id = fn.form_id
-		WHERE f.name LIKE ? OR f.description LIKE ?
+		WHERE (f.name LIKE ? OR f.description LIKE ?) AND f.archived_at IS NULL
 		GROUP BY f.id
 		ORDER BY f.created_at DESC
 	`
@@ -345,6 +413,7 @@ func (c *Client) SearchForms(searchTerm string) ([]FormWithStats, error) {
 		if updatedAt, ok := row["updated_at"].(string); ok {
 			form.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
 		}
+		applyPauseState(&form.Form, row)
 		if count, ok := row["field_count"].(float64); ok {
 			form.FieldCount = int(count)
 		}
@@ -381,5 +450,10 @@ func (c *Client) ImportForm(data []byte) error {
 		return fmt.Errorf("form with ID %s already exists", form.ID)
 	}
 
-	return c.CreateForm(&form)
+	if err := c.createForm(&form); err != nil {
+		return err
+	}
+	c.saveFormVersion(form.ID, "imported")
+	c.auditForm("import", form.ID, nil)
+	return nil
 }
\ No newline at end of file
@@ -0,0 +1,383 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/log"
+)
+
+// ReservedFormIDs are form IDs the worker answers itself instead of
+// looking them up in the database
+var ReservedFormIDs = []string{"elementor", "default"}
+
+// NormalizePhone reduces a phone number to its digits so that
+// "+55 (11) 99999-9999" and "5511999999999" compare equal
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// IsWorkerReachableID reports whether the worker can route /webhook/{id} to this form
+func IsWorkerReachableID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, reserved := range ReservedFormIDs {
+		if id == reserved {
+			return false
+		}
+	}
+	for _, r := range id {
+		// The worker matches against the raw URL path, so anything that
+		// gets percent-encoded will never match the stored ID
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '-' && r != '_' && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// DefaultFields returns the field mapping new forms start with
+func DefaultFields() []Field {
+	return []Field{
+		{ElementorID: "name", Label: "Name", Type: "text", Required: true},
+		{ElementorID: "email", Label: "Email", Type: "email", Required: true},
+		{ElementorID: "phone", Label: "Phone", Type: "tel", Required: false},
+		{ElementorID: "message", Label: "Message", Type: "textarea", Required: false},
+	}
+}
+
+// GetOrphanNumbers returns form recipients that are not linked to an existing contact
+func (c *Client) GetOrphanNumbers() ([]Number, error) {
+	query := `
+		SELECT fn.*
+		FROM form_numbers fn
+		LEFT JOIN contacts c ON c.id = fn.contact_id
+		WHERE fn.contact_id IS NULL OR c.id IS NULL
+		ORDER BY fn.form_id, fn.id
+	`
+
+	result, err := c.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orphan numbers: %w", err)
+	}
+
+	var numbers []Number
+	for _, row := range result.Results {
+		numbers = append(numbers, numberFromRow(row))
+	}
+
+	return numbers, nil
+}
+
+// CountDanglingFormRows counts fields and numbers that point at a deleted form
+func (c *Client) CountDanglingFormRows() (int, error) {
+	total := 0
+	for _, table := range []string{"form_fields", "form_numbers"} {
+		query := fmt.Sprintf(
+			"SELECT COUNT(*) as count FROM %s WHERE form_id NOT IN (SELECT id FROM forms)",
+			table,
+		)
+		result, err := c.Query(query)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count dangling %s: %w", table, err)
+		}
+		if len(result.Results) > 0 {
+			if count, ok := result.Results[0]["count"].(float64); ok {
+				total += int(count)
+			}
+		}
+	}
+	return total, nil
+}
+
+// RepairOrphanLinks removes rows of deleted forms and links every
+// orphan recipient to the contact with the same phone, creating the
+// contact when none exists
+func (c *Client) RepairOrphanLinks() (int, error) {
+	fixed := 0
+	for _, table := range []string{"form_fields", "form_numbers"} {
+		query := fmt.Sprintf("DELETE FROM %s WHERE form_id NOT IN (SELECT id FROM forms)", table)
+		result, err := c.Query(query)
+		if err != nil {
+			return fixed, fmt.Errorf("failed to remove dangling %s: %w", table, err)
+		}
+		fixed += result.Meta.Changes
+	}
+
+	orphans, err := c.GetOrphanNumbers()
+	if err != nil {
+		return fixed, err
+	}
+	if len(orphans) == 0 {
+		return fixed, nil
+	}
+
+	contacts, err := c.getEveryContact()
+	if err != nil {
+		return fixed, err
+	}
+	byPhone := make(map[string]int)
+	for _, contact := range contacts {
+		byPhone[NormalizePhone(contact.PhoneNumber)] = contact.ID
+	}
+
+	for _, number := range orphans {
+		phone := NormalizePhone(number.PhoneNumber)
+		contactID, ok := byPhone[phone]
+		if !ok {
+			name := number.Label
+			if name == "" {
+				name = number.PhoneNumber
+			}
+			contactID, err = c.CreateContact(&Contact{PhoneNumber: number.PhoneNumber, Name: name})
+			if err != nil {
+				log.Warn("Failed to create contact for orphan number", "phone", number.PhoneNumber, "error", err)
+				continue
+			}
+			byPhone[phone] = contactID
+		}
+
+		if _, err := c.Query("UPDATE form_numbers SET contact_id = ? WHERE id = ?", contactID, number.ID); err != nil {
+			log.Warn("Failed to link orphan number", "id", number.ID, "error", err)
+			continue
+		}
+		c.audit("relink", AuditForm, number.FormID,
+			map[string]interface{}{"recipient": number.PhoneNumber, "contact_id": number.ContactID},
+			map[string]interface{}{"recipient": number.PhoneNumber, "contact_id": contactID})
+		fixed++
+	}
+
+	return fixed, nil
+}
+
+// GetDuplicateContacts groups contacts whose phone numbers normalize to the same digits
+func (c *Client) GetDuplicateContacts() ([][]Contact, error) {
+	contacts, err := c.getEveryContact()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]Contact)
+	var order []string
+	for _, contact := range contacts {
+		phone := NormalizePhone(contact.PhoneNumber)
+		if phone == "" {
+			// Without digits there is nothing to compare
+			continue
+		}
+		if _, seen := groups[phone]; !seen {
+			order = append(order, phone)
+		}
+		groups[phone] = append(groups[phone], contact)
+	}
+
+	var duplicates [][]Contact
+	for _, phone := range order {
+		if len(groups[phone]) > 1 {
+			group := groups[phone]
+			sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+			duplicates = append(duplicates, group)
+		}
+	}
+
+	return duplicates, nil
+}
+
+// GetDuplicateNumbers returns recipients that repeat a phone already on the same form
+func (c *Client) GetDuplicateNumbers() ([]Number, error) {
+	result, err := c.Query("SELECT * FROM form_numbers ORDER BY form_id, id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get form numbers: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var duplicates []Number
+	for _, row := range result.Results {
+		number := numberFromRow(row)
+		key := number.FormID + "|" + NormalizePhone(number.PhoneNumber)
+		if seen[key] {
+			duplicates = append(duplicates, number)
+			continue
+		}
+		seen[key] = true
+	}
+
+	return duplicates, nil
+}
+
+// MergeDuplicateContacts keeps the oldest contact of each duplicate
+// group, moves the others' form links onto it and deletes them. Repeated
+// recipients on the same form are removed afterwards.
+func (c *Client) MergeDuplicateContacts() (int, error) {
+	groups, err := c.GetDuplicateContacts()
+	if err != nil {
+		return 0, err
+	}
+
+	fixed := 0
+	for _, group := range groups {
+		keeper := group[0]
+		for _, dup := range group[1:] {
+			if keeper.Company == "" {
+				keeper.Company = dup.Company
+			}
+			if keeper.Role == "" {
+				keeper.Role = dup.Role
+			}
+			if dup.Notes != "" && !strings.Contains(keeper.Notes, dup.Notes) {
+				keeper.Notes = strings.TrimSpace(keeper.Notes + "\n" + dup.Notes)
+			}
+
+			before := c.contactAuditState(dup.ID)
+			if _, err := c.Query("UPDATE form_numbers SET contact_id = ? WHERE contact_id = ?", keeper.ID, dup.ID); err != nil {
+				return fixed, fmt.Errorf("failed to move links of contact %d: %w", dup.ID, err)
+			}
+			if _, err := c.Query("UPDATE submissions SET owner_contact_id = ? WHERE owner_contact_id = ?", keeper.ID, dup.ID); err != nil {
+				log.Warn("Failed to move leads of contact", "id", dup.ID, "error", err)
+			}
+			if _, err := c.Query("DELETE FROM contacts WHERE id = ?", dup.ID); err != nil {
+				return fixed, fmt.Errorf("failed to delete duplicate contact %d: %w", dup.ID, err)
+			}
+			c.audit("merge", AuditContact, strconv.Itoa(dup.ID), before, map[string]int{"merged_into": keeper.ID})
+			fixed++
+		}
+
+		if err := c.UpdateContact(&keeper); err != nil {
+			log.Warn("Failed to merge contact details", "id", keeper.ID, "error", err)
+		}
+	}
+
+	numbers, err := c.GetDuplicateNumbers()
+	if err != nil {
+		return fixed, err
+	}
+	for _, number := range numbers {
+		if _, err := c.Query("DELETE FROM form_numbers WHERE id = ?", number.ID); err != nil {
+			return fixed, fmt.Errorf("failed to delete duplicate number %d: %w", number.ID, err)
+		}
+		fixed++
+	}
+
+	return fixed, nil
+}
+
+// AddDefaultFields gives a form without fields the default field mapping
+func (c *Client) AddDefaultFields(formID string) error {
+	before := c.formAuditState(formID)
+
+	for i, field := range DefaultFields() {
+		field.FormID = formID
+		field.Position = i
+		if err := c.createFormField(&field); err != nil {
+			return fmt.Errorf("failed to add default fields to %s: %w", formID, err)
+		}
+	}
+	c.saveFormVersion(formID, "default fields")
+	c.auditForm("update", formID, before)
+	return nil
+}
+
+// RenameForm moves a form and everything that references it to a new ID
+func (c *Client) RenameForm(oldID, newID string) error {
+	before := c.formAuditState(oldID)
+	if existing, _ := c.GetForm(newID); existing != nil {
+		return fmt.Errorf("form with ID %s already exists", newID)
+	}
+
+	// Copy the row first so child rows never point at a missing form
+	query := `
+		INSERT INTO forms (id, name, description, created_at, updated_at, archived_at, enabled, paused_until)
+		SELECT ?, name, description, created_at, CURRENT_TIMESTAMP, archived_at, enabled, paused_until
+		FROM forms WHERE id = ?
+	`
+	if _, err := c.Query(query, newID, oldID); err != nil {
+		return fmt.Errorf("failed to copy form: %w", err)
+	}
+
+	for _, table := range []string{"form_fields", "form_numbers", "webhook_logs", "buffered_submissions", "form_versions", "outbound_jobs", "submissions"} {
+		query := fmt.Sprintf("UPDATE %s SET form_id = ? WHERE form_id = ?", table)
+		if _, err := c.Query(query, newID, oldID); err != nil {
+			return fmt.Errorf("failed to move %s: %w", table, err)
+		}
+	}
+
+	if _, err := c.Query("DELETE FROM forms WHERE id = ?", oldID); err != nil {
+		return fmt.Errorf("failed to remove old form: %w", err)
+	}
+
+	c.audit("rename", AuditForm, oldID, before, map[string]string{"renamed_to": newID})
+	c.auditForm("rename", newID, before)
+	return nil
+}
+
+// SuggestFormID derives a worker-reachable ID from an existing one
+func SuggestFormID(id string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(id) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.':
+			b.WriteRune(r)
+		case r == ' ' || r == '/':
+			b.WriteRune('-')
+		}
+	}
+
+	suggested := strings.Trim(b.String(), "-")
+	if suggested == "" {
+		suggested = "form"
+	}
+	for _, reserved := range ReservedFormIDs {
+		if suggested == reserved {
+			suggested += "-form"
+		}
+	}
+	return suggested
+}
+
+// getEveryContact includes archived contacts, which still hold their
+// phone number's unique slot
+func (c *Client) getEveryContact() ([]Contact, error) {
+	result, err := c.Query("SELECT * FROM contacts ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contacts: %w", err)
+	}
+
+	var contacts []Contact
+	for _, row := range result.Results {
+		contacts = append(contacts, contactFromRow(row))
+	}
+	return contacts, nil
+}
+
+func numberFromRow(row map[string]interface{}) Number {
+	number := Number{}
+
+	if id, ok := row["id"].(float64); ok {
+		number.ID = int(id)
+	}
+	if formID, ok := row["form_id"].(string); ok {
+		number.FormID = formID
+	}
+	if phone, ok := row["phone_number"].(string); ok {
+		number.PhoneNumber = phone
+	}
+	if label, ok := row["label"].(string); ok {
+		number.Label = label
+	}
+	if contactID, ok := row["contact_id"].(float64); ok {
+		id := int(contactID)
+		number.ContactID = &id
+	}
+
+	return number
+}
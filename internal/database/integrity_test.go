@@ -0,0 +1,115 @@
+package database
+
+import "testing"
+
+func createTestForm(t *testing.T, c *Client, id string, phones ...string) {
+	t.Helper()
+	form := &Form{ID: id, Name: id, Fields: DefaultFields()}
+	for _, phone := range phones {
+		form.Numbers = append(form.Numbers, Number{PhoneNumber: phone})
+	}
+	if err := c.CreateForm(form); err != nil {
+		t.Fatalf("CreateForm(%s): %v", id, err)
+	}
+}
+
+func createTestContact(t *testing.T, c *Client, phone string) int {
+	t.Helper()
+	id, err := c.CreateContact(&Contact{PhoneNumber: phone, Name: phone})
+	if err != nil {
+		t.Fatalf("CreateContact(%s): %v", phone, err)
+	}
+	return id
+}
+
+func TestDeleteContactRemovesItsNumbers(t *testing.T) {
+	c := newTestClient(t)
+	contactID := createTestContact(t, c, "5511999990001")
+	createTestForm(t, c, "contact", "5511999990002")
+	if err := c.createFormNumber(&Number{FormID: "contact", PhoneNumber: "5511999990001", ContactID: &contactID}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.DeleteContact(contactID); err != nil {
+		t.Fatalf("DeleteContact: %v", err)
+	}
+
+	if n := count(t, c, "SELECT COUNT(*) FROM form_numbers WHERE phone_number = ?", "5511999990001"); n != 0 {
+		t.Errorf("%d numbers of the deleted contact left", n)
+	}
+	if n := count(t, c, "SELECT COUNT(*) FROM form_numbers WHERE form_id = 'contact'"); n != 1 {
+		t.Errorf("form has %d numbers, want the other recipient kept", n)
+	}
+
+	// Nothing is left for the orphan repair to recreate the contact from
+	if _, err := c.RepairOrphanLinks(); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(t, c, "SELECT COUNT(*) FROM contacts WHERE phone_number = ?", "5511999990001"); n != 0 {
+		t.Error("orphan repair recreated the deleted contact")
+	}
+}
+
+func TestRepairOrphanLinks(t *testing.T) {
+	c := newTestClient(t)
+	existing := createTestContact(t, c, "+55 11 99999-0001")
+	createTestForm(t, c, "contact", "5511999990001", "5511999990002")
+
+	orphans, err := c.GetOrphanNumbers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orphans) != 2 {
+		t.Fatalf("GetOrphanNumbers = %d, want 2", len(orphans))
+	}
+
+	fixed, err := c.RepairOrphanLinks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixed != 2 {
+		t.Errorf("RepairOrphanLinks fixed %d, want 2", fixed)
+	}
+	if n := count(t, c, "SELECT COUNT(*) FROM form_numbers WHERE contact_id = ?", existing); n != 1 {
+		t.Errorf("%d numbers linked to the contact with the same phone, want 1", n)
+	}
+	if n := count(t, c, "SELECT COUNT(*) FROM contacts"); n != 2 {
+		t.Errorf("%d contacts, want one created for the unknown phone", n)
+	}
+	if orphans, _ := c.GetOrphanNumbers(); len(orphans) != 0 {
+		t.Errorf("%d orphans left after repair", len(orphans))
+	}
+}
+
+func TestRenameForm(t *testing.T) {
+	c := newTestClient(t)
+	createTestForm(t, c, "Contact Form", "5511999990001")
+	createTestForm(t, c, "contact-form")
+
+	if err := c.RenameForm("Contact Form", "contact-form"); err == nil {
+		t.Error("renaming onto an existing form should fail")
+	}
+
+	if err := c.RenameForm("Contact Form", "contact-form-2"); err != nil {
+		t.Fatalf("RenameForm: %v", err)
+	}
+	if form, _ := c.GetForm("Contact Form"); form != nil {
+		t.Error("old form still exists")
+	}
+	form, err := c.GetForm("contact-form-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(form.Fields) != len(DefaultFields()) || len(form.Numbers) != 1 {
+		t.Errorf("renamed form has %d fields and %d numbers", len(form.Fields), len(form.Numbers))
+	}
+}
+
+func TestSuggestFormID(t *testing.T) {
+	for _, id := range []string{"Contact Form", "orçamento", "default", "a/b"} {
+		suggested := SuggestFormID(id)
+		if !IsWorkerReachableID(suggested) {
+			t.Errorf("SuggestFormID(%q) = %q is not reachable", id, suggested)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// Lead statuses, in the order a lead moves through them
+const (
+	LeadNew       = "new"
+	LeadContacted = "contacted"
+	LeadQualified = "qualified"
+	LeadLost      = "lost"
+)
+
+// AuditLead is the audit log entity type for lead owner and follow-up changes
+const AuditLead = "lead"
+
+// LeadStatuses lists every lead status in board order
+var LeadStatuses = []string{LeadNew, LeadContacted, LeadQualified, LeadLost}
+
+// ValidLeadStatus reports whether status is a known lead status
+func ValidLeadStatus(status string) bool {
+	for _, s := range LeadStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLeadStatus moves a lead to a new status and records the change
+func (c *Client) SetLeadStatus(id int, status string) error {
+	if !ValidLeadStatus(status) {
+		return fmt.Errorf("unknown lead status %q", status)
+	}
+
+	lead, err := c.GetSubmission(id)
+	if err != nil {
+		return err
+	}
+	if lead.LeadStatus == status {
+		return nil
+	}
+
+	if _, err := c.Query("UPDATE submissions SET lead_status = ? WHERE id = ?", status, id); err != nil {
+		return fmt.Errorf("failed to update lead status: %w", err)
+	}
+
+	query := `
+		INSERT INTO lead_status_history (submission_id, from_status, to_status, actor)
+		VALUES (?, ?, ?, ?)
+	`
+	if _, err := c.Query(query, id, lead.LeadStatus, status, c.config.AuditActor()); err != nil {
+		log.Warn("Failed to record lead status change", "lead", id, "error", err)
+	}
+	return nil
+}
+
+// AssignLead sets the contact working a lead; nil clears the owner
+func (c *Client) AssignLead(id int, contactID *int) error {
+	lead, err := c.GetSubmission(id)
+	if err != nil {
+		return err
+	}
+
+	var owner interface{}
+	if contactID != nil {
+		if _, err := c.GetContact(*contactID); err != nil {
+			return fmt.Errorf("contact %d not found", *contactID)
+		}
+		owner = *contactID
+	}
+
+	if _, err := c.Query("UPDATE submissions SET owner_contact_id = ? WHERE id = ?", owner, id); err != nil {
+		return fmt.Errorf("failed to assign lead: %w", err)
+	}
+
+	c.audit("assign", AuditLead, strconv.Itoa(id),
+		map[string]string{"owner": leadOwner(lead.OwnerContactID)},
+		map[string]string{"owner": leadOwner(contactID)})
+	return nil
+}
+
+// SetLeadFollowUp sets when a lead should be followed up; nil clears it
+func (c *Client) SetLeadFollowUp(id int, at *time.Time) error {
+	lead, err := c.GetSubmission(id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.Query("UPDATE submissions SET follow_up_at = ? WHERE id = ?", sqlTimePtr(at), id); err != nil {
+		return fmt.Errorf("failed to set follow-up: %w", err)
+	}
+
+	c.audit("follow_up", AuditLead, strconv.Itoa(id),
+		map[string]string{"follow_up_at": auditTime(lead.FollowUpAt)},
+		map[string]string{"follow_up_at": auditTime(at)})
+	return nil
+}
+
+// AddLeadNote adds a note to a lead
+func (c *Client) AddLeadNote(id int, body string) error {
+	if body == "" {
+		return fmt.Errorf("note is empty")
+	}
+	if _, err := c.GetSubmission(id); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO lead_notes (submission_id, body, actor) VALUES (?, ?, ?)"
+	if _, err := c.Query(query, id, body, c.config.AuditActor()); err != nil {
+		return fmt.Errorf("failed to add note: %w", err)
+	}
+	return nil
+}
+
+// GetLeadNotes retrieves a lead's notes, newest first
+func (c *Client) GetLeadNotes(id int) ([]LeadNote, error) {
+	result, err := c.Query("SELECT * FROM lead_notes WHERE submission_id = ? ORDER BY id DESC", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lead notes: %w", err)
+	}
+
+	var notes []LeadNote
+	for _, row := range result.Results {
+		note := LeadNote{SubmissionID: id}
+		if noteID, ok := row["id"].(float64); ok {
+			note.ID = int(noteID)
+		}
+		note.Body, _ = row["body"].(string)
+		note.Actor, _ = row["actor"].(string)
+		note.CreatedAt = parseTime(row["created_at"])
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// GetLeadStatusHistory retrieves a lead's status changes, newest first
+func (c *Client) GetLeadStatusHistory(id int) ([]LeadStatusChange, error) {
+	result, err := c.Query("SELECT * FROM lead_status_history WHERE submission_id = ? ORDER BY id DESC", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lead history: %w", err)
+	}
+
+	var changes []LeadStatusChange
+	for _, row := range result.Results {
+		change := LeadStatusChange{SubmissionID: id}
+		if changeID, ok := row["id"].(float64); ok {
+			change.ID = int(changeID)
+		}
+		change.FromStatus, _ = row["from_status"].(string)
+		change.ToStatus, _ = row["to_status"].(string)
+		change.Actor, _ = row["actor"].(string)
+		change.CreatedAt = parseTime(row["created_at"])
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// GetLeadCounts counts leads by status, optionally for a single form
+func (c *Client) GetLeadCounts(formID string) (map[string]int, error) {
+	query := "SELECT COALESCE(lead_status, ?) as status, COUNT(*) as count FROM submissions"
+	params := []interface{}{LeadNew}
+	if formID != "" {
+		query += " WHERE form_id = ?"
+		params = append(params, formID)
+	}
+	query += " GROUP BY status"
+
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count leads: %w", err)
+	}
+
+	counts := make(map[string]int, len(LeadStatuses))
+	for _, status := range LeadStatuses {
+		counts[status] = 0
+	}
+	for _, row := range result.Results {
+		status, _ := row["status"].(string)
+		if count, ok := row["count"].(float64); ok {
+			counts[status] += int(count)
+		}
+	}
+	return counts, nil
+}
+
+func leadOwner(contactID *int) string {
+	if contactID == nil {
+		return ""
+	}
+	return strconv.Itoa(*contactID)
+}
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetLeadStatusRecordsHistory(t *testing.T) {
+	c := newTestClient(t)
+	id := insertTestSubmission(t, c, "contact", `{}`, time.Now())
+
+	for _, status := range []string{LeadContacted, LeadContacted, LeadQualified} {
+		if err := c.SetLeadStatus(id, status); err != nil {
+			t.Fatalf("SetLeadStatus(%s): %v", status, err)
+		}
+	}
+	if err := c.SetLeadStatus(id, "won"); err == nil {
+		t.Error("SetLeadStatus accepted an unknown status")
+	}
+
+	history, err := c.GetLeadStatusHistory(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("%d status changes, want 2 (unchanged status not recorded)", len(history))
+	}
+	if h := history[0]; h.FromStatus != LeadContacted || h.ToStatus != LeadQualified || h.Actor != "test" {
+		t.Errorf("latest change = %s -> %s by %s", h.FromStatus, h.ToStatus, h.Actor)
+	}
+	if h := history[1]; h.FromStatus != LeadNew || h.ToStatus != LeadContacted {
+		t.Errorf("first change = %s -> %s", h.FromStatus, h.ToStatus)
+	}
+
+	leads, err := c.GetSubmissions(SubmissionFilter{LeadStatus: LeadQualified})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leads) != 1 || leads[0].ID != id {
+		t.Errorf("GetSubmissions(qualified) = %d leads, want the moved one", len(leads))
+	}
+}
+
+func TestAssignLeadAndFollowUp(t *testing.T) {
+	c := newTestClient(t)
+	id := insertTestSubmission(t, c, "contact", `{}`, time.Now())
+	owner := createTestContact(t, c, "5511999990001")
+
+	missing := owner + 100
+	if err := c.AssignLead(id, &missing); err == nil {
+		t.Error("AssignLead to a missing contact succeeded")
+	}
+	if err := c.AssignLead(id, &owner); err != nil {
+		t.Fatal(err)
+	}
+	due := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	if err := c.SetLeadFollowUp(id, &due); err != nil {
+		t.Fatal(err)
+	}
+
+	leads, err := c.GetSubmissions(SubmissionFilter{OwnerContactID: owner, FollowUpBefore: due})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leads) != 1 || leads[0].FollowUpAt == nil || !leads[0].FollowUpAt.Equal(due) {
+		t.Fatalf("GetSubmissions(owner, follow-up due) = %+v", leads)
+	}
+	if leads, _ := c.GetSubmissions(SubmissionFilter{FollowUpBefore: due.Add(-time.Minute)}); len(leads) != 0 {
+		t.Error("lead due later matched an earlier follow-up bound")
+	}
+
+	if err := c.AssignLead(id, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetLeadFollowUp(id, nil); err != nil {
+		t.Fatal(err)
+	}
+	lead, err := c.GetSubmission(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lead.OwnerContactID != nil || lead.FollowUpAt != nil {
+		t.Errorf("owner %v and follow-up %v not cleared", lead.OwnerContactID, lead.FollowUpAt)
+	}
+
+	entries, err := c.GetAuditEntries(AuditFilter{EntityType: AuditLead})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 4 {
+		t.Errorf("%d lead audit entries, want 4", len(entries))
+	}
+}
+
+func TestLeadNotes(t *testing.T) {
+	c := newTestClient(t)
+	id := insertTestSubmission(t, c, "contact", `{}`, time.Now())
+
+	if err := c.AddLeadNote(id, ""); err == nil {
+		t.Error("AddLeadNote accepted an empty note")
+	}
+	if err := c.AddLeadNote(id+100, "call back"); err == nil {
+		t.Error("AddLeadNote on a missing lead succeeded")
+	}
+	for _, body := range []string{"called", "sent quote"} {
+		if err := c.AddLeadNote(id, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notes, err := c.GetLeadNotes(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 2 || notes[0].Body != "sent quote" || notes[1].Body != "called" || notes[0].Actor != "test" {
+		t.Errorf("GetLeadNotes = %+v, want both notes newest first", notes)
+	}
+}
+
+func TestGetLeadCounts(t *testing.T) {
+	c := newTestClient(t)
+	first := insertTestSubmission(t, c, "contact", `{}`, time.Now())
+	insertTestSubmission(t, c, "contact", `{}`, time.Now())
+	insertTestSubmission(t, c, "quote", `{}`, time.Now())
+	if _, err := c.Query("UPDATE submissions SET lead_status = NULL WHERE form_id = 'quote'"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetLeadStatus(first, LeadLost); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := c.GetLeadCounts("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts[LeadNew] != 2 || counts[LeadLost] != 1 || counts[LeadContacted] != 0 || len(counts) != len(LeadStatuses) {
+		t.Errorf("GetLeadCounts = %v", counts)
+	}
+
+	counts, err = c.GetLeadCounts("quote")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts[LeadNew] != 1 || counts[LeadLost] != 0 {
+		t.Errorf("GetLeadCounts(quote) = %v, want the unset status counted as new", counts)
+	}
+}
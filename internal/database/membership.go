@@ -0,0 +1,140 @@
+package database
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/csvsafe"
+)
+
+// AddContactsToForm makes the contacts recipients of the form, skipping
+// those that already are. It returns the IDs of the contacts added.
+func (c *Client) AddContactsToForm(formID string, contacts []Contact) ([]int, error) {
+	if len(contacts) == 0 {
+		return nil, nil
+	}
+	ids := make([]int, len(contacts))
+	for i, contact := range contacts {
+		ids[i] = contact.ID
+	}
+	linked, err := c.formContactIDs(formID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	before := c.formAuditState(formID)
+	var added []int
+	for _, contact := range contacts {
+		if linked[contact.ID] {
+			continue
+		}
+		contactID := contact.ID
+		number := Number{FormID: formID, PhoneNumber: contact.PhoneNumber, Label: contact.Name, ContactID: &contactID}
+		if err := c.createFormNumber(&number); err != nil {
+			return added, fmt.Errorf("failed to add %s to form %s: %w", contact.Name, formID, err)
+		}
+		linked[contact.ID] = true
+		added = append(added, contact.ID)
+	}
+
+	if len(added) > 0 {
+		c.saveFormVersion(formID, fmt.Sprintf("added %d contacts", len(added)))
+		c.auditForm("update", formID, before)
+	}
+	return added, nil
+}
+
+// RemoveContactsFromForm stops the form from messaging the contacts. It
+// returns the IDs of the contacts that were recipients.
+func (c *Client) RemoveContactsFromForm(formID string, contactIDs []int) ([]int, error) {
+	if len(contactIDs) == 0 {
+		return nil, nil
+	}
+	linked, err := c.formContactIDs(formID, contactIDs)
+	if err != nil {
+		return nil, err
+	}
+	var removed []int
+	for _, id := range contactIDs {
+		if linked[id] {
+			removed = append(removed, id)
+		}
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	before := c.formAuditState(formID)
+	params := []interface{}{formID}
+	for _, id := range removed {
+		params = append(params, id)
+	}
+	query := fmt.Sprintf("DELETE FROM form_numbers WHERE form_id = ? AND contact_id IN (%s)", placeholders(len(removed)))
+	if _, err := c.Query(query, params...); err != nil {
+		return nil, fmt.Errorf("failed to remove contacts from form %s: %w", formID, err)
+	}
+
+	c.saveFormVersion(formID, fmt.Sprintf("removed %d contacts", len(removed)))
+	c.auditForm("update", formID, before)
+	return removed, nil
+}
+
+// formContactIDs returns which of the contacts are recipients of the form
+func (c *Client) formContactIDs(formID string, contactIDs []int) (map[int]bool, error) {
+	params := []interface{}{formID}
+	for _, id := range contactIDs {
+		params = append(params, id)
+	}
+	query := fmt.Sprintf(
+		"SELECT DISTINCT contact_id FROM form_numbers WHERE form_id = ? AND contact_id IN (%s)",
+		placeholders(len(contactIDs)),
+	)
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipients of form %s: %w", formID, err)
+	}
+
+	linked := make(map[int]bool)
+	for _, row := range result.Results {
+		if id, ok := row["contact_id"].(float64); ok {
+			linked[int(id)] = true
+		}
+	}
+	return linked, nil
+}
+
+// ContactsCSV renders contacts in the CSV layout of ExportContactsCSV.
+// Cells a spreadsheet would run as formulas are escaped.
+func ContactsCSV(contacts []ContactWithStats) ([]byte, error) {
+	var buf strings.Builder
+	writer := csv.NewWriter(&buf)
+
+	header := []string{"ID", "Name", "Phone Number", "Company", "Role", "Notes", "Form Count", "Created At"}
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, contact := range contacts {
+		record := []string{
+			fmt.Sprintf("%d", contact.ID),
+			contact.Name,
+			contact.PhoneNumber,
+			contact.Company,
+			contact.Role,
+			contact.Notes,
+			fmt.Sprintf("%d", contact.FormCount),
+			contact.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+		if err := writer.Write(csvsafe.EscapeCells(record)); err != nil {
+			return nil, fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("CSV writer error: %w", err)
+	}
+
+	return []byte(buf.String()), nil
+}
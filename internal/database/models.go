@@ -1,18 +1,24 @@
 package database
 
 import (
+	"encoding/json"
 	"time"
 )
 
 // Form represents a webhook form configuration
 type Form struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Fields      []Field   `json:"fields"`
-	Numbers     []Number  `json:"numbers"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Fields      []Field    `json:"fields"`
+	Numbers     []Number   `json:"numbers"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	// Enabled is false while the form is paused; submissions are buffered
+	Enabled bool `json:"enabled"`
+	// PausedUntil ends a pause automatically; nil pauses until resumed
+	PausedUntil *time.Time `json:"paused_until,omitempty"`
 }
 
 // Field represents a form field mapping
@@ -37,14 +43,15 @@ type Number struct {
 
 // Contact represents a contact in the system
 type Contact struct {
-	ID          int       `json:"id"`
-	PhoneNumber string    `json:"phone_number"`
-	Name        string    `json:"name"`
-	Company     string    `json:"company,omitempty"`
-	Role        string    `json:"role,omitempty"`
-	Notes       string    `json:"notes,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int        `json:"id"`
+	PhoneNumber string     `json:"phone_number"`
+	Name        string     `json:"name"`
+	Company     string     `json:"company,omitempty"`
+	Role        string     `json:"role,omitempty"`
+	Notes       string     `json:"notes,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
 }
 
 // FormWithStats includes form with additional statistics
@@ -72,15 +79,146 @@ type WebhookLog struct {
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+// FormVersion is a numbered snapshot of a form taken on every save
+type FormVersion struct {
+	ID        int       `json:"id"`
+	FormID    string    `json:"form_id"`
+	Version   int       `json:"version"`
+	Form      Form      `json:"form"`
+	Note      string    `json:"note,omitempty"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditEntry records a single configuration change
+type AuditEntry struct {
+	ID         int             `json:"id"`
+	Actor      string          `json:"actor"`
+	Profile    string          `json:"profile"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Delivery is a webhook the worker processed, with what it sent and to whom
+type Delivery struct {
+	ID         int                 `json:"id"`
+	FormID     string              `json:"form_id"`
+	Status     string              `json:"status"`
+	Message    string              `json:"message"`
+	Fields     map[string]string   `json:"fields,omitempty"`
+	Recipients []DeliveryRecipient `json:"recipients"`
+	Duration   int                 `json:"duration_ms"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+// DeliveryRecipient is the outcome of a delivery for one phone number
+type DeliveryRecipient struct {
+	Phone     string    `json:"phone"`
+	Status    string    `json:"status"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Submission is a form submission as the worker received it
+type Submission struct {
+	ID             int               `json:"id"`
+	FormID         string            `json:"form_id"`
+	Fields         map[string]string `json:"fields"`
+	Payload        string            `json:"payload,omitempty"`
+	Country        string            `json:"country,omitempty"`
+	City           string            `json:"city,omitempty"`
+	UserAgent      string            `json:"user_agent,omitempty"`
+	IP             string            `json:"ip,omitempty"`
+	Referer        string            `json:"referer,omitempty"`
+	DeliveryStatus string            `json:"delivery_status"`
+	LogID          *int              `json:"log_id,omitempty"`
+	LeadStatus     string            `json:"lead_status"`
+	OwnerContactID *int              `json:"owner_contact_id,omitempty"`
+	FollowUpAt     *time.Time        `json:"follow_up_at,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+// LeadStatusChange is one entry of a lead's status history
+type LeadStatusChange struct {
+	ID           int       `json:"id"`
+	SubmissionID int       `json:"submission_id"`
+	FromStatus   string    `json:"from_status"`
+	ToStatus     string    `json:"to_status"`
+	Actor        string    `json:"actor"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// LeadNote is a note someone left on a lead
+type LeadNote struct {
+	ID           int       `json:"id"`
+	SubmissionID int       `json:"submission_id"`
+	Body         string    `json:"body"`
+	Actor        string    `json:"actor"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// MonitoringState is the Z-API connection state the worker's cron last
+// recorded; it only changes when the connection flips
+type MonitoringState struct {
+	Key         string          `json:"key"`
+	Connected   bool            `json:"connected"`
+	Session     bool            `json:"session"`
+	Status      json.RawMessage `json:"status,omitempty"`
+	LastChanged time.Time       `json:"last_changed"`
+}
+
+// MonitoringEvent is one flip of the Z-API connection state
+type MonitoringEvent struct {
+	ID        int             `json:"id"`
+	Key       string          `json:"key"`
+	Connected bool            `json:"connected"`
+	Session   bool            `json:"session"`
+	Status    json.RawMessage `json:"status,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// OutboundJob is one message waiting to be sent to one recipient
+type OutboundJob struct {
+	ID            int        `json:"id"`
+	FormID        string     `json:"form_id"`
+	LogID         *int       `json:"log_id,omitempty"`
+	Phone         string     `json:"phone"`
+	Message       string     `json:"message"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	MaxAttempts   int        `json:"max_attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// QueueStats counts outbound jobs by status
+type QueueStats struct {
+	Pending  int `json:"pending"`
+	InFlight int `json:"in_flight"`
+	Sent     int `json:"sent"`
+	Dead     int `json:"dead"`
+}
+
 // Stats represents dashboard statistics
 type Stats struct {
 	TotalForms       int       `json:"total_forms"`
 	ActiveForms      int       `json:"active_forms"`
+	PausedForms      int       `json:"paused_forms"`
 	TotalContacts    int       `json:"total_contacts"`
 	WebhooksToday    int       `json:"webhooks_today"`
 	WebhooksThisWeek int       `json:"webhooks_week"`
 	LastWebhook      time.Time `json:"last_webhook"`
 	ConnectionStatus string    `json:"connection_status"`
+	// Leads counts submissions by lead status
+	Leads map[string]int `json:"leads"`
 }
 
 // D1Response represents the response from Cloudflare D1 API
@@ -97,13 +235,14 @@ type D1Result struct {
 	Meta    D1Meta                   `json:"meta"`
 }
 
-// D1Meta contains metadata about the query execution
+// D1Meta contains metadata about the query execution. Changes counts the
+// rows a write inserted, updated or deleted; D1 has no rows_affected.
 type D1Meta struct {
-	Duration     float64 `json:"duration"`
-	LastRowID    int64   `json:"last_row_id"`
-	RowsAffected int     `json:"rows_affected"`
-	RowsRead     int     `json:"rows_read"`
-	RowsWritten  int     `json:"rows_written"`
+	Changes     int     `json:"changes"`
+	Duration    float64 `json:"duration"`
+	LastRowID   int64   `json:"last_row_id"`
+	RowsRead    int     `json:"rows_read"`
+	RowsWritten int     `json:"rows_written"`
 }
 
 // D1Error represents an error from the D1 API
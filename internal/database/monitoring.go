@@ -0,0 +1,100 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MonitorZAPI is the key the worker's cron records the Z-API connection under
+const MonitorZAPI = "zapi-status"
+
+// ErrMonitoringNotStarted is returned when the worker has not created the
+// monitoring tables yet
+var ErrMonitoringNotStarted = errors.New("monitoring has not run yet; set MONITORING_ENABLED=true on the worker and wait for its cron")
+
+// GetMonitoringState retrieves the last recorded state of a monitor, or nil
+// when the worker has not checked it yet
+func (c *Client) GetMonitoringState(key string) (*MonitoringState, error) {
+	result, err := c.Query("SELECT * FROM monitoring_state WHERE key = ?", key)
+	if err != nil {
+		return nil, monitoringError("failed to get monitoring state", err)
+	}
+	if len(result.Results) == 0 {
+		return nil, nil
+	}
+
+	row := result.Results[0]
+	state := &MonitoringState{
+		Key:         key,
+		Connected:   rowBool(row["connected"]),
+		Session:     rowBool(row["session"]),
+		Status:      rowJSON(row["status_json"]),
+		LastChanged: parseTime(row["last_changed"]),
+	}
+	return state, nil
+}
+
+// GetMonitoringHistory retrieves the most recent state flips of a monitor,
+// newest first. The worker keeps the last 100.
+func (c *Client) GetMonitoringHistory(key string, limit int) ([]MonitoringEvent, error) {
+	query := "SELECT * FROM monitoring_history WHERE key = ? ORDER BY id DESC"
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	result, err := c.Query(query, key)
+	if err != nil {
+		return nil, monitoringError("failed to get monitoring history", err)
+	}
+
+	var events []MonitoringEvent
+	for _, row := range result.Results {
+		event := MonitoringEvent{
+			Key:       key,
+			Connected: rowBool(row["connected"]),
+			Session:   rowBool(row["session"]),
+			Status:    rowJSON(row["status_json"]),
+			CreatedAt: parseTime(row["created_at"]),
+		}
+		if id, ok := row["id"].(float64); ok {
+			event.ID = int(id)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// StatusError returns the error the worker recorded with a state, if the
+// Z-API status check itself failed
+func StatusError(status json.RawMessage) string {
+	var fields struct {
+		Error string `json:"error"`
+	}
+	if len(status) == 0 || json.Unmarshal(status, &fields) != nil {
+		return ""
+	}
+	return fields.Error
+}
+
+// monitoringError maps a missing table to ErrMonitoringNotStarted
+func monitoringError(msg string, err error) error {
+	if strings.Contains(err.Error(), "no such table") {
+		return ErrMonitoringNotStarted
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func rowBool(value interface{}) bool {
+	n, ok := value.(float64)
+	return ok && n != 0
+}
+
+func rowJSON(value interface{}) json.RawMessage {
+	s, ok := value.(string)
+	if !ok || s == "" || !json.Valid([]byte(s)) {
+		return nil
+	}
+	return json.RawMessage(s)
+}
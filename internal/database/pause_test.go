@@ -0,0 +1,80 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPauseAndResumeForm(t *testing.T) {
+	c := newTestClient(t)
+	createTestForm(t, c, "contact")
+
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := c.PauseForm("contact", &until); err != nil {
+		t.Fatalf("PauseForm: %v", err)
+	}
+	form, err := c.GetForm("contact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !form.IsPaused(time.Now()) || form.IsPaused(until.Add(time.Second)) {
+		t.Errorf("paused until %v: Enabled = %v, PausedUntil = %v", until, form.Enabled, form.PausedUntil)
+	}
+
+	if err := c.ResumeForm("contact"); err != nil {
+		t.Fatalf("ResumeForm: %v", err)
+	}
+	if form, _ := c.GetForm("contact"); form.IsPaused(time.Now()) || form.PausedUntil != nil {
+		t.Errorf("resumed form: Enabled = %v, PausedUntil = %v", form.Enabled, form.PausedUntil)
+	}
+
+	if err := c.PauseForm("missing", nil); err == nil {
+		t.Error("pausing a missing form should fail")
+	}
+	if err := c.ResumeForm("missing"); err == nil {
+		t.Error("resuming a missing form should fail")
+	}
+}
+
+func TestGetBufferedCounts(t *testing.T) {
+	c := newTestClient(t)
+	createTestForm(t, c, "contact")
+	inserts := []string{
+		"INSERT INTO buffered_submissions (form_id, message) VALUES ('contact', 'a')",
+		"INSERT INTO buffered_submissions (form_id, message) VALUES ('contact', 'b')",
+		"INSERT INTO buffered_submissions (form_id, message, delivered_at) VALUES ('contact', 'c', CURRENT_TIMESTAMP)",
+	}
+	for _, insert := range inserts {
+		if _, err := c.Query(insert); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counts, err := c.GetBufferedCounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts["contact"] != 2 {
+		t.Errorf("buffered count = %d, want 2 undelivered", counts["contact"])
+	}
+}
+
+func TestIsPaused(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+	tests := []struct {
+		name string
+		form Form
+		want bool
+	}{
+		{"enabled", Form{Enabled: true}, false},
+		{"indefinitely", Form{}, true},
+		{"window open", Form{PausedUntil: &future}, true},
+		{"window ended", Form{PausedUntil: &past}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.form.IsPaused(now); got != tt.want {
+			t.Errorf("%s: IsPaused = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// Outbound job statuses
+const (
+	JobPending  = "pending"
+	JobInFlight = "in_flight"
+	JobSent     = "sent"
+	JobDead     = "dead"
+)
+
+// EnqueueJob adds a message for one recipient to the outbound queue
+func (c *Client) EnqueueJob(job *OutboundJob) error {
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = 5
+	}
+	if job.NextAttemptAt.IsZero() {
+		job.NextAttemptAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO outbound_jobs (form_id, log_id, phone, message, status, max_attempts, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	var logID interface{}
+	if job.LogID != nil {
+		logID = *job.LogID
+	}
+	result, err := c.Query(query, job.FormID, logID, job.Phone, job.Message, JobPending, job.MaxAttempts, sqlTime(job.NextAttemptAt))
+	if err != nil {
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	job.ID = int(result.Meta.LastRowID)
+	job.Status = JobPending
+	return nil
+}
+
+// ClaimJobs leases up to limit due jobs to a runner. Pending jobs whose
+// next attempt is due are claimed, as are in-flight jobs whose lease
+// expired because their runner died. Claiming counts as an attempt.
+func (c *Client) ClaimJobs(runner string, limit int, lease time.Duration) ([]OutboundJob, error) {
+	now := time.Now()
+	query := `
+		UPDATE outbound_jobs
+		SET status = ?, attempts = attempts + 1, locked_by = ?, locked_until = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM outbound_jobs
+			WHERE (status = ? AND next_attempt_at <= ?)
+				OR (status = ? AND locked_until < ?)
+			ORDER BY next_attempt_at, id
+			LIMIT ?
+		)
+		RETURNING *
+	`
+	result, err := c.Query(query,
+		JobInFlight, runner, sqlTime(now.Add(lease)),
+		JobPending, sqlTime(now),
+		JobInFlight, sqlTime(now),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim jobs: %w", err)
+	}
+
+	var jobs []OutboundJob
+	for _, row := range result.Results {
+		jobs = append(jobs, jobFromRow(row))
+	}
+	return jobs, nil
+}
+
+// CompleteJob marks a job as sent
+func (c *Client) CompleteJob(id int) error {
+	query := `
+		UPDATE outbound_jobs
+		SET status = ?, locked_by = NULL, locked_until = NULL, last_error = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	if _, err := c.Query(query, JobSent, id); err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+	return nil
+}
+
+// RetryJob releases a failed job to be attempted again at next
+func (c *Client) RetryJob(id int, lastError string, next time.Time) error {
+	query := `
+		UPDATE outbound_jobs
+		SET status = ?, next_attempt_at = ?, locked_by = NULL, locked_until = NULL, last_error = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	if _, err := c.Query(query, JobPending, sqlTime(next), lastError, id); err != nil {
+		return fmt.Errorf("failed to reschedule job: %w", err)
+	}
+	return nil
+}
+
+// DeadLetterJob gives up on a job after its last attempt failed
+func (c *Client) DeadLetterJob(id int, lastError string) error {
+	query := `
+		UPDATE outbound_jobs
+		SET status = ?, locked_by = NULL, locked_until = NULL, last_error = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	if _, err := c.Query(query, JobDead, lastError, id); err != nil {
+		return fmt.Errorf("failed to dead-letter job: %w", err)
+	}
+	return nil
+}
+
+// RequeueDeadJobs gives dead jobs a fresh set of attempts. With no IDs
+// every dead job is requeued. It returns how many jobs were requeued.
+func (c *Client) RequeueDeadJobs(ids ...int) (int, error) {
+	query := `
+		UPDATE outbound_jobs
+		SET status = ?, attempts = 0, next_attempt_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE status = ?
+	`
+	params := []interface{}{JobPending, JobDead}
+	if len(ids) > 0 {
+		query += " AND id IN (" + placeholders(len(ids)) + ")"
+		for _, id := range ids {
+			params = append(params, id)
+		}
+	}
+
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue jobs: %w", err)
+	}
+	return result.Meta.Changes, nil
+}
+
+// GetJobs lists jobs with the given status, or all jobs when status is
+// empty, most recently updated first
+func (c *Client) GetJobs(status string, limit int) ([]OutboundJob, error) {
+	query := "SELECT * FROM outbound_jobs"
+	var params []interface{}
+	if status != "" {
+		query += " WHERE status = ?"
+		params = append(params, status)
+	}
+	query += " ORDER BY updated_at DESC, id DESC"
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+
+	var jobs []OutboundJob
+	for _, row := range result.Results {
+		jobs = append(jobs, jobFromRow(row))
+	}
+	return jobs, nil
+}
+
+// GetQueueStats counts jobs by status
+func (c *Client) GetQueueStats() (*QueueStats, error) {
+	result, err := c.Query("SELECT status, COUNT(*) as count FROM outbound_jobs GROUP BY status")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue stats: %w", err)
+	}
+
+	stats := &QueueStats{}
+	for _, row := range result.Results {
+		count, _ := row["count"].(float64)
+		switch row["status"] {
+		case JobPending:
+			stats.Pending = int(count)
+		case JobInFlight:
+			stats.InFlight = int(count)
+		case JobSent:
+			stats.Sent = int(count)
+		case JobDead:
+			stats.Dead = int(count)
+		}
+	}
+	return stats, nil
+}
+
+func jobFromRow(row map[string]interface{}) OutboundJob {
+	job := OutboundJob{}
+
+	if id, ok := row["id"].(float64); ok {
+		job.ID = int(id)
+	}
+	job.FormID, _ = row["form_id"].(string)
+	if logID, ok := row["log_id"].(float64); ok {
+		id := int(logID)
+		job.LogID = &id
+	}
+	job.Phone, _ = row["phone"].(string)
+	job.Message, _ = row["message"].(string)
+	job.Status, _ = row["status"].(string)
+	if attempts, ok := row["attempts"].(float64); ok {
+		job.Attempts = int(attempts)
+	}
+	if maxAttempts, ok := row["max_attempts"].(float64); ok {
+		job.MaxAttempts = int(maxAttempts)
+	}
+	job.NextAttemptAt = parseTime(row["next_attempt_at"])
+	job.LockedUntil = parseTimePtr(row["locked_until"])
+	job.LastError, _ = row["last_error"].(string)
+	job.CreatedAt = parseTime(row["created_at"])
+	job.UpdatedAt = parseTime(row["updated_at"])
+
+	return job
+}
@@ -0,0 +1,327 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaColumn describes a column the database layer expects to exist.
+// Definition must be usable in ALTER TABLE ... ADD COLUMN, so it cannot
+// carry PRIMARY KEY or NOT NULL without a default.
+type SchemaColumn struct {
+	Name       string
+	Definition string
+}
+
+// SchemaTable describes a table the database layer reads and writes
+type SchemaTable struct {
+	Name    string
+	Columns []SchemaColumn
+}
+
+// ExpectedSchema lists the tables and columns ewctl depends on, in the
+// same order InitSchema creates them
+var ExpectedSchema = []SchemaTable{
+	{
+		Name: "forms",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "TEXT"},
+			{Name: "name", Definition: "TEXT"},
+			{Name: "description", Definition: "TEXT"},
+			{Name: "created_at", Definition: "DATETIME"},
+			{Name: "updated_at", Definition: "DATETIME"},
+			{Name: "archived_at", Definition: "DATETIME"},
+			{Name: "enabled", Definition: "BOOLEAN DEFAULT 1"},
+			{Name: "paused_until", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "form_fields",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "form_id", Definition: "TEXT"},
+			{Name: "elementor_id", Definition: "TEXT"},
+			{Name: "label", Definition: "TEXT"},
+			{Name: "type", Definition: "TEXT DEFAULT 'text'"},
+			{Name: "required", Definition: "BOOLEAN DEFAULT 0"},
+			{Name: "position", Definition: "INTEGER DEFAULT 0"},
+		},
+	},
+	{
+		Name: "contacts",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "phone_number", Definition: "TEXT"},
+			{Name: "name", Definition: "TEXT"},
+			{Name: "company", Definition: "TEXT"},
+			{Name: "role", Definition: "TEXT"},
+			{Name: "notes", Definition: "TEXT"},
+			{Name: "created_at", Definition: "DATETIME"},
+			{Name: "updated_at", Definition: "DATETIME"},
+			{Name: "archived_at", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "form_numbers",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "form_id", Definition: "TEXT"},
+			{Name: "phone_number", Definition: "TEXT"},
+			{Name: "label", Definition: "TEXT"},
+			{Name: "contact_id", Definition: "INTEGER REFERENCES contacts(id)"},
+		},
+	},
+	{
+		Name: "webhook_logs",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "form_id", Definition: "TEXT"},
+			{Name: "status", Definition: "TEXT"},
+			{Name: "request", Definition: "TEXT"},
+			{Name: "response", Definition: "TEXT"},
+			{Name: "duration_ms", Definition: "INTEGER"},
+			{Name: "created_at", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "delivery_recipients",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "log_id", Definition: "INTEGER"},
+			{Name: "phone", Definition: "TEXT"},
+			{Name: "status", Definition: "TEXT"},
+			{Name: "attempts", Definition: "INTEGER DEFAULT 1"},
+			{Name: "last_error", Definition: "TEXT"},
+			{Name: "updated_at", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "outbound_jobs",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "form_id", Definition: "TEXT"},
+			{Name: "log_id", Definition: "INTEGER"},
+			{Name: "phone", Definition: "TEXT"},
+			{Name: "message", Definition: "TEXT"},
+			{Name: "status", Definition: "TEXT DEFAULT 'pending'"},
+			{Name: "attempts", Definition: "INTEGER DEFAULT 0"},
+			{Name: "max_attempts", Definition: "INTEGER DEFAULT 5"},
+			{Name: "next_attempt_at", Definition: "DATETIME"},
+			{Name: "locked_by", Definition: "TEXT"},
+			{Name: "locked_until", Definition: "DATETIME"},
+			{Name: "last_error", Definition: "TEXT"},
+			{Name: "created_at", Definition: "DATETIME"},
+			{Name: "updated_at", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "form_versions",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "form_id", Definition: "TEXT"},
+			{Name: "version", Definition: "INTEGER"},
+			{Name: "snapshot", Definition: "TEXT"},
+			{Name: "note", Definition: "TEXT"},
+			{Name: "actor", Definition: "TEXT"},
+			{Name: "created_at", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "audit_log",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "actor", Definition: "TEXT"},
+			{Name: "profile", Definition: "TEXT"},
+			{Name: "action", Definition: "TEXT"},
+			{Name: "entity_type", Definition: "TEXT"},
+			{Name: "entity_id", Definition: "TEXT"},
+			{Name: "before_json", Definition: "TEXT"},
+			{Name: "after_json", Definition: "TEXT"},
+			{Name: "created_at", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "buffered_submissions",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "form_id", Definition: "TEXT"},
+			{Name: "message", Definition: "TEXT"},
+			{Name: "payload", Definition: "TEXT"},
+			{Name: "created_at", Definition: "DATETIME"},
+			{Name: "delivered_at", Definition: "DATETIME"},
+			{Name: "submission_id", Definition: "INTEGER"},
+			{Name: "claimed_at", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "submissions",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "form_id", Definition: "TEXT"},
+			{Name: "fields", Definition: "TEXT"},
+			{Name: "payload", Definition: "TEXT"},
+			{Name: "country", Definition: "TEXT"},
+			{Name: "city", Definition: "TEXT"},
+			{Name: "user_agent", Definition: "TEXT"},
+			{Name: "ip", Definition: "TEXT"},
+			{Name: "referer", Definition: "TEXT"},
+			{Name: "delivery_status", Definition: "TEXT"},
+			{Name: "log_id", Definition: "INTEGER"},
+			{Name: "lead_status", Definition: "TEXT DEFAULT 'new'"},
+			{Name: "owner_contact_id", Definition: "INTEGER"},
+			{Name: "follow_up_at", Definition: "DATETIME"},
+			{Name: "created_at", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "lead_status_history",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "submission_id", Definition: "INTEGER"},
+			{Name: "from_status", Definition: "TEXT"},
+			{Name: "to_status", Definition: "TEXT"},
+			{Name: "actor", Definition: "TEXT"},
+			{Name: "created_at", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "lead_notes",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "submission_id", Definition: "INTEGER"},
+			{Name: "body", Definition: "TEXT"},
+			{Name: "actor", Definition: "TEXT"},
+			{Name: "created_at", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "monitoring_state",
+		Columns: []SchemaColumn{
+			{Name: "key", Definition: "TEXT"},
+			{Name: "connected", Definition: "INTEGER DEFAULT 0"},
+			{Name: "session", Definition: "INTEGER"},
+			{Name: "status_json", Definition: "TEXT"},
+			{Name: "last_changed", Definition: "DATETIME"},
+		},
+	},
+	{
+		Name: "monitoring_history",
+		Columns: []SchemaColumn{
+			{Name: "id", Definition: "INTEGER"},
+			{Name: "key", Definition: "TEXT"},
+			{Name: "connected", Definition: "INTEGER DEFAULT 0"},
+			{Name: "session", Definition: "INTEGER"},
+			{Name: "status_json", Definition: "TEXT"},
+			{Name: "created_at", Definition: "DATETIME"},
+		},
+	},
+}
+
+// SchemaDrift describes a table or column missing from the live database
+type SchemaDrift struct {
+	Table  string
+	Column string // empty when the whole table is missing
+}
+
+func (d SchemaDrift) String() string {
+	if d.Column == "" {
+		return fmt.Sprintf("table %s is missing", d.Table)
+	}
+	return fmt.Sprintf("column %s.%s is missing", d.Table, d.Column)
+}
+
+// GetTableColumns returns the column names of a table, or nil if it does not exist
+func (c *Client) GetTableColumns(table string) ([]string, error) {
+	result, err := c.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+
+	var columns []string
+	for _, row := range result.Results {
+		if name, ok := row["name"].(string); ok {
+			columns = append(columns, name)
+		}
+	}
+
+	return columns, nil
+}
+
+// GetSchemaDrift compares the live schema against ExpectedSchema
+func (c *Client) GetSchemaDrift() ([]SchemaDrift, error) {
+	var drift []SchemaDrift
+	for _, table := range ExpectedSchema {
+		columns, err := c.GetTableColumns(table.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(columns) == 0 {
+			drift = append(drift, SchemaDrift{Table: table.Name})
+			continue
+		}
+
+		existing := make(map[string]bool)
+		for _, col := range columns {
+			existing[strings.ToLower(col)] = true
+		}
+		for _, col := range table.Columns {
+			if !existing[col.Name] {
+				drift = append(drift, SchemaDrift{Table: table.Name, Column: col.Name})
+			}
+		}
+	}
+
+	return drift, nil
+}
+
+// MigrateSchema creates missing tables and adds missing columns
+func (c *Client) MigrateSchema() (int, error) {
+	drift, err := c.GetSchemaDrift()
+	if err != nil {
+		return 0, err
+	}
+
+	missingTable := false
+	for _, d := range drift {
+		if d.Column == "" {
+			missingTable = true
+		}
+	}
+	if missingTable {
+		if err := c.InitSchema(); err != nil {
+			return 0, err
+		}
+	}
+
+	fixed := 0
+	for _, d := range drift {
+		if d.Column == "" {
+			fixed++
+			continue
+		}
+
+		def := expectedColumn(d.Table, d.Column)
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", d.Table, d.Column, def)
+		if _, err := c.Query(query); err != nil {
+			return fixed, fmt.Errorf("failed to add column %s.%s: %w", d.Table, d.Column, err)
+		}
+		fixed++
+	}
+
+	return fixed, nil
+}
+
+func expectedColumn(table, column string) string {
+	for _, t := range ExpectedSchema {
+		if t.Name != table {
+			continue
+		}
+		for _, col := range t.Columns {
+			if col.Name == column {
+				return col.Definition
+			}
+		}
+	}
+	return "TEXT"
+}
@@ -0,0 +1,185 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// ContactSnapshot captures a contact together with its form links so it
+// can be restored after deletion
+type ContactSnapshot struct {
+	Contact Contact  `json:"contact"`
+	Links   []Number `json:"links"`
+}
+
+// FormSnapshot captures a form together with the rows deleting it
+// removes, so it can be restored after deletion
+type FormSnapshot struct {
+	Form Form `json:"form"`
+	// Rows holds the form's rows in other tables, parents before children
+	Rows []TableRows `json:"rows"`
+}
+
+// TableRows holds rows of one table as read from D1
+type TableRows struct {
+	Table string                   `json:"table"`
+	Rows  []map[string]interface{} `json:"rows"`
+}
+
+// formRowTables lists what deleting a form removes besides its fields and
+// numbers, in the order the rows can be inserted back
+var formRowTables = []string{"webhook_logs", "delivery_recipients", "buffered_submissions", "outbound_jobs"}
+
+func formRowsQuery(table string) string {
+	if table == "delivery_recipients" {
+		return "SELECT * FROM delivery_recipients WHERE log_id IN (SELECT id FROM webhook_logs WHERE form_id = ?) ORDER BY id"
+	}
+	return fmt.Sprintf("SELECT * FROM %s WHERE form_id = ? ORDER BY id", table)
+}
+
+// SnapshotForm captures a form with its fields, numbers, delivery logs,
+// buffered submissions and queued messages
+func (c *Client) SnapshotForm(id string) (*FormSnapshot, error) {
+	form, err := c.GetForm(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot form: %w", err)
+	}
+
+	snapshot := &FormSnapshot{Form: *form}
+	for _, table := range formRowTables {
+		result, err := c.Query(formRowsQuery(table), id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot form %s: %w", table, err)
+		}
+		snapshot.Rows = append(snapshot.Rows, TableRows{Table: table, Rows: result.Results})
+	}
+	return snapshot, nil
+}
+
+// RestoreForm recreates a form from a snapshot, keeping its original ID
+// and creation time. Logs, buffered submissions and queued messages keep
+// their IDs so the rows referencing each other stay linked.
+func (c *Client) RestoreForm(snapshot *FormSnapshot) error {
+	form := &snapshot.Form
+	query := `
+		INSERT INTO forms (id, name, description, created_at, updated_at, archived_at, enabled, paused_until)
+		VALUES (?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP), CURRENT_TIMESTAMP, ?, ?, ?)
+	`
+	_, err := c.Query(query, form.ID, form.Name, form.Description, sqlTime(form.CreatedAt),
+		sqlTimePtr(form.ArchivedAt), form.Enabled, sqlTimePtr(form.PausedUntil))
+	if err != nil {
+		return fmt.Errorf("failed to restore form: %w", err)
+	}
+
+	for _, field := range form.Fields {
+		field.FormID = form.ID
+		if err := c.createFormField(&field); err != nil {
+			log.Error("Failed to restore field", "error", err)
+		}
+	}
+	for _, number := range form.Numbers {
+		number.FormID = form.ID
+		if err := c.createFormNumber(&number); err != nil {
+			log.Error("Failed to restore number", "error", err)
+		}
+	}
+	for _, tr := range snapshot.Rows {
+		for _, row := range tr.Rows {
+			if err := c.insertRow(tr.Table, row); err != nil {
+				log.Error("Failed to restore row", "table", tr.Table, "id", row["id"], "error", err)
+			}
+		}
+	}
+
+	c.saveFormVersion(form.ID, "restored")
+	c.auditForm("undelete", form.ID, nil)
+	return nil
+}
+
+// SnapshotContact captures a contact and every form it is linked to
+func (c *Client) SnapshotContact(id int) (*ContactSnapshot, error) {
+	contact, err := c.GetContactByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot contact: %w", err)
+	}
+
+	result, err := c.Query("SELECT * FROM form_numbers WHERE contact_id = ? ORDER BY id", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot contact links: %w", err)
+	}
+
+	snapshot := &ContactSnapshot{Contact: *contact}
+	for _, row := range result.Results {
+		snapshot.Links = append(snapshot.Links, numberFromRow(row))
+	}
+
+	return snapshot, nil
+}
+
+// RestoreContact recreates a contact under its original ID and puts it
+// back on the forms it was linked to. Links to forms deleted in the
+// meantime are skipped.
+func (c *Client) RestoreContact(snapshot *ContactSnapshot) error {
+	contact := snapshot.Contact
+	query := `
+		INSERT INTO contacts (id, phone_number, name, company, role, notes, created_at, updated_at, archived_at)
+		VALUES (?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP), CURRENT_TIMESTAMP, ?)
+	`
+	_, err := c.Query(query, contact.ID, contact.PhoneNumber, contact.Name, contact.Company,
+		contact.Role, contact.Notes, sqlTime(contact.CreatedAt), sqlTimePtr(contact.ArchivedAt))
+	if err != nil {
+		return fmt.Errorf("failed to restore contact: %w", err)
+	}
+
+	for _, link := range snapshot.Links {
+		if existing, _ := c.GetForm(link.FormID); existing == nil {
+			log.Warn("Skipping link to deleted form", "form", link.FormID)
+			continue
+		}
+		if err := c.createFormNumber(&link); err != nil {
+			log.Error("Failed to restore contact link", "form", link.FormID, "error", err)
+		}
+	}
+
+	c.auditContact("undelete", contact.ID, nil)
+	return nil
+}
+
+// insertRow writes a row read with SELECT * back into its table. Rows
+// that survived the deletion are left as they are.
+func (c *Client) insertRow(table string, row map[string]interface{}) error {
+	columns := make([]string, 0, len(row))
+	for column := range row {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = row[column]
+	}
+
+	query := fmt.Sprintf("INSERT OR IGNORE INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), placeholders(len(columns)))
+	_, err := c.Query(query, values...)
+	return err
+}
+
+// sqlTime formats a time for D1, or returns nil for the zero time so
+// the column falls back to its default
+func sqlTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+func sqlTimePtr(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return sqlTime(*t)
+}
@@ -0,0 +1,49 @@
+package database
+
+import "testing"
+
+func TestRestoreFormBringsBackItsRows(t *testing.T) {
+	c := newTestClient(t)
+	createTestForm(t, c, "contact", "5511999990001")
+	inserts := []string{
+		"INSERT INTO webhook_logs (id, form_id, status) VALUES (7, 'contact', 'success')",
+		"INSERT INTO delivery_recipients (log_id, phone, status) VALUES (7, '5511999990001', 'sent')",
+		"INSERT INTO buffered_submissions (form_id, message) VALUES ('contact', 'hi')",
+		"INSERT INTO outbound_jobs (form_id, log_id, phone, message) VALUES ('contact', 7, '5511999990001', 'hi')",
+	}
+	for _, insert := range inserts {
+		if _, err := c.Query(insert); err != nil {
+			t.Fatalf("%s: %v", insert, err)
+		}
+	}
+
+	snapshot, err := c.SnapshotForm("contact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteForm("contact"); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(t, c, "SELECT COUNT(*) FROM delivery_recipients"); n != 0 {
+		t.Fatalf("deleting the form left %d delivery recipients", n)
+	}
+
+	if err := c.RestoreForm(snapshot); err != nil {
+		t.Fatalf("RestoreForm: %v", err)
+	}
+	form, err := c.GetForm("contact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(form.Fields) != len(DefaultFields()) || len(form.Numbers) != 1 {
+		t.Errorf("restored form has %d fields and %d numbers", len(form.Fields), len(form.Numbers))
+	}
+	for _, table := range formRowTables {
+		if n := count(t, c, "SELECT COUNT(*) FROM "+table); n != 1 {
+			t.Errorf("%s has %d rows after restore, want 1", table, n)
+		}
+	}
+	if n := count(t, c, "SELECT COUNT(*) FROM outbound_jobs WHERE log_id = 7"); n != 1 {
+		t.Error("queued message lost its delivery log")
+	}
+}
@@ -0,0 +1,211 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SubmissionFilter narrows GetSubmissions; zero values match everything
+type SubmissionFilter struct {
+	FormID string
+	// Search matches anywhere in the submitted fields
+	Search string
+	Since  time.Time
+	Until  time.Time
+	// LeadStatus, OwnerContactID and FollowUpBefore narrow to leads
+	LeadStatus     string
+	OwnerContactID int
+	FollowUpBefore time.Time
+	Limit          int
+	Offset         int
+}
+
+// GetSubmissions retrieves submissions matching the filter, newest first
+func (c *Client) GetSubmissions(filter SubmissionFilter) ([]Submission, error) {
+	where, params := filter.where()
+	query := "SELECT * FROM submissions" + where + " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d OFFSET %d", filter.Limit, filter.Offset)
+	}
+
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+
+	var submissions []Submission
+	for _, row := range result.Results {
+		submissions = append(submissions, submissionFromRow(row))
+	}
+	return submissions, nil
+}
+
+// CountSubmissions counts the submissions matching the filter, ignoring
+// its limit and offset
+func (c *Client) CountSubmissions(filter SubmissionFilter) (int, error) {
+	where, params := filter.where()
+	result, err := c.Query("SELECT COUNT(*) as count FROM submissions"+where, params...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count submissions: %w", err)
+	}
+	if len(result.Results) > 0 {
+		if count, ok := result.Results[0]["count"].(float64); ok {
+			return int(count), nil
+		}
+	}
+	return 0, nil
+}
+
+// GetLastSubmissionTime returns when the form, or any form when formID is
+// empty, last received a submission; nil when it never has
+func (c *Client) GetLastSubmissionTime(formID string) (*time.Time, error) {
+	where, params := rangeWhere("", time.Time{}, time.Time{}, formID)
+	result, err := c.Query("SELECT MAX(created_at) as last FROM submissions"+where, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last submission: %w", err)
+	}
+	if len(result.Results) == 0 {
+		return nil, nil
+	}
+	return parseTimePtr(result.Results[0]["last"]), nil
+}
+
+// GetSubmission retrieves a single submission with its raw payload
+func (c *Client) GetSubmission(id int) (*Submission, error) {
+	result, err := c.Query("SELECT * FROM submissions WHERE id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission: %w", err)
+	}
+	if len(result.Results) == 0 {
+		return nil, fmt.Errorf("submission %d not found", id)
+	}
+
+	submission := submissionFromRow(result.Results[0])
+	return &submission, nil
+}
+
+// SubmissionField is one submitted value under its configured label
+type SubmissionField struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// LabeledFields pairs the submitted values with the form's field labels in
+// field position order. Values for fields the form no longer has follow,
+// sorted by key and labelled with it.
+func (s Submission) LabeledFields(fields []Field) []SubmissionField {
+	ordered := make([]Field, len(fields))
+	copy(ordered, fields)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Position < ordered[j].Position
+	})
+
+	var labeled []SubmissionField
+	known := make(map[string]bool, len(ordered))
+	for _, field := range ordered {
+		known[field.ElementorID] = true
+		if value, ok := s.Fields[field.ElementorID]; ok {
+			label := field.Label
+			if label == "" {
+				label = field.ElementorID
+			}
+			labeled = append(labeled, SubmissionField{Key: field.ElementorID, Label: label, Value: value})
+		}
+	}
+
+	var extra []string
+	for key := range s.Fields {
+		if !known[key] {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	for _, key := range extra {
+		labeled = append(labeled, SubmissionField{Key: key, Label: key, Value: s.Fields[key]})
+	}
+
+	return labeled
+}
+
+func (f SubmissionFilter) where() (string, []interface{}) {
+	var conditions []string
+	var params []interface{}
+
+	if f.FormID != "" {
+		conditions = append(conditions, "form_id = ?")
+		params = append(params, f.FormID)
+	}
+	if f.Search != "" {
+		conditions = append(conditions, "fields LIKE ?")
+		params = append(params, "%"+f.Search+"%")
+	}
+	if !f.Since.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		params = append(params, sqlTime(f.Since))
+	}
+	if !f.Until.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		params = append(params, sqlTime(f.Until))
+	}
+	if f.LeadStatus != "" {
+		conditions = append(conditions, "COALESCE(lead_status, 'new') = ?")
+		params = append(params, f.LeadStatus)
+	}
+	if f.OwnerContactID != 0 {
+		conditions = append(conditions, "owner_contact_id = ?")
+		params = append(params, f.OwnerContactID)
+	}
+	if !f.FollowUpBefore.IsZero() {
+		conditions = append(conditions, "follow_up_at IS NOT NULL AND follow_up_at <= ?")
+		params = append(params, sqlTime(f.FollowUpBefore))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), params
+}
+
+func submissionFromRow(row map[string]interface{}) Submission {
+	s := Submission{}
+
+	if id, ok := row["id"].(float64); ok {
+		s.ID = int(id)
+	}
+	s.FormID, _ = row["form_id"].(string)
+	s.Payload, _ = row["payload"].(string)
+	s.Country, _ = row["country"].(string)
+	s.City, _ = row["city"].(string)
+	s.UserAgent, _ = row["user_agent"].(string)
+	s.IP, _ = row["ip"].(string)
+	s.Referer, _ = row["referer"].(string)
+	s.DeliveryStatus, _ = row["delivery_status"].(string)
+	if logID, ok := row["log_id"].(float64); ok {
+		id := int(logID)
+		s.LogID = &id
+	}
+	s.LeadStatus, _ = row["lead_status"].(string)
+	if s.LeadStatus == "" {
+		s.LeadStatus = LeadNew
+	}
+	if owner, ok := row["owner_contact_id"].(float64); ok {
+		id := int(owner)
+		s.OwnerContactID = &id
+	}
+	s.FollowUpAt = parseTimePtr(row["follow_up_at"])
+	s.CreatedAt = parseTime(row["created_at"])
+
+	var fields map[string]interface{}
+	if raw, ok := row["fields"].(string); ok && json.Unmarshal([]byte(raw), &fields) == nil {
+		s.Fields = make(map[string]string, len(fields))
+		for key, value := range fields {
+			s.Fields[key] = fmt.Sprint(value)
+		}
+	}
+
+	return s
+}
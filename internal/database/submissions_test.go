@@ -0,0 +1,122 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+// insertTestSubmission stores a submission the way the worker does
+func insertTestSubmission(t *testing.T, c *Client, formID, fields string, createdAt time.Time) int {
+	t.Helper()
+	query := "INSERT INTO submissions (form_id, fields, payload, delivery_status, created_at) VALUES (?, ?, ?, 'sent', ?)"
+	result, err := c.Query(query, formID, fields, fields, sqlTime(createdAt))
+	if err != nil {
+		t.Fatalf("insert submission: %v", err)
+	}
+	return int(result.Meta.LastRowID)
+}
+
+func TestSubmissionFilter(t *testing.T) {
+	c := newTestClient(t)
+	day := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	insertTestSubmission(t, c, "contact", `{"name":"Ana","email":"ana@example.com"}`, day)
+	insertTestSubmission(t, c, "contact", `{"name":"Bruno"}`, day.Add(24*time.Hour))
+	insertTestSubmission(t, c, "quote", `{"name":"Ana Clara"}`, day.Add(48*time.Hour))
+
+	for _, tt := range []struct {
+		name   string
+		filter SubmissionFilter
+		want   []string
+	}{
+		{"all newest first", SubmissionFilter{}, []string{"Ana Clara", "Bruno", "Ana"}},
+		{"form", SubmissionFilter{FormID: "contact"}, []string{"Bruno", "Ana"}},
+		{"search", SubmissionFilter{Search: "Ana"}, []string{"Ana Clara", "Ana"}},
+		{"since", SubmissionFilter{Since: day.Add(time.Hour)}, []string{"Ana Clara", "Bruno"}},
+		{"until excludes the bound", SubmissionFilter{Until: day.Add(24 * time.Hour)}, []string{"Ana"}},
+		{"page", SubmissionFilter{Limit: 1, Offset: 1}, []string{"Bruno"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			submissions, err := c.GetSubmissions(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, s := range submissions {
+				got = append(got, s.Fields["name"])
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+
+			total, err := c.CountSubmissions(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.filter.Limit == 0 && total != len(tt.want) {
+				t.Errorf("CountSubmissions = %d, want %d", total, len(tt.want))
+			}
+		})
+	}
+
+	if total, _ := c.CountSubmissions(SubmissionFilter{Limit: 1}); total != 3 {
+		t.Errorf("CountSubmissions with a limit = %d, want every match (3)", total)
+	}
+}
+
+func TestGetSubmissionAndLastTime(t *testing.T) {
+	c := newTestClient(t)
+	if last, err := c.GetLastSubmissionTime(""); err != nil || last != nil {
+		t.Fatalf("GetLastSubmissionTime on no submissions = %v, %v; want nil", last, err)
+	}
+
+	day := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	id := insertTestSubmission(t, c, "contact", `{"name":"Ana","age":31}`, day)
+	insertTestSubmission(t, c, "quote", `{}`, day.Add(time.Hour))
+
+	submission, err := c.GetSubmission(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if submission.FormID != "contact" || submission.Fields["age"] != "31" || submission.LeadStatus != LeadNew {
+		t.Errorf("GetSubmission = %+v", submission)
+	}
+	if _, err := c.GetSubmission(id + 100); err == nil {
+		t.Error("GetSubmission of a missing id succeeded")
+	}
+
+	last, err := c.GetLastSubmissionTime("contact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last == nil || !last.Equal(day) {
+		t.Errorf("GetLastSubmissionTime(contact) = %v, want %v", last, day)
+	}
+}
+
+func TestLabeledFields(t *testing.T) {
+	s := Submission{Fields: map[string]string{"email": "ana@example.com", "name": "Ana", "utm": "ads", "old": "x"}}
+	fields := []Field{
+		{ElementorID: "email", Label: "E-mail", Position: 2},
+		{ElementorID: "name", Label: "Name", Position: 1},
+		{ElementorID: "phone", Label: "Phone", Position: 3},
+	}
+
+	var got []string
+	for _, field := range s.LabeledFields(fields) {
+		got = append(got, field.Label+"="+field.Value)
+	}
+	want := []string{"Name=Ana", "E-mail=ana@example.com", "old=x", "utm=ads"}
+	if len(got) != len(want) {
+		t.Fatalf("LabeledFields = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("LabeledFields = %v, want %v", got, want)
+		}
+	}
+}
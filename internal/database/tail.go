@@ -0,0 +1,96 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TailFilter narrows the rows a live tail follows; zero values match
+// everything
+type TailFilter struct {
+	FormID string
+	// Statuses match a delivery's status or a submission's delivery status
+	Statuses []string
+}
+
+// GetDeliveriesAfter retrieves deliveries with an ID above afterID, oldest
+// first, with their per-recipient outcomes
+func (c *Client) GetDeliveriesAfter(afterID int, filter TailFilter, limit int) ([]Delivery, error) {
+	where, params := filter.where("status", afterID)
+	query := "SELECT * FROM webhook_logs" + where + " ORDER BY id ASC" + fmt.Sprintf(" LIMIT %d", limit)
+
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deliveries: %w", err)
+	}
+
+	var deliveries []Delivery
+	for _, row := range result.Results {
+		deliveries = append(deliveries, deliveryFromRow(row))
+	}
+	if err := c.loadDeliveryRecipients(deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetSubmissionsAfter retrieves submissions with an ID above afterID,
+// oldest first
+func (c *Client) GetSubmissionsAfter(afterID int, filter TailFilter, limit int) ([]Submission, error) {
+	where, params := filter.where("delivery_status", afterID)
+	query := "SELECT * FROM submissions" + where + " ORDER BY id ASC" + fmt.Sprintf(" LIMIT %d", limit)
+
+	result, err := c.Query(query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+
+	var submissions []Submission
+	for _, row := range result.Results {
+		submissions = append(submissions, submissionFromRow(row))
+	}
+	return submissions, nil
+}
+
+// GetTailStart returns the submission and delivery IDs to follow from so
+// the tail begins with the last n matching rows of each
+func (c *Client) GetTailStart(filter TailFilter, n int) (submissionID, deliveryID int, err error) {
+	for _, table := range []struct {
+		name, statusColumn string
+		id                 *int
+	}{
+		{"submissions", "delivery_status", &submissionID},
+		{"webhook_logs", "status", &deliveryID},
+	} {
+		where, params := filter.where(table.statusColumn, 0)
+		query := fmt.Sprintf("SELECT id FROM %s%s ORDER BY id DESC LIMIT 1 OFFSET %d", table.name, where, n)
+		result, err := c.Query(query, params...)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to find where to tail %s from: %w", table.name, err)
+		}
+		if len(result.Results) > 0 {
+			if id, ok := result.Results[0]["id"].(float64); ok {
+				*table.id = int(id)
+			}
+		}
+	}
+	return submissionID, deliveryID, nil
+}
+
+func (f TailFilter) where(statusColumn string, afterID int) (string, []interface{}) {
+	conditions := []string{"id > ?"}
+	params := []interface{}{afterID}
+
+	if f.FormID != "" {
+		conditions = append(conditions, "form_id = ?")
+		params = append(params, f.FormID)
+	}
+	if len(f.Statuses) > 0 {
+		conditions = append(conditions, fmt.Sprintf("%s IN (%s)", statusColumn, placeholders(len(f.Statuses))))
+		for _, status := range f.Statuses {
+			params = append(params, status)
+		}
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), params
+}
@@ -0,0 +1,161 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
+
+// GetFormVersions retrieves every saved version of a form, newest first
+func (c *Client) GetFormVersions(formID string) ([]FormVersion, error) {
+	result, err := c.Query("SELECT * FROM form_versions WHERE form_id = ? ORDER BY version DESC", formID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get form versions: %w", err)
+	}
+
+	var versions []FormVersion
+	for _, row := range result.Results {
+		version, err := formVersionFromRow(row)
+		if err != nil {
+			log.Warn("Skipping unreadable form version", "form", formID, "error", err)
+			continue
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+// GetFormVersion retrieves a single numbered version of a form
+func (c *Client) GetFormVersion(formID string, version int) (*FormVersion, error) {
+	result, err := c.Query("SELECT * FROM form_versions WHERE form_id = ? AND version = ?", formID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get form version: %w", err)
+	}
+	if len(result.Results) == 0 {
+		return nil, fmt.Errorf("form %s has no version %d", formID, version)
+	}
+
+	v, err := formVersionFromRow(result.Results[0])
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// RollbackForm restores a form's name, description, fields and numbers
+// from a saved version. The rollback is itself saved as a new version.
+func (c *Client) RollbackForm(formID string, version int) error {
+	v, err := c.GetFormVersion(formID, version)
+	if err != nil {
+		return err
+	}
+
+	before := c.formAuditState(formID)
+	if before == nil {
+		return fmt.Errorf("form %s not found", formID)
+	}
+
+	form := v.Form
+	form.ID = formID
+	if err := c.updateForm(&form); err != nil {
+		return err
+	}
+
+	c.saveFormVersion(formID, fmt.Sprintf("rollback to v%d", version))
+	c.auditForm("rollback", formID, before)
+	return nil
+}
+
+// saveFormVersion snapshots the form as stored into the next version
+// number. Failures are logged so an older database without the table
+// never blocks the save itself.
+func (c *Client) saveFormVersion(formID, note string) {
+	form, err := c.GetForm(formID)
+	if err != nil {
+		log.Warn("Failed to snapshot form version", "form", formID, "error", err)
+		return
+	}
+
+	snapshot, err := json.Marshal(form)
+	if err != nil {
+		log.Warn("Failed to snapshot form version", "form", formID, "error", err)
+		return
+	}
+
+	query := `
+		INSERT INTO form_versions (form_id, version, snapshot, note, actor)
+		SELECT ?, COALESCE(MAX(version), 0) + 1, ?, ?, ?
+		FROM form_versions WHERE form_id = ?
+	`
+	if _, err := c.Query(query, formID, string(snapshot), note, c.config.AuditActor(), formID); err != nil {
+		log.Warn("Failed to save form version", "form", formID, "error", err)
+	}
+}
+
+// saveBaselineVersion snapshots a form that has no versions yet, so its
+// first tracked edit can be rolled back
+func (c *Client) saveBaselineVersion(formID string) {
+	result, err := c.Query("SELECT COUNT(*) as count FROM form_versions WHERE form_id = ?", formID)
+	if err != nil {
+		log.Warn("Failed to check form versions", "form", formID, "error", err)
+		return
+	}
+	if len(result.Results) > 0 {
+		if count, ok := result.Results[0]["count"].(float64); ok && count > 0 {
+			return
+		}
+	}
+	c.saveFormVersion(formID, "before first tracked edit")
+}
+
+// Lines renders the version as text for diffing
+func (v FormVersion) Lines() []string {
+	form := v.Form
+	lines := []string{
+		"Name: " + form.Name,
+		"Description: " + strings.ReplaceAll(form.Description, "\n", " "),
+		"",
+		fmt.Sprintf("Fields (%d):", len(form.Fields)),
+	}
+	for _, field := range form.Fields {
+		line := fmt.Sprintf("  %s → %s (%s)", field.ElementorID, field.Label, field.Type)
+		if field.Required {
+			line += " *"
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", fmt.Sprintf("Recipients (%d):", len(form.Numbers)))
+	for _, number := range form.Numbers {
+		line := "  " + number.PhoneNumber
+		if number.Label != "" {
+			line += " (" + number.Label + ")"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func formVersionFromRow(row map[string]interface{}) (FormVersion, error) {
+	v := FormVersion{}
+
+	if id, ok := row["id"].(float64); ok {
+		v.ID = int(id)
+	}
+	v.FormID, _ = row["form_id"].(string)
+	if version, ok := row["version"].(float64); ok {
+		v.Version = int(version)
+	}
+	v.Note, _ = row["note"].(string)
+	v.Actor, _ = row["actor"].(string)
+	v.CreatedAt = parseTime(row["created_at"])
+
+	snapshot, _ := row["snapshot"].(string)
+	if err := json.Unmarshal([]byte(snapshot), &v.Form); err != nil {
+		return v, fmt.Errorf("failed to decode version %d: %w", v.Version, err)
+	}
+
+	return v, nil
+}
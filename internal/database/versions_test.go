@@ -0,0 +1,49 @@
+package database
+
+import "testing"
+
+func TestRollbackFirstEditOfUnversionedForm(t *testing.T) {
+	c := newTestClient(t)
+	createTestForm(t, c, "contact", "5511999990001")
+	// Forms created before versioning have no versions
+	if _, err := c.Query("DELETE FROM form_versions"); err != nil {
+		t.Fatal(err)
+	}
+
+	form, err := c.GetForm("contact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	form.Name = "Renamed"
+	form.Numbers = nil
+	if err := c.UpdateForm(form); err != nil {
+		t.Fatalf("UpdateForm: %v", err)
+	}
+
+	versions, err := c.GetFormVersions("contact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("%d versions, want the baseline and the edit", len(versions))
+	}
+	if err := c.RollbackForm("contact", 1); err != nil {
+		t.Fatalf("RollbackForm: %v", err)
+	}
+
+	form, err = c.GetForm("contact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form.Name != "contact" || len(form.Numbers) != 1 || len(form.Fields) != len(DefaultFields()) {
+		t.Errorf("rolled back to %q with %d numbers and %d fields", form.Name, len(form.Numbers), len(form.Fields))
+	}
+
+	// Later edits don't add another baseline
+	if err := c.UpdateForm(form); err != nil {
+		t.Fatal(err)
+	}
+	if versions, _ := c.GetFormVersions("contact"); len(versions) != 4 {
+		t.Errorf("%d versions after rollback and edit, want 4", len(versions))
+	}
+}
@@ -0,0 +1,86 @@
+// Package diff compares two texts line by line for side-by-side display
+package diff
+
+// Op is how a line differs between the two texts
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+	Change
+)
+
+// Line is one row of a side-by-side diff. Left is empty for inserts and
+// Right is empty for deletes.
+type Line struct {
+	Op    Op
+	Left  string
+	Right string
+}
+
+// Lines diffs a against b using the longest common subsequence. Runs of
+// deletions directly followed by insertions are paired up as changes.
+func Lines(a, b []string) []Line {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []Line
+	var deleted, inserted []string
+	flush := func() {
+		n := max(len(deleted), len(inserted))
+		for k := 0; k < n; k++ {
+			switch {
+			case k < len(deleted) && k < len(inserted):
+				lines = append(lines, Line{Op: Change, Left: deleted[k], Right: inserted[k]})
+			case k < len(deleted):
+				lines = append(lines, Line{Op: Delete, Left: deleted[k]})
+			default:
+				lines = append(lines, Line{Op: Insert, Right: inserted[k]})
+			}
+		}
+		deleted, inserted = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			lines = append(lines, Line{Op: Equal, Left: a[i], Right: b[j]})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			inserted = append(inserted, b[j])
+			j++
+		default:
+			deleted = append(deleted, a[i])
+			i++
+		}
+	}
+	flush()
+
+	return lines
+}
+
+// Changed reports whether any line differs
+func Changed(lines []Line) bool {
+	for _, line := range lines {
+		if line.Op != Equal {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want []Line
+	}{
+		{
+			name: "both empty",
+		},
+		{
+			name: "equal",
+			a:    []string{"name: Contact", "numbers: 2"},
+			b:    []string{"name: Contact", "numbers: 2"},
+			want: []Line{
+				{Op: Equal, Left: "name: Contact", Right: "name: Contact"},
+				{Op: Equal, Left: "numbers: 2", Right: "numbers: 2"},
+			},
+		},
+		{
+			name: "all inserted",
+			b:    []string{"a", "b"},
+			want: []Line{{Op: Insert, Right: "a"}, {Op: Insert, Right: "b"}},
+		},
+		{
+			name: "all deleted",
+			a:    []string{"a", "b"},
+			want: []Line{{Op: Delete, Left: "a"}, {Op: Delete, Left: "b"}},
+		},
+		{
+			name: "insert in the middle",
+			a:    []string{"a", "c"},
+			b:    []string{"a", "b", "c"},
+			want: []Line{
+				{Op: Equal, Left: "a", Right: "a"},
+				{Op: Insert, Right: "b"},
+				{Op: Equal, Left: "c", Right: "c"},
+			},
+		},
+		{
+			name: "delete at the end",
+			a:    []string{"a", "b", "c"},
+			b:    []string{"a", "b"},
+			want: []Line{
+				{Op: Equal, Left: "a", Right: "a"},
+				{Op: Equal, Left: "b", Right: "b"},
+				{Op: Delete, Left: "c"},
+			},
+		},
+		{
+			name: "replaced line is a change",
+			a:    []string{"a", "old", "c"},
+			b:    []string{"a", "new", "c"},
+			want: []Line{
+				{Op: Equal, Left: "a", Right: "a"},
+				{Op: Change, Left: "old", Right: "new"},
+				{Op: Equal, Left: "c", Right: "c"},
+			},
+		},
+		{
+			name: "unpaired deletions follow the changes",
+			a:    []string{"a", "x", "y", "z"},
+			b:    []string{"a", "w"},
+			want: []Line{
+				{Op: Equal, Left: "a", Right: "a"},
+				{Op: Change, Left: "x", Right: "w"},
+				{Op: Delete, Left: "y"},
+				{Op: Delete, Left: "z"},
+			},
+		},
+		{
+			name: "unpaired insertions follow the changes",
+			a:    []string{"x", "end"},
+			b:    []string{"v", "w", "end"},
+			want: []Line{
+				{Op: Change, Left: "x", Right: "v"},
+				{Op: Insert, Right: "w"},
+				{Op: Equal, Left: "end", Right: "end"},
+			},
+		},
+		{
+			name: "keeps the longest common subsequence",
+			a:    []string{"a", "b", "c", "d"},
+			b:    []string{"b", "c", "d", "a"},
+			want: []Line{
+				{Op: Delete, Left: "a"},
+				{Op: Equal, Left: "b", Right: "b"},
+				{Op: Equal, Left: "c", Right: "c"},
+				{Op: Equal, Left: "d", Right: "d"},
+				{Op: Insert, Right: "a"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Lines(tt.a, tt.b)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChanged(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want bool
+	}{
+		{"both empty", nil, nil, false},
+		{"equal", []string{"a", "b"}, []string{"a", "b"}, false},
+		{"inserted", []string{"a"}, []string{"a", "b"}, true},
+		{"changed", []string{"a"}, []string{"b"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Changed(Lines(tt.a, tt.b)); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package doctor
+
+import (
+	"fmt"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+// Issue is a single problem reported by a check
+type Issue struct {
+	Subject string
+	Detail  string
+}
+
+// Check is a named integrity check with an automatic fix
+type Check struct {
+	Name        string
+	Description string
+	// FixDescription tells the user what Fix will do before they run it
+	FixDescription string
+	// Confirm is set for fixes with effects outside the database. It says
+	// what the user has to agree to; such fixes never run unasked.
+	Confirm string
+	Run     func(db *database.Client) ([]Issue, error)
+	Fix     func(db *database.Client) (int, error)
+}
+
+// Result is the outcome of running a single check
+type Result struct {
+	Check  Check
+	Issues []Issue
+	Err    error
+}
+
+// OK reports whether the check ran and found nothing
+func (r Result) OK() bool {
+	return r.Err == nil && len(r.Issues) == 0
+}
+
+// Checks returns the full suite in the order it should run. Schema
+// drift comes first because the other checks query the columns it
+// verifies.
+func Checks() []Check {
+	return []Check{
+		{
+			Name:           "schema-drift",
+			Description:    "Tables and columns ewctl expects exist in D1",
+			FixDescription: "create missing tables and add missing columns",
+			Run:            checkSchemaDrift,
+			Fix: func(db *database.Client) (int, error) {
+				return db.MigrateSchema()
+			},
+		},
+		{
+			Name:           "orphan-links",
+			Description:    "Every recipient belongs to an existing form and contact",
+			FixDescription: "drop rows of deleted forms and link recipients to a contact with the same phone, creating it if needed",
+			Run:            checkOrphanLinks,
+			Fix: func(db *database.Client) (int, error) {
+				return db.RepairOrphanLinks()
+			},
+		},
+		{
+			Name:           "duplicate-phones",
+			Description:    "No two contacts or recipients share a normalized phone",
+			FixDescription: "merge duplicate contacts into the oldest one and remove repeated recipients",
+			Run:            checkDuplicatePhones,
+			Fix: func(db *database.Client) (int, error) {
+				return db.MergeDuplicateContacts()
+			},
+		},
+		{
+			Name:           "forms-without-fields",
+			Description:    "Every form maps at least one Elementor field",
+			FixDescription: "add the default name/email/phone/message fields",
+			Run:            checkFormsWithoutFields,
+			Fix:            fixFormsWithoutFields,
+		},
+		{
+			Name:           "forms-without-recipients",
+			Description:    "Every form has at least one WhatsApp recipient",
+			FixDescription: "archive forms that have no recipients (restore them once recipients are added)",
+			Run:            checkFormsWithoutRecipients,
+			Fix:            fixFormsWithoutRecipients,
+		},
+		{
+			Name:           "unreachable-forms",
+			Description:    "Every form ID can be routed by the worker's /webhook/{id}",
+			FixDescription: "rename the form to the suggested URL-safe ID",
+			Confirm:        "Renaming changes the webhook URL of each form: update it in Elementor afterwards, or the site keeps posting to the old one",
+			Run:            checkUnreachableForms,
+			Fix:            fixUnreachableForms,
+		},
+	}
+}
+
+// Find returns the check with the given name
+func Find(name string) (Check, bool) {
+	for _, check := range Checks() {
+		if check.Name == name {
+			return check, true
+		}
+	}
+	return Check{}, false
+}
+
+// Run executes the named checks, or all of them when no names are given
+func Run(db *database.Client, names ...string) []Result {
+	checks := Checks()
+	if len(names) > 0 {
+		checks = nil
+		for _, name := range names {
+			check, ok := Find(name)
+			if !ok {
+				continue
+			}
+			checks = append(checks, check)
+		}
+	}
+
+	var results []Result
+	for _, check := range checks {
+		results = append(results, RunCheck(db, check))
+	}
+	return results
+}
+
+// RunCheck executes a single check
+func RunCheck(db *database.Client, check Check) Result {
+	issues, err := check.Run(db)
+	return Result{Check: check, Issues: issues, Err: err}
+}
+
+func checkSchemaDrift(db *database.Client) ([]Issue, error) {
+	drift, err := db.GetSchemaDrift()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	for _, d := range drift {
+		subject := d.Table
+		if d.Column != "" {
+			subject = d.Table + "." + d.Column
+		}
+		issues = append(issues, Issue{Subject: subject, Detail: d.String()})
+	}
+	return issues, nil
+}
+
+func checkOrphanLinks(db *database.Client) ([]Issue, error) {
+	var issues []Issue
+
+	dangling, err := db.CountDanglingFormRows()
+	if err != nil {
+		return nil, err
+	}
+	if dangling > 0 {
+		issues = append(issues, Issue{
+			Subject: "deleted forms",
+			Detail:  fmt.Sprintf("%d fields or recipients belong to forms that no longer exist", dangling),
+		})
+	}
+
+	orphans, err := db.GetOrphanNumbers()
+	if err != nil {
+		return nil, err
+	}
+	for _, number := range orphans {
+		detail := "recipient has no contact"
+		if number.ContactID != nil {
+			detail = fmt.Sprintf("recipient points at missing contact #%d", *number.ContactID)
+		}
+		issues = append(issues, Issue{
+			Subject: fmt.Sprintf("%s → %s", number.FormID, number.PhoneNumber),
+			Detail:  detail,
+		})
+	}
+
+	return issues, nil
+}
+
+func checkDuplicatePhones(db *database.Client) ([]Issue, error) {
+	var issues []Issue
+
+	groups, err := db.GetDuplicateContacts()
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		names := ""
+		for i, contact := range group {
+			if i > 0 {
+				names += ", "
+			}
+			names += fmt.Sprintf("#%d %s (%s)", contact.ID, contact.Name, contact.PhoneNumber)
+		}
+		issues = append(issues, Issue{
+			Subject: database.NormalizePhone(group[0].PhoneNumber),
+			Detail:  fmt.Sprintf("%d contacts share this phone: %s", len(group), names),
+		})
+	}
+
+	numbers, err := db.GetDuplicateNumbers()
+	if err != nil {
+		return nil, err
+	}
+	for _, number := range numbers {
+		issues = append(issues, Issue{
+			Subject: fmt.Sprintf("%s → %s", number.FormID, number.PhoneNumber),
+			Detail:  "phone is listed more than once on this form",
+		})
+	}
+
+	return issues, nil
+}
+
+func formsWithout(db *database.Client, match func(database.FormWithStats) bool) ([]database.FormWithStats, error) {
+	forms, err := db.GetAllForms()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []database.FormWithStats
+	for _, form := range forms {
+		if match(form) {
+			matched = append(matched, form)
+		}
+	}
+	return matched, nil
+}
+
+func noFields(form database.FormWithStats) bool { return form.FieldCount == 0 }
+
+func noRecipients(form database.FormWithStats) bool { return form.NumberCount == 0 }
+
+func checkFormsWithoutFields(db *database.Client) ([]Issue, error) {
+	forms, err := formsWithout(db, noFields)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	for _, form := range forms {
+		issues = append(issues, Issue{
+			Subject: form.ID,
+			Detail:  fmt.Sprintf("%q has no fields; every submission is rejected", form.Name),
+		})
+	}
+	return issues, nil
+}
+
+func fixFormsWithoutFields(db *database.Client) (int, error) {
+	forms, err := formsWithout(db, noFields)
+	if err != nil {
+		return 0, err
+	}
+
+	fixed := 0
+	for _, form := range forms {
+		if err := db.AddDefaultFields(form.ID); err != nil {
+			return fixed, err
+		}
+		fixed++
+	}
+	return fixed, nil
+}
+
+func checkFormsWithoutRecipients(db *database.Client) ([]Issue, error) {
+	forms, err := formsWithout(db, noRecipients)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	for _, form := range forms {
+		issues = append(issues, Issue{
+			Subject: form.ID,
+			Detail:  fmt.Sprintf("%q has no recipients; submissions are never delivered", form.Name),
+		})
+	}
+	return issues, nil
+}
+
+func fixFormsWithoutRecipients(db *database.Client) (int, error) {
+	forms, err := formsWithout(db, noRecipients)
+	if err != nil {
+		return 0, err
+	}
+
+	fixed := 0
+	for _, form := range forms {
+		if err := db.ArchiveForm(form.ID); err != nil {
+			return fixed, err
+		}
+		fixed++
+	}
+	return fixed, nil
+}
+
+func checkUnreachableForms(db *database.Client) ([]Issue, error) {
+	forms, err := db.GetAllForms()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	for _, form := range forms {
+		if database.IsWorkerReachableID(form.ID) {
+			continue
+		}
+		issues = append(issues, Issue{
+			Subject: form.ID,
+			Detail:  fmt.Sprintf("the worker cannot route /webhook/%s; suggested ID %q", form.ID, database.SuggestFormID(form.ID)),
+		})
+	}
+	return issues, nil
+}
+
+func fixUnreachableForms(db *database.Client) (int, error) {
+	forms, err := db.GetAllForms()
+	if err != nil {
+		return 0, err
+	}
+
+	fixed := 0
+	for _, form := range forms {
+		if database.IsWorkerReachableID(form.ID) {
+			continue
+		}
+		if err := db.RenameForm(form.ID, database.SuggestFormID(form.ID)); err != nil {
+			return fixed, err
+		}
+		fixed++
+	}
+	return fixed, nil
+}
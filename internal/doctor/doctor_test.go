@@ -0,0 +1,63 @@
+package doctor
+
+import (
+	"testing"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database/d1test"
+)
+
+func newTestClient(t *testing.T) *database.Client {
+	t.Helper()
+	db, err := database.NewClient(d1test.New(t))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if err := db.InitSchema(); err != nil {
+		t.Fatalf("InitSchema: %v", err)
+	}
+	return db
+}
+
+func TestFixesClearTheirIssues(t *testing.T) {
+	db := newTestClient(t)
+	forms := []*database.Form{
+		{ID: "Contact Form", Name: "unreachable", Fields: database.DefaultFields(), Numbers: []database.Number{{PhoneNumber: "5511999990001"}}},
+		{ID: "no-fields", Name: "no fields", Numbers: []database.Number{{PhoneNumber: "5511999990002"}}},
+	}
+	for _, form := range forms {
+		if err := db.CreateForm(form); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, result := range Run(db) {
+		if result.Err != nil {
+			t.Fatalf("%s: %v", result.Check.Name, result.Err)
+		}
+		if result.OK() {
+			continue
+		}
+		if _, err := result.Check.Fix(db); err != nil {
+			t.Fatalf("%s fix: %v", result.Check.Name, err)
+		}
+		if after := RunCheck(db, result.Check); !after.OK() {
+			t.Errorf("%s still reports %v after its fix", result.Check.Name, after.Issues)
+		}
+	}
+
+	if form, _ := db.GetForm(database.SuggestFormID("Contact Form")); form == nil {
+		t.Error("unreachable form was not renamed to the suggested ID")
+	}
+}
+
+func TestOnlyRenamesNeedConfirmation(t *testing.T) {
+	for _, check := range Checks() {
+		if check.Fix == nil {
+			t.Errorf("%s has no fix", check.Name)
+		}
+		if (check.Confirm != "") != (check.Name == "unreachable-forms") {
+			t.Errorf("%s: Confirm = %q", check.Name, check.Confirm)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/csvsafe"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+type csvWriter struct {
+	w      *csv.Writer
+	layout *Layout
+}
+
+func newCSVWriter(w io.Writer, layout *Layout) (*csvWriter, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvsafe.EscapeCells(layout.Header())); err != nil {
+		return nil, fmt.Errorf("failed to write csv: %w", err)
+	}
+	return &csvWriter{w: cw, layout: layout}, nil
+}
+
+func (c *csvWriter) Write(s database.Submission) error {
+	if err := c.w.Write(csvsafe.EscapeCells(c.layout.Row(s))); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
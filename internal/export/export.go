@@ -0,0 +1,178 @@
+// Package export writes stored submissions as CSV, JSON lines or XLSX
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+// Formats lists the supported export formats
+var Formats = []string{"csv", "jsonl", "xlsx"}
+
+// batchSize is how many submissions Stream fetches per query
+const batchSize = 500
+
+// baseColumns come before the form's field columns in every row
+var baseColumns = []string{"id", "created_at", "form_id", "delivery_status", "lead_status", "country", "city"}
+
+// otherColumn holds submitted values the form has no field for, as JSON
+const otherColumn = "other_fields"
+
+// Writer writes submissions one at a time; Close finishes the file
+type Writer interface {
+	Write(s database.Submission) error
+	Close() error
+}
+
+// Layout maps submitted values to columns. Field columns follow each form's
+// configured labels in Field.Position order.
+type Layout struct {
+	fields  map[string][]database.Field
+	labels  []string
+	columns map[string]map[string]int
+}
+
+// NewLayout builds the columns for one form, or for every form in fields
+// when formID is empty
+func NewLayout(fields map[string][]database.Field, formID string) *Layout {
+	l := &Layout{fields: fields, columns: make(map[string]map[string]int)}
+
+	formIDs := []string{formID}
+	if formID == "" {
+		formIDs = formIDs[:0]
+		for id := range fields {
+			formIDs = append(formIDs, id)
+		}
+		sort.Strings(formIDs)
+	}
+
+	index := make(map[string]int)
+	for _, id := range formIDs {
+		ordered := make([]database.Field, len(fields[id]))
+		copy(ordered, fields[id])
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return ordered[i].Position < ordered[j].Position
+		})
+
+		l.columns[id] = make(map[string]int, len(ordered))
+		for _, field := range ordered {
+			label := field.Label
+			if label == "" {
+				label = field.ElementorID
+			}
+			if _, ok := index[label]; !ok {
+				index[label] = len(l.labels)
+				l.labels = append(l.labels, label)
+			}
+			l.columns[id][field.ElementorID] = index[label]
+		}
+	}
+	return l
+}
+
+// Header returns the column names of a row
+func (l *Layout) Header() []string {
+	header := append([]string{}, baseColumns...)
+	header = append(header, l.labels...)
+	return append(header, otherColumn)
+}
+
+// Row returns a submission's values in Header order
+func (l *Layout) Row(s database.Submission) []string {
+	row := []string{
+		strconv.Itoa(s.ID),
+		s.CreatedAt.Format(time.RFC3339),
+		s.FormID,
+		s.DeliveryStatus,
+		s.LeadStatus,
+		s.Country,
+		s.City,
+	}
+
+	values := make([]string, len(l.labels))
+	other := make(map[string]string)
+	for key, value := range s.Fields {
+		if i, ok := l.columns[s.FormID][key]; ok {
+			values[i] = value
+		} else {
+			other[key] = value
+		}
+	}
+	row = append(row, values...)
+
+	extra := ""
+	if len(other) > 0 {
+		data, _ := json.Marshal(other)
+		extra = string(data)
+	}
+	return append(row, extra)
+}
+
+// NewWriter returns a writer for the format
+func NewWriter(format string, w io.Writer, layout *Layout) (Writer, error) {
+	switch format {
+	case "csv":
+		return newCSVWriter(w, layout)
+	case "jsonl":
+		return newJSONLWriter(w, layout), nil
+	case "xlsx":
+		return newXLSXWriter(w, layout)
+	default:
+		return nil, fmt.Errorf("unknown format %q (use %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// FormatFromPath guesses the format from a file extension
+func FormatFromPath(path string) string {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	for _, format := range Formats {
+		if ext == format {
+			return format
+		}
+	}
+	return ""
+}
+
+// Stream writes every submission matching the filter, fetching them in
+// batches so large ranges never sit in memory. Submissions received after
+// the export starts are left out so paging stays stable.
+func Stream(db *database.Client, filter database.SubmissionFilter, w Writer) (int, error) {
+	if now := time.Now(); filter.Until.IsZero() || filter.Until.After(now) {
+		filter.Until = now
+	}
+	limit := filter.Limit
+	filter.Offset = 0
+
+	written := 0
+	for limit <= 0 || written < limit {
+		filter.Limit = batchSize
+		if limit > 0 {
+			filter.Limit = min(batchSize, limit-written)
+		}
+
+		batch, err := db.GetSubmissions(filter)
+		if err != nil {
+			return written, err
+		}
+		for _, s := range batch {
+			if err := w.Write(s); err != nil {
+				return written, err
+			}
+			written++
+		}
+		if len(batch) < filter.Limit {
+			break
+		}
+		filter.Offset += len(batch)
+	}
+
+	return written, w.Close()
+}
@@ -0,0 +1,160 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database/d1test"
+)
+
+var testFields = map[string][]database.Field{
+	"contact": {
+		{ElementorID: "email", Label: "E-mail", Position: 2},
+		{ElementorID: "name", Label: "Name", Position: 1},
+	},
+	"quote": {
+		{ElementorID: "full_name", Label: "Name", Position: 1},
+		{ElementorID: "budget", Position: 2},
+	},
+}
+
+func TestLayoutSharesLabelsAcrossForms(t *testing.T) {
+	layout := NewLayout(testFields, "")
+
+	header := layout.Header()
+	want := append(append([]string{}, baseColumns...), "Name", "E-mail", "budget", otherColumn)
+	if strings.Join(header, ",") != strings.Join(want, ",") {
+		t.Fatalf("Header = %v, want %v", header, want)
+	}
+
+	row := layout.Row(database.Submission{
+		ID:        7,
+		FormID:    "quote",
+		CreatedAt: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+		Fields:    map[string]string{"full_name": "Ana", "budget": "100", "utm": "ads"},
+	})
+	fields := row[len(baseColumns):]
+	if row[0] != "7" || row[1] != "2026-03-10T12:00:00Z" || fields[0] != "Ana" || fields[1] != "" || fields[2] != "100" {
+		t.Errorf("Row = %v", row)
+	}
+	if fields[3] != `{"utm":"ads"}` {
+		t.Errorf("other fields = %q, want the unknown field as JSON", fields[3])
+	}
+}
+
+func TestStreamWritesEveryFormat(t *testing.T) {
+	db, err := database.NewClient(d1test.New(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.InitSchema(); err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	for i, fields := range []string{`{"name":"=cmd()","email":"a@example.com"}`, `{"name":"Bruno"}`, `{"name":"Caio"}`} {
+		query := "INSERT INTO submissions (form_id, fields, payload, created_at) VALUES ('contact', ?, 'raw', ?)"
+		if _, err := db.Query(query, fields, day.Add(time.Duration(i)*time.Hour).Format("2006-01-02 15:04:05")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	layout := NewLayout(testFields, "contact")
+
+	export := func(format string, filter database.SubmissionFilter, want int) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		w, err := NewWriter(format, &buf, layout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err := Stream(db, filter, w)
+		if err != nil {
+			t.Fatalf("Stream(%s): %v", format, err)
+		}
+		if n != want {
+			t.Errorf("Stream(%s) wrote %d submissions, want %d", format, n, want)
+		}
+		return buf.Bytes()
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		records, err := csv.NewReader(bytes.NewReader(export("csv", database.SubmissionFilter{}, 3))).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 4 {
+			t.Fatalf("%d csv records, want header and 3 rows", len(records))
+		}
+		name := len(baseColumns)
+		if records[1][name] != "Caio" || records[3][name] != "'=cmd()" {
+			t.Errorf("names = %q, %q; want newest first and formulas escaped", records[1][name], records[3][name])
+		}
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(string(export("jsonl", database.SubmissionFilter{Limit: 2}, 2))), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("%d jsonl lines, want the limit of 2", len(lines))
+		}
+		var record struct {
+			Payload string                     `json:"payload"`
+			Labeled []database.SubmissionField `json:"labeled_fields"`
+		}
+		if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+			t.Fatal(err)
+		}
+		if record.Payload != "" || len(record.Labeled) != 1 || record.Labeled[0].Label != "Name" {
+			t.Errorf("record = %+v, want labeled fields without the payload", record)
+		}
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		data := export("xlsx", database.SubmissionFilter{}, 3)
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sheet []byte
+		for _, f := range zr.File {
+			if f.Name == "xl/worksheets/sheet1.xml" {
+				r, err := f.Open()
+				if err != nil {
+					t.Fatal(err)
+				}
+				sheet, _ = io.ReadAll(r)
+				r.Close()
+			}
+		}
+		if n := strings.Count(string(sheet), "<row "); n != 4 {
+			t.Errorf("%d sheet rows, want header and 3 rows", n)
+		}
+		if !strings.Contains(string(sheet), `<c r="A2"><v>3</v></c>`) {
+			t.Error("ID not written as a number")
+		}
+	})
+}
+
+func TestFormatFromPath(t *testing.T) {
+	for path, want := range map[string]string{"leads.CSV": "csv", "out.jsonl": "jsonl", "a.b.xlsx": "xlsx", "notes.txt": "", "csv": ""} {
+		if got := FormatFromPath(path); got != want {
+			t.Errorf("FormatFromPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+	if _, err := NewWriter("pdf", io.Discard, NewLayout(nil, "")); err == nil {
+		t.Error("NewWriter accepted an unknown format")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(i); got != want {
+			t.Errorf("columnName(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+type jsonlWriter struct {
+	enc    *json.Encoder
+	layout *Layout
+}
+
+func newJSONLWriter(w io.Writer, layout *Layout) *jsonlWriter {
+	return &jsonlWriter{enc: json.NewEncoder(w), layout: layout}
+}
+
+// Write encodes the submission without its raw payload, with its values
+// under their configured labels
+func (j *jsonlWriter) Write(s database.Submission) error {
+	record := struct {
+		database.Submission
+		Labeled []database.SubmissionField `json:"labeled_fields"`
+	}{s, s.LabeledFields(j.layout.fields[s.FormID])}
+	record.Payload = ""
+
+	if err := j.enc.Encode(record); err != nil {
+		return fmt.Errorf("failed to write jsonl: %w", err)
+	}
+	return nil
+}
+
+func (j *jsonlWriter) Close() error {
+	return nil
+}
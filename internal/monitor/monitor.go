@@ -0,0 +1,199 @@
+// Package monitor turns the Z-API connection flips the worker records into
+// periods, outages, uptime and a timeline
+package monitor
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+// Period is a span during which the connection stayed up or down
+type Period struct {
+	Connected bool      `json:"connected"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	// Ongoing is set on the last period, which ends now
+	Ongoing bool   `json:"ongoing"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Duration returns how long the period lasted
+func (p Period) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// Report summarizes the connection over a window
+type Report struct {
+	State   *database.MonitoringState `json:"state"`
+	Since   time.Time                 `json:"since"`
+	Until   time.Time                 `json:"until"`
+	Periods []Period                  `json:"periods"`
+	// Outages are the down periods overlapping the window, newest first
+	Outages []Period `json:"outages"`
+	// Uptime is the connected fraction of the known part of the window, or
+	// -1 when nothing was recorded in it
+	Uptime   float64       `json:"uptime"`
+	Downtime time.Duration `json:"downtime"`
+}
+
+// Periods turns state flips into consecutive periods, oldest first; the
+// last one runs until now. Flips to the same state are merged.
+func Periods(history []database.MonitoringEvent, now time.Time) []Period {
+	events := append([]database.MonitoringEvent(nil), history...)
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		}
+		return events[i].ID < events[j].ID
+	})
+
+	var periods []Period
+	for _, event := range events {
+		if n := len(periods); n > 0 {
+			if periods[n-1].Connected == event.Connected {
+				continue
+			}
+			periods[n-1].End = event.CreatedAt
+		}
+		periods = append(periods, Period{
+			Connected: event.Connected,
+			Start:     event.CreatedAt,
+			Error:     database.StatusError(event.Status),
+		})
+	}
+	if n := len(periods); n > 0 {
+		periods[n-1].End = now
+		periods[n-1].Ongoing = true
+	}
+	return periods
+}
+
+// Compute reads a monitor's state and history and summarizes the window
+// from since to now
+func Compute(db *database.Client, key string, since time.Time) (*Report, error) {
+	state, err := db.GetMonitoringState(key)
+	if err != nil {
+		return nil, err
+	}
+	history, err := db.GetMonitoringHistory(key, 0)
+	if err != nil {
+		return nil, err
+	}
+	return Summarize(state, history, since, time.Now()), nil
+}
+
+// Summarize computes a report from already loaded rows
+func Summarize(state *database.MonitoringState, history []database.MonitoringEvent, since, now time.Time) *Report {
+	report := &Report{
+		State:   state,
+		Since:   since,
+		Until:   now,
+		Periods: Periods(history, now),
+		Uptime:  -1,
+	}
+	// The history may have been trimmed away while the state remains
+	if len(report.Periods) == 0 && state != nil {
+		report.Periods = []Period{{
+			Connected: state.Connected,
+			Start:     state.LastChanged,
+			End:       now,
+			Ongoing:   true,
+			Error:     database.StatusError(state.Status),
+		}}
+	}
+
+	var known, up time.Duration
+	for _, period := range report.Periods {
+		span := overlap(period, since, now)
+		if span <= 0 {
+			continue
+		}
+		known += span
+		if period.Connected {
+			up += span
+		} else {
+			report.Downtime += span
+			report.Outages = append(report.Outages, period)
+		}
+	}
+	if known > 0 {
+		report.Uptime = float64(up) / float64(known)
+	}
+
+	// Newest outage first
+	for i, j := 0, len(report.Outages)-1; i < j; i, j = i+1, j-1 {
+		report.Outages[i], report.Outages[j] = report.Outages[j], report.Outages[i]
+	}
+	return report
+}
+
+// Timeline splits the window into cells and returns the connected
+// fraction of each, or -1 for cells before the first recorded flip
+func (r *Report) Timeline(cells int) []float64 {
+	if cells <= 0 || !r.Until.After(r.Since) {
+		return nil
+	}
+
+	width := r.Until.Sub(r.Since) / time.Duration(cells)
+	timeline := make([]float64, cells)
+	for i := range timeline {
+		start := r.Since.Add(time.Duration(i) * width)
+		end := start.Add(width)
+		if i == cells-1 {
+			end = r.Until
+		}
+
+		var known, up time.Duration
+		for _, period := range r.Periods {
+			span := overlap(period, start, end)
+			if span <= 0 {
+				continue
+			}
+			known += span
+			if period.Connected {
+				up += span
+			}
+		}
+		timeline[i] = -1
+		if known > 0 {
+			timeline[i] = float64(up) / float64(known)
+		}
+	}
+	return timeline
+}
+
+// overlap returns how much of the period falls between start and end
+func overlap(p Period, start, end time.Time) time.Duration {
+	from := p.Start
+	if start.After(from) {
+		from = start
+	}
+	to := p.End
+	if end.Before(to) {
+		to = end
+	}
+	return to.Sub(from)
+}
+
+// FormatDuration renders a duration with its two largest units, like
+// "2d 3h" or "4m 10s"
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	seconds := int(d % time.Minute / time.Second)
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm %ds", minutes, seconds)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
@@ -0,0 +1,153 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database/d1test"
+)
+
+var start = time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+func at(hours int) time.Time {
+	return start.Add(time.Duration(hours) * time.Hour)
+}
+
+// history flips down at 2h, repeats the down state at 3h, comes back at 4h
+// and drops again at 8h, listed out of order
+func history() []database.MonitoringEvent {
+	return []database.MonitoringEvent{
+		{ID: 5, Connected: false, CreatedAt: at(8)},
+		{ID: 1, Connected: true, CreatedAt: at(0)},
+		{ID: 2, Connected: false, CreatedAt: at(2), Status: json.RawMessage(`{"error":"offline"}`)},
+		{ID: 3, Connected: false, CreatedAt: at(3)},
+		{ID: 4, Connected: true, CreatedAt: at(4)},
+	}
+}
+
+func TestPeriodsMergesRepeatedStates(t *testing.T) {
+	periods := Periods(history(), at(10))
+
+	var got []string
+	for _, p := range periods {
+		got = append(got, fmt.Sprintf("%t %v-%v %q", p.Connected, p.Start.Sub(start), p.End.Sub(start), p.Error))
+	}
+	want := []string{`true 0s-2h0m0s ""`, `false 2h0m0s-4h0m0s "offline"`, `true 4h0m0s-8h0m0s ""`, `false 8h0m0s-10h0m0s ""`}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("Periods = %v, want %v", got, want)
+	}
+	if !periods[3].Ongoing || periods[2].Ongoing {
+		t.Error("only the last period should be ongoing")
+	}
+}
+
+func TestSummarizeWindow(t *testing.T) {
+	report := Summarize(nil, history(), at(1), at(10))
+
+	if report.Downtime != 4*time.Hour {
+		t.Errorf("Downtime = %v, want 4h", report.Downtime)
+	}
+	if want := 5.0 / 9; report.Uptime != want {
+		t.Errorf("Uptime = %v, want %v", report.Uptime, want)
+	}
+	if len(report.Outages) != 2 || !report.Outages[0].Start.Equal(at(8)) || !report.Outages[1].Start.Equal(at(2)) {
+		t.Errorf("Outages = %+v, want both, newest first", report.Outages)
+	}
+
+	if report := Summarize(nil, nil, at(1), at(10)); report.Uptime != -1 || len(report.Periods) != 0 {
+		t.Errorf("empty report = %+v, want unknown uptime", report)
+	}
+}
+
+func TestSummarizeFallsBackToState(t *testing.T) {
+	state := &database.MonitoringState{Connected: false, LastChanged: at(6), Status: json.RawMessage(`{"error":"logged out"}`)}
+	report := Summarize(state, nil, at(0), at(10))
+
+	if len(report.Periods) != 1 || report.Periods[0].Error != "logged out" || !report.Periods[0].Ongoing {
+		t.Fatalf("Periods = %+v, want one ongoing period from the state", report.Periods)
+	}
+	// Only the part since the last change is known, and it was all down
+	if report.Uptime != 0 || report.Downtime != 4*time.Hour {
+		t.Errorf("Uptime = %v, Downtime = %v; want 0 and 4h", report.Uptime, report.Downtime)
+	}
+}
+
+func TestComputeReadsWorkerRows(t *testing.T) {
+	db, err := database.NewClient(d1test.New(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Compute(db, database.MonitorZAPI, at(0)); !errors.Is(err, database.ErrMonitoringNotStarted) {
+		t.Fatalf("Compute before the worker ran = %v, want ErrMonitoringNotStarted", err)
+	}
+
+	if err := db.InitSchema(); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	rows := []struct {
+		sql    string
+		params []interface{}
+	}{
+		{"INSERT INTO monitoring_history (key, connected, session, status_json, created_at) VALUES (?, 1, 1, '{}', ?)",
+			[]interface{}{database.MonitorZAPI, now.Add(-3 * time.Hour).Format("2006-01-02 15:04:05")}},
+		{"INSERT INTO monitoring_history (key, connected, session, status_json, created_at) VALUES (?, 0, 0, ?, ?)",
+			[]interface{}{database.MonitorZAPI, `{"error":"offline"}`, now.Add(-time.Hour).Format("2006-01-02 15:04:05")}},
+		{"INSERT INTO monitoring_state (key, connected, session, status_json, last_changed) VALUES (?, 0, 0, ?, ?)",
+			[]interface{}{database.MonitorZAPI, `{"error":"offline"}`, now.Add(-time.Hour).Format("2006-01-02 15:04:05")}},
+	}
+	for _, row := range rows {
+		if _, err := db.Query(row.sql, row.params...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := Compute(db, database.MonitorZAPI, now.Add(-4*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.State == nil || report.State.Connected || database.StatusError(report.State.Status) != "offline" {
+		t.Errorf("State = %+v, want disconnected with its error", report.State)
+	}
+	if len(report.Outages) != 1 || report.Outages[0].Error != "offline" || !report.Outages[0].Ongoing {
+		t.Errorf("Outages = %+v, want the ongoing offline period", report.Outages)
+	}
+	if report.Uptime < 0.6 || report.Uptime > 0.7 {
+		t.Errorf("Uptime = %v, want about two of the three recorded hours", report.Uptime)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	report := Summarize(nil, history(), at(-2), at(10))
+
+	got := report.Timeline(6)
+	want := []float64{-1, 1, 0, 1, 1, 0}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Timeline(6) = %v, want %v", got, want)
+	}
+	if got := report.Timeline(4); got[0] != 1 || got[1] != 1.0/3 {
+		t.Errorf("Timeline(4) = %v, want the known part of the first cell up and a third of the second", got)
+	}
+	if report.Timeline(0) != nil {
+		t.Error("Timeline(0) should be empty")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                                   "0s",
+		1500 * time.Millisecond:             "2s",
+		4*time.Minute + 10*time.Second:      "4m 10s",
+		3*time.Hour + 59*time.Second:        "3h 0m",
+		50*time.Hour + 30*time.Minute:       "2d 2h",
+		24*time.Hour - 500*time.Millisecond: "1d 0h",
+	} {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Package probe checks configured credentials against the live services:
+// D1 through the Cloudflare API, the worker's /health endpoint and the
+// Z-API instance status
+package probe
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+// httpClient is used for the worker health check
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// Result is the outcome of one probe
+type Result struct {
+	Name string `json:"name"`
+	OK   bool   `json:"ok"`
+	// Skipped is set when the settings the probe needs are missing
+	Skipped bool   `json:"skipped,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func ok(name, detail string) Result {
+	return Result{Name: name, OK: true, Detail: detail}
+}
+
+func failed(name string, err error) Result {
+	return Result{Name: name, Error: err.Error()}
+}
+
+func skipped(name, detail string) Result {
+	return Result{Name: name, Skipped: true, Detail: detail}
+}
+
+// D1 runs SELECT 1 against the configured database
+func D1(cfg *config.Config) Result {
+	const name = "D1 database"
+	if cfg.Cloudflare.AccountID == "" || cfg.Cloudflare.APIToken == "" || cfg.Cloudflare.DatabaseID == "" {
+		return skipped(name, "cloudflare account_id, api_token and database_id are required")
+	}
+
+	// Only the Cloudflare credentials matter here, so the config is not
+	// validated as a whole
+	db := database.NewUncheckedClient(cfg)
+	if err := db.Ping(); err != nil {
+		return failed(name, err)
+	}
+	return ok(name, "SELECT 1 succeeded")
+}
+
+// Worker calls the worker's /health endpoint. A degraded worker still
+// counts as reachable; the unhealthy checks are reported in the detail.
+func Worker(cfg *config.Config) Result {
+	const name = "Worker"
+	if cfg.Cloudflare.WorkerURL == "" {
+		return skipped(name, "cloudflare.worker_url is required")
+	}
+
+	url := strings.TrimRight(cfg.Cloudflare.WorkerURL, "/") + "/health"
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return failed(name, fmt.Errorf("failed to reach %s: %w", url, err))
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var health struct {
+		Status  string            `json:"status"`
+		Version string            `json:"version"`
+		Checks  map[string]string `json:"checks"`
+	}
+	if err := json.Unmarshal(data, &health); err != nil || health.Status == "" {
+		return failed(name, fmt.Errorf("%s returned %d without a health report; is this the ewctl worker?", url, resp.StatusCode))
+	}
+
+	detail := health.Status
+	if health.Version != "" {
+		detail += ", version " + health.Version
+	}
+	var unhealthy []string
+	for check, status := range health.Checks {
+		if status != "healthy" {
+			unhealthy = append(unhealthy, check)
+		}
+	}
+	if len(unhealthy) > 0 {
+		detail += " (unhealthy: " + strings.Join(unhealthy, ", ") + ")"
+	}
+	return ok(name, detail)
+}
+
+// ZAPI asks the Z-API instance whether it is connected to WhatsApp
+func ZAPI(cfg *config.Config) Result {
+	const name = "Z-API"
+	client := zapi.NewClient(cfg.ZAPI.InstanceID, cfg.ZAPI.InstanceToken, cfg.ZAPI.ClientToken).WithBaseURL(cfg.ZAPI.BaseURL)
+	if !client.Configured() {
+		return skipped(name, "zapi instance_id and instance_token are not set")
+	}
+
+	status, err := client.Status()
+	if err != nil {
+		return failed(name, err)
+	}
+	// The credentials work even when the phone is not paired yet
+	if !status.Connected {
+		detail := "credentials work, but the instance is not connected to WhatsApp; pair it in the Z-API panel"
+		if status.Error != "" {
+			detail += " (" + status.Error + ")"
+		}
+		return ok(name, detail)
+	}
+	return ok(name, "connected to WhatsApp")
+}
+
+// All runs every probe
+func All(cfg *config.Config) []Result {
+	return []Result{D1(cfg), Worker(cfg), ZAPI(cfg)}
+}
@@ -0,0 +1,91 @@
+package probe
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database/d1test"
+)
+
+func TestD1(t *testing.T) {
+	// The worker URL is not needed to reach D1
+	cfg := d1test.New(t)
+	cfg.Cloudflare.WorkerURL = ""
+	if result := D1(cfg); !result.OK {
+		t.Errorf("D1 = %+v, want ok", result)
+	}
+
+	cfg.Cloudflare.APIURL = "http://127.0.0.1:1"
+	if result := D1(cfg); result.OK || result.Error == "" {
+		t.Errorf("D1 against a closed port = %+v, want an error", result)
+	}
+
+	cfg.Cloudflare.APIToken = ""
+	if result := D1(cfg); !result.Skipped {
+		t.Errorf("D1 without a token = %+v, want skipped", result)
+	}
+}
+
+func TestWorker(t *testing.T) {
+	body := `{"status":"degraded","version":"1.4.0","checks":{"d1":"healthy","zapi":"unhealthy"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.Cloudflare.WorkerURL = server.URL + "/"
+	result := Worker(cfg)
+	if !result.OK || result.Detail != "degraded, version 1.4.0 (unhealthy: zapi)" {
+		t.Errorf("Worker = %+v, want reachable with the unhealthy check", result)
+	}
+
+	body = "<html>Not the worker</html>"
+	if result := Worker(cfg); result.OK || !strings.Contains(result.Error, "without a health report") {
+		t.Errorf("Worker on another site = %+v", result)
+	}
+
+	cfg.Cloudflare.WorkerURL = ""
+	if result := Worker(cfg); !result.Skipped {
+		t.Errorf("Worker without a URL = %+v, want skipped", result)
+	}
+}
+
+func TestZAPI(t *testing.T) {
+	status := `{"connected":false,"error":"You are not connected."}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/instances/i/token/t/status") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(status))
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.ZAPI.BaseURL = server.URL
+	if result := ZAPI(cfg); !result.Skipped {
+		t.Errorf("ZAPI without credentials = %+v, want skipped", result)
+	}
+
+	cfg.ZAPI.InstanceID, cfg.ZAPI.InstanceToken = "i", "t"
+	if result := ZAPI(cfg); !result.OK || !strings.Contains(result.Detail, "not connected to WhatsApp; pair it in the Z-API panel (You are not connected.)") {
+		t.Errorf("ZAPI unpaired = %+v, want ok with a pairing hint", result)
+	}
+	status = `{"connected":true}`
+	if result := ZAPI(cfg); !result.OK || result.Detail != "connected to WhatsApp" {
+		t.Errorf("ZAPI paired = %+v", result)
+	}
+
+	cfg.ZAPI.InstanceToken = "wrong"
+	if result := ZAPI(cfg); result.OK {
+		t.Errorf("ZAPI with a wrong token = %+v, want an error", result)
+	}
+}
@@ -0,0 +1,227 @@
+// Package queue drains the durable outbound message queue stored in D1.
+// Each job is one message to one recipient; failed sends are retried with
+// exponential backoff until they succeed or run out of attempts, at which
+// point they are dead-lettered for manual requeueing.
+package queue
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/replay"
+)
+
+// Outcomes of processing a job
+const (
+	OutcomeSent    = "sent"
+	OutcomeSkipped = "skipped"
+	OutcomeRetry   = "retry"
+	OutcomeDead    = "dead"
+)
+
+// defaultLease is longer than a delivery recipient claim, so a job whose
+// runner died mid-send is only picked up once that claim is abandoned too
+const defaultLease = 15 * time.Minute
+
+// Policy decides how often and how long a job is retried
+type Policy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// PolicyFromConfig reads the retry policy from the queue settings
+func PolicyFromConfig(cfg config.QueueConfig) Policy {
+	return Policy{
+		MaxAttempts: cfg.MaxAttempts,
+		BaseDelay:   cfg.BaseDelay,
+		MaxDelay:    cfg.MaxDelay,
+	}
+}
+
+// Backoff returns how long to wait after the given failed attempt:
+// BaseDelay doubled for every earlier attempt, capped at MaxDelay. A zero
+// MaxDelay leaves the delay uncapped.
+func (p Policy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		if delay > math.MaxInt64/2 {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// Result is what happened to one job
+type Result struct {
+	Job         database.OutboundJob
+	Outcome     string
+	Err         error
+	NextAttempt time.Time
+}
+
+// Runner claims due jobs and sends them
+type Runner struct {
+	db     *database.Client
+	sender replay.Sender
+	policy Policy
+
+	// ID identifies the runner holding a job's lease
+	ID           string
+	BatchSize    int
+	Lease        time.Duration
+	PollInterval time.Duration
+	// OnResult is called after every processed job
+	OnResult func(Result)
+}
+
+// NewRunner creates a runner with the configured retry policy
+func NewRunner(db *database.Client, sender replay.Sender, cfg config.QueueConfig) *Runner {
+	hostname, _ := os.Hostname()
+	return &Runner{
+		db:           db,
+		sender:       sender,
+		policy:       PolicyFromConfig(cfg),
+		ID:           fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		BatchSize:    max(cfg.BatchSize, 1),
+		Lease:        defaultLease,
+		PollInterval: cfg.PollInterval,
+	}
+}
+
+// Enqueue adds one job per recipient for the message
+func Enqueue(db *database.Client, formID string, phones []string, message string, policy Policy) error {
+	for _, phone := range phones {
+		job := &database.OutboundJob{
+			FormID:      formID,
+			Phone:       phone,
+			Message:     message,
+			MaxAttempts: policy.MaxAttempts,
+		}
+		if err := db.EnqueueJob(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunOnce claims a batch of due jobs and processes it. It returns how
+// many jobs were processed.
+func (r *Runner) RunOnce() (int, error) {
+	jobs, err := r.db.ClaimJobs(r.ID, r.BatchSize, r.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		result := r.process(job)
+		if r.OnResult != nil {
+			r.OnResult(result)
+		}
+	}
+	return len(jobs), nil
+}
+
+// Drain processes batches until no job is due
+func (r *Runner) Drain(ctx context.Context) error {
+	for ctx.Err() == nil {
+		n, err := r.RunOnce()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Run drains the queue every PollInterval until the context is cancelled.
+// Errors talking to D1 are logged and retried on the next poll.
+func (r *Runner) Run(ctx context.Context) error {
+	interval := r.PollInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Drain(ctx); err != nil {
+			log.Error("Queue drain failed", "runner", r.ID, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) process(job database.OutboundJob) Result {
+	result := Result{Job: job}
+
+	// Jobs retrying a logged delivery share its per-recipient claim, so a
+	// manual `ewctl deliveries retry` and the queue never both send
+	if job.LogID != nil {
+		claimed, err := r.db.ClaimDeliveryRecipient(*job.LogID, job.Phone)
+		if err != nil {
+			return r.fail(result, err)
+		}
+		if !claimed {
+			result.Outcome = OutcomeSkipped
+			result.Err = r.db.CompleteJob(job.ID)
+			return result
+		}
+	}
+
+	_, sendErr := r.sender.SendText(job.Phone, job.Message)
+	if job.LogID != nil {
+		if err := r.db.RecordDeliveryAttempt(*job.LogID, job.Phone, sendErr); err != nil {
+			log.Warn("Failed to record delivery attempt", "job", job.ID, "error", err)
+		}
+	}
+	if sendErr != nil {
+		return r.fail(result, sendErr)
+	}
+
+	result.Outcome = OutcomeSent
+	result.Err = r.db.CompleteJob(job.ID)
+	return result
+}
+
+// fail reschedules the job, or dead-letters it when it is out of attempts
+func (r *Runner) fail(result Result, err error) Result {
+	job := result.Job
+	maxAttempts := job.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = r.policy.MaxAttempts
+	}
+
+	if job.Attempts >= maxAttempts {
+		result.Outcome = OutcomeDead
+		result.Err = err
+		if dlErr := r.db.DeadLetterJob(job.ID, err.Error()); dlErr != nil {
+			result.Err = dlErr
+		}
+		return result
+	}
+
+	result.Outcome = OutcomeRetry
+	result.Err = err
+	result.NextAttempt = time.Now().Add(r.policy.Backoff(job.Attempts))
+	if retryErr := r.db.RetryJob(job.ID, err.Error(), result.NextAttempt); retryErr != nil {
+		result.Err = retryErr
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package queue
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPolicyBackoff(t *testing.T) {
+	capped := Policy{MaxAttempts: 5, BaseDelay: 30 * time.Second, MaxDelay: 5 * time.Minute}
+	uncapped := Policy{MaxAttempts: 5, BaseDelay: time.Second}
+
+	tests := []struct {
+		name    string
+		policy  Policy
+		attempt int
+		want    time.Duration
+	}{
+		{"before the first attempt", capped, 0, 30 * time.Second},
+		{"first attempt", capped, 1, 30 * time.Second},
+		{"second attempt doubles", capped, 2, time.Minute},
+		{"third attempt doubles again", capped, 3, 2 * time.Minute},
+		{"fourth attempt", capped, 4, 4 * time.Minute},
+		{"capped at max delay", capped, 5, 5 * time.Minute},
+		{"stays capped", capped, 50, 5 * time.Minute},
+		{"base above max delay", Policy{BaseDelay: time.Hour, MaxDelay: time.Minute}, 1, time.Minute},
+		{"no base delay", Policy{MaxDelay: time.Minute}, 3, 0},
+		{"zero max delay is uncapped", uncapped, 11, 1024 * time.Second},
+		{"uncapped does not overflow", Policy{BaseDelay: time.Nanosecond}, 1000, 1 << 62},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Backoff(tt.attempt); got != tt.want {
+				t.Errorf("Backoff(%d) = %s, want %s", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
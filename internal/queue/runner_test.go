@@ -0,0 +1,141 @@
+package queue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database/d1test"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+// fakeSender records sends and fails for the phones in fail
+type fakeSender struct {
+	sent []string
+	fail map[string]bool
+}
+
+func (s *fakeSender) SendText(phone, message string) (*zapi.SendResult, error) {
+	if s.fail[phone] {
+		return nil, errors.New("not on WhatsApp")
+	}
+	s.sent = append(s.sent, phone)
+	return &zapi.SendResult{}, nil
+}
+
+func newRunner(t *testing.T, sender *fakeSender, maxAttempts int) (*Runner, *database.Client) {
+	t.Helper()
+	db, err := database.NewClient(d1test.New(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.InitSchema(); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.DefaultConfig().Queue
+	cfg.MaxAttempts = maxAttempts
+	return NewRunner(db, sender, cfg), db
+}
+
+func stats(t *testing.T, db *database.Client) database.QueueStats {
+	t.Helper()
+	s, err := db.GetQueueStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *s
+}
+
+func TestRunnerRetriesThenDeadLetters(t *testing.T) {
+	sender := &fakeSender{fail: map[string]bool{"5511999990002": true}}
+	runner, db := newRunner(t, sender, 2)
+	if err := Enqueue(db, "contact", []string{"5511999990001", "5511999990002"}, "hi", runner.policy); err != nil {
+		t.Fatal(err)
+	}
+
+	var outcomes []string
+	runner.OnResult = func(r Result) { outcomes = append(outcomes, r.Outcome) }
+	if n, err := runner.RunOnce(); err != nil || n != 2 {
+		t.Fatalf("RunOnce = %d, %v", n, err)
+	}
+	if len(sender.sent) != 1 || len(outcomes) != 2 || outcomes[0] != OutcomeSent || outcomes[1] != OutcomeRetry {
+		t.Fatalf("sent %v with outcomes %v", sender.sent, outcomes)
+	}
+
+	// The retry waits out its backoff
+	if n, _ := runner.RunOnce(); n != 0 {
+		t.Errorf("claimed %d jobs before the retry was due", n)
+	}
+	if _, err := db.Query("UPDATE outbound_jobs SET next_attempt_at = datetime('now', '-1 minute') WHERE status = 'pending'"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runner.Drain(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if got := stats(t, db); got.Sent != 1 || got.Dead != 1 {
+		t.Errorf("stats = %+v, want one sent and one dead after two attempts", got)
+	}
+
+	if n, err := db.RequeueDeadJobs(); err != nil || n != 1 {
+		t.Errorf("RequeueDeadJobs = %d, %v", n, err)
+	}
+	if got := stats(t, db); got.Pending != 1 {
+		t.Errorf("stats = %+v, want the dead job pending again", got)
+	}
+}
+
+func TestRunnerReclaimsExpiredLeases(t *testing.T) {
+	sender := &fakeSender{}
+	runner, db := newRunner(t, sender, 5)
+	if err := Enqueue(db, "contact", []string{"5511999990001"}, "hi", runner.policy); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another runner claimed the job and died
+	if jobs, err := db.ClaimJobs("dead-runner", 10, time.Minute); err != nil || len(jobs) != 1 {
+		t.Fatalf("ClaimJobs = %v, %v", jobs, err)
+	}
+	if n, _ := runner.RunOnce(); n != 0 {
+		t.Error("claimed a job under another runner's lease")
+	}
+
+	if _, err := db.Query("UPDATE outbound_jobs SET locked_until = datetime('now', '-1 minute')"); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := runner.RunOnce(); err != nil || n != 1 || len(sender.sent) != 1 {
+		t.Errorf("RunOnce after the lease expired = %d, %v; sent %v", n, err, sender.sent)
+	}
+}
+
+func TestRunnerSkipsRecipientsAlreadyResent(t *testing.T) {
+	sender := &fakeSender{}
+	runner, db := newRunner(t, sender, 5)
+	inserts := []string{
+		"INSERT INTO forms (id, name) VALUES ('contact', 'Contact')",
+		"INSERT INTO webhook_logs (id, form_id, status) VALUES (1, 'contact', 'failed')",
+		"INSERT INTO delivery_recipients (log_id, phone, status) VALUES (1, '5511999990001', 'sent')",
+	}
+	for _, insert := range inserts {
+		if _, err := db.Query(insert); err != nil {
+			t.Fatal(err)
+		}
+	}
+	logID := 1
+	if err := db.EnqueueJob(&database.OutboundJob{FormID: "contact", LogID: &logID, Phone: "5511999990001", Message: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var outcome string
+	runner.OnResult = func(r Result) { outcome = r.Outcome }
+	if _, err := runner.RunOnce(); err != nil {
+		t.Fatal(err)
+	}
+	if outcome != OutcomeSkipped || len(sender.sent) != 0 {
+		t.Errorf("outcome %q, sent %v; want the job skipped", outcome, sender.sent)
+	}
+	if got := stats(t, db); got.Sent != 1 {
+		t.Errorf("stats = %+v, want the skipped job completed", got)
+	}
+}
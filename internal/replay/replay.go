@@ -0,0 +1,105 @@
+// Package replay resends failed WhatsApp deliveries recorded by the worker
+package replay
+
+import (
+	"fmt"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+// Sender delivers a text message to one phone number
+type Sender interface {
+	SendText(phone, message string) (*zapi.SendResult, error)
+}
+
+// Options control which recipients a resend targets
+type Options struct {
+	// CurrentRecipients sends to the form's current numbers instead of the
+	// recipients that failed originally
+	CurrentRecipients bool
+	// DryRun reports the targets without claiming or sending anything
+	DryRun bool
+}
+
+// Outcome is what happened to one recipient of a resend
+type Outcome struct {
+	DeliveryID int
+	Phone      string
+	// Skipped explains why nothing was sent, e.g. already delivered
+	Skipped string
+	Err     error
+	// RecordErr is set when the message went out but storing that failed
+	RecordErr error
+}
+
+// Sent reports whether the message went out during this resend
+func (o Outcome) Sent() bool {
+	return o.Skipped == "" && o.Err == nil
+}
+
+// Targets lists the phones a resend of the delivery would send to
+func Targets(db *database.Client, delivery database.Delivery, opts Options) ([]string, error) {
+	if !opts.CurrentRecipients {
+		var phones []string
+		for _, r := range delivery.Failed() {
+			phones = append(phones, r.Phone)
+		}
+		return phones, nil
+	}
+
+	form, err := db.GetForm(delivery.FormID)
+	if err != nil {
+		return nil, err
+	}
+	var phones []string
+	for _, number := range form.Numbers {
+		phones = append(phones, number.PhoneNumber)
+	}
+	return phones, nil
+}
+
+// Resend sends the delivery's message again. Each recipient is claimed in
+// the database before sending so that concurrent resends, or a resend of a
+// recipient who already got the message, never deliver it twice.
+func Resend(db *database.Client, sender Sender, delivery database.Delivery, opts Options) ([]Outcome, error) {
+	if delivery.Message == "" {
+		return nil, fmt.Errorf("delivery %d has no stored message to resend", delivery.ID)
+	}
+
+	phones, err := Targets(db, delivery, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var outcomes []Outcome
+	for _, phone := range phones {
+		outcome := Outcome{DeliveryID: delivery.ID, Phone: phone}
+
+		switch {
+		case delivery.SentTo(phone):
+			outcome.Skipped = "already delivered"
+		case opts.DryRun:
+			outcome.Skipped = "dry run"
+		default:
+			claimed, err := db.ClaimDeliveryRecipient(delivery.ID, phone)
+			if err != nil {
+				outcome.Err = err
+			} else if !claimed {
+				outcome.Skipped = "already delivered or in progress"
+			} else {
+				// A failure to record a message that went out must not
+				// read as a failed send, which invites another resend
+				_, sendErr := sender.SendText(phone, delivery.Message)
+				if err := db.RecordDeliveryAttempt(delivery.ID, phone, sendErr); err != nil && sendErr == nil {
+					outcome.RecordErr = err
+				}
+				outcome.Err = sendErr
+			}
+		}
+
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes, nil
+}
@@ -0,0 +1,158 @@
+package replay
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database/d1test"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+// fakeSender records sends and fails for the phones in fail. before runs
+// ahead of each send.
+type fakeSender struct {
+	sent   []string
+	fail   map[string]bool
+	before func()
+}
+
+func (s *fakeSender) SendText(phone, message string) (*zapi.SendResult, error) {
+	if s.before != nil {
+		s.before()
+	}
+	if s.fail[phone] {
+		return nil, errors.New("not on WhatsApp")
+	}
+	s.sent = append(s.sent, phone)
+	return &zapi.SendResult{}, nil
+}
+
+// newDelivery stores a partial delivery to one recipient who got the
+// message and two who did not, and returns it as ewctl loads it
+func newDelivery(t *testing.T) (*database.Client, database.Delivery) {
+	t.Helper()
+	db, err := database.NewClient(d1test.New(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.InitSchema(); err != nil {
+		t.Fatal(err)
+	}
+	inserts := []string{
+		"INSERT INTO forms (id, name) VALUES ('contact', 'Contact')",
+		`INSERT INTO webhook_logs (id, form_id, status, request) VALUES (1, 'contact', 'partial', '{"message":"hi"}')`,
+		"INSERT INTO delivery_recipients (log_id, phone, status) VALUES (1, '5511999990001', 'sent')",
+		"INSERT INTO delivery_recipients (log_id, phone, status) VALUES (1, '5511999990002', 'failed')",
+		"INSERT INTO delivery_recipients (log_id, phone, status) VALUES (1, '5511999990003', 'failed')",
+	}
+	for _, insert := range inserts {
+		if _, err := db.Query(insert); err != nil {
+			t.Fatalf("%s: %v", insert, err)
+		}
+	}
+
+	deliveries, err := db.GetFailedDeliveries(database.DeliveryFilter{})
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("GetFailedDeliveries = %v, %v", deliveries, err)
+	}
+	return db, deliveries[0]
+}
+
+func status(t *testing.T, db *database.Client, sql string) string {
+	t.Helper()
+	result, err := db.Query(sql)
+	if err != nil || len(result.Results) != 1 {
+		t.Fatalf("%s: %v", sql, err)
+	}
+	for _, v := range result.Results[0] {
+		s, _ := v.(string)
+		return s
+	}
+	return ""
+}
+
+func TestResendClaimsEachRecipient(t *testing.T) {
+	db, delivery := newDelivery(t)
+	// Another resend reached the last recipient after this one loaded the delivery
+	if _, err := db.Query("UPDATE delivery_recipients SET status = 'sent' WHERE phone = '5511999990003'"); err != nil {
+		t.Fatal(err)
+	}
+
+	sender := &fakeSender{}
+	outcomes, err := Resend(db, sender, delivery, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sender.sent) != 1 || sender.sent[0] != "5511999990002" {
+		t.Errorf("sent to %v, want [5511999990002]", sender.sent)
+	}
+	if len(outcomes) != 2 || !outcomes[0].Sent() || outcomes[1].Skipped == "" {
+		t.Errorf("outcomes = %+v, want the first failed recipient sent and the other skipped", outcomes)
+	}
+	if got := status(t, db, "SELECT status FROM webhook_logs WHERE id = 1"); got != database.DeliveryResent {
+		t.Errorf("delivery status = %q, want %q once everyone has it", got, database.DeliveryResent)
+	}
+
+	// A second resend of the same stale delivery sends nothing
+	sender.sent = nil
+	if _, err := Resend(db, sender, delivery, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 0 {
+		t.Errorf("second resend sent to %v", sender.sent)
+	}
+}
+
+func TestResendRecordsFailures(t *testing.T) {
+	db, delivery := newDelivery(t)
+	sender := &fakeSender{fail: map[string]bool{"5511999990003": true}}
+
+	outcomes, err := Resend(db, sender, delivery, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 2 || outcomes[1].Err == nil {
+		t.Fatalf("outcomes = %+v, want the last recipient failed", outcomes)
+	}
+	if got := status(t, db, "SELECT status FROM delivery_recipients WHERE phone = '5511999990003'"); got != database.RecipientFailed {
+		t.Errorf("recipient is %q, want it released as failed for the next resend", got)
+	}
+	if got := status(t, db, "SELECT status FROM webhook_logs WHERE id = 1"); got != database.DeliveryPartial {
+		t.Errorf("delivery status = %q, want it left partial", got)
+	}
+}
+
+func TestResendReportsUnrecordedSends(t *testing.T) {
+	db, delivery := newDelivery(t)
+	// Break the database while the message is on its way
+	sender := &fakeSender{before: func() {
+		db.Query("DROP TABLE delivery_recipients")
+	}}
+
+	outcomes, err := Resend(db, sender, delivery, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := outcomes[0]
+	if !first.Sent() || first.RecordErr == nil {
+		t.Errorf("outcome = %+v, want sent with a record error", first)
+	}
+}
+
+func TestDryRunClaimsNothing(t *testing.T) {
+	db, delivery := newDelivery(t)
+	sender := &fakeSender{}
+
+	outcomes, err := Resend(db, sender, delivery, Options{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 0 || len(outcomes) != 2 || outcomes[0].Skipped != "dry run" {
+		t.Errorf("dry run sent to %v with outcomes %+v", sender.sent, outcomes)
+	}
+	if got := status(t, db, "SELECT COUNT(*) || '' FROM delivery_recipients WHERE status = 'sending'"); got != "0" {
+		t.Errorf("dry run left %s recipients claimed", got)
+	}
+}
@@ -0,0 +1,312 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/charmbracelet/x/term"
+	"github.com/zalando/go-keyring"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultService is the keyring service of references without one
+const DefaultService = "ewctl"
+
+// Keyring reads the system keyring: the macOS Keychain, the Secret Service
+// on Linux or the Windows Credential Manager. Targets are service/user, or
+// just user under the ewctl service.
+type Keyring struct{}
+
+func splitKeyring(target string) (service, user string) {
+	if service, user, ok := strings.Cut(target, "/"); ok {
+		return service, user
+	}
+	return DefaultService, target
+}
+
+func (Keyring) Resolve(target string) (string, error) {
+	service, user := splitKeyring(target)
+	secret, err := keyring.Get(service, user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", fmt.Errorf("no %s/%s entry in the keyring", service, user)
+	}
+	return secret, err
+}
+
+func (Keyring) Describe(target string) string {
+	service, user := splitKeyring(target)
+	return fmt.Sprintf("keyring %s/%s", service, user)
+}
+
+func (Keyring) Set(name, value string) error {
+	service, user := splitKeyring(name)
+	return keyring.Set(service, user, value)
+}
+
+// Command runs a helper such as `pass show cf-token` and uses its output,
+// without the trailing newline
+type Command struct{}
+
+// commandTimeout bounds helpers that wait for input that never comes
+const commandTimeout = 30 * time.Second
+
+func (Command) Resolve(target string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", target)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", target)
+	}
+	// Let helpers like pass ask for a GPG passphrase
+	cmd.Stdin = os.Stdin
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	secret := strings.TrimRight(string(out), "\r\n")
+	if secret == "" {
+		return "", errors.New("the command printed nothing")
+	}
+	return secret, nil
+}
+
+func (Command) Describe(target string) string {
+	return fmt.Sprintf("command `%s`", target)
+}
+
+// Env reads an environment variable
+type Env struct{}
+
+func (Env) Resolve(target string) (string, error) {
+	secret := os.Getenv(target)
+	if secret == "" {
+		return "", fmt.Errorf("$%s is not set", target)
+	}
+	return secret, nil
+}
+
+func (Env) Describe(target string) string {
+	return "environment $" + target
+}
+
+// AgeFile is an age-encrypted YAML file of name: secret pairs. It is
+// decrypted with the identities in $EWCTL_AGE_IDENTITY if set, otherwise
+// with a passphrase from $EWCTL_SECRETS_PASSPHRASE or the terminal.
+type AgeFile struct {
+	Path string
+
+	mu         sync.Mutex
+	passphrase string
+	secrets    map[string]string
+}
+
+func NewAgeFile(path string) *AgeFile {
+	return &AgeFile{Path: path}
+}
+
+func (f *AgeFile) Resolve(target string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.secrets == nil {
+		secrets, err := f.read()
+		if err != nil {
+			return "", err
+		}
+		f.secrets = secrets
+	}
+	secret, ok := f.secrets[target]
+	if !ok {
+		return "", fmt.Errorf("%s has no %q entry", f.Path, target)
+	}
+	return secret, nil
+}
+
+func (f *AgeFile) Describe(target string) string {
+	return fmt.Sprintf("encrypted file %s (%s)", filepath.Base(f.Path), target)
+}
+
+// Set adds or replaces a secret, creating the file if needed
+func (f *AgeFile) Set(name, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	secrets := f.secrets
+	if secrets == nil {
+		var err error
+		if secrets, err = f.read(); err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			secrets = make(map[string]string)
+		}
+	}
+	secrets[name] = value
+
+	plain, err := yaml.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal secrets: %w", err)
+	}
+	recipients, err := f.recipients()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	armored := armor.NewWriter(&buf)
+	w, err := age.Encrypt(armored, recipients...)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+	if _, err := w.Write(plain); err != nil {
+		return fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+	if err := armored.Close(); err != nil {
+		return fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+	if err := os.WriteFile(f.Path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.Path, err)
+	}
+	f.secrets = secrets
+	return nil
+}
+
+// read decrypts the file; the error wraps os.ErrNotExist when it is missing
+func (f *AgeFile) read() (map[string]string, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := f.identities()
+	if err != nil {
+		return nil, err
+	}
+
+	var src io.Reader = bytes.NewReader(data)
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(armor.Header)) {
+		src = armor.NewReader(src)
+	}
+	r, err := age.Decrypt(src, identities...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", f.Path, err)
+	}
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", f.Path, err)
+	}
+
+	secrets := make(map[string]string)
+	if err := yaml.Unmarshal(plain, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", f.Path, err)
+	}
+	return secrets, nil
+}
+
+func (f *AgeFile) identities() ([]age.Identity, error) {
+	if path := os.Getenv("EWCTL_AGE_IDENTITY"); path != "" {
+		return parseIdentityFile(path)
+	}
+	passphrase, err := f.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return []age.Identity{identity}, nil
+}
+
+func (f *AgeFile) recipients() ([]age.Recipient, error) {
+	if path := os.Getenv("EWCTL_AGE_IDENTITY"); path != "" {
+		identities, err := parseIdentityFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var recipients []age.Recipient
+		for _, identity := range identities {
+			if x, ok := identity.(*age.X25519Identity); ok {
+				recipients = append(recipients, x.Recipient())
+			}
+		}
+		if len(recipients) == 0 {
+			return nil, fmt.Errorf("%s has no X25519 identities to encrypt to", path)
+		}
+		return recipients, nil
+	}
+	passphrase, err := f.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return []age.Recipient{recipient}, nil
+}
+
+func (f *AgeFile) getPassphrase() (string, error) {
+	if f.passphrase != "" {
+		return f.passphrase, nil
+	}
+	if passphrase := os.Getenv("EWCTL_SECRETS_PASSPHRASE"); passphrase != "" {
+		f.passphrase = passphrase
+		return passphrase, nil
+	}
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return "", fmt.Errorf("set EWCTL_SECRETS_PASSPHRASE or EWCTL_AGE_IDENTITY to decrypt %s", f.Path)
+	}
+
+	fmt.Fprintf(os.Stderr, "Passphrase for %s: ", f.Path)
+	passphrase, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if len(passphrase) == 0 {
+		return "", errors.New("the passphrase is empty")
+	}
+	f.passphrase = string(passphrase)
+	return f.passphrase, nil
+}
+
+func parseIdentityFile(path string) ([]age.Identity, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open identity file: %w", err)
+	}
+	defer file.Close()
+	identities, err := age.ParseIdentities(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return identities, nil
+}
@@ -0,0 +1,107 @@
+// Package secrets resolves secret references in config.yaml, such as
+// "keyring:ewctl/cloudflare" or "cmd:pass show cf-token", so credentials
+// do not have to be stored in plaintext
+package secrets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Provider looks up the secret a reference points to
+type Provider interface {
+	// Resolve returns the secret named by target, the part of the
+	// reference after the scheme
+	Resolve(target string) (string, error)
+	// Describe says where target is read from, for display
+	Describe(target string) string
+}
+
+// Store is a provider ewctl can also write secrets to
+type Store interface {
+	Provider
+	Set(name, value string) error
+}
+
+// Resolver maps reference schemes to providers
+type Resolver struct {
+	providers map[string]Provider
+}
+
+// NewResolver returns a resolver for the keyring, age, cmd and env
+// schemes. ageFile is the encrypted file age: references read.
+func NewResolver(ageFile string) *Resolver {
+	return &Resolver{providers: map[string]Provider{
+		"keyring": Keyring{},
+		"age":     NewAgeFile(ageFile),
+		"cmd":     Command{},
+		"env":     Env{},
+	}}
+}
+
+// Schemes returns the registered reference schemes
+func (r *Resolver) Schemes() []string {
+	schemes := make([]string, 0, len(r.providers))
+	for scheme := range r.providers {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// Parse splits a reference into its provider and target; ok is false for
+// plaintext values
+func (r *Resolver) Parse(value string) (provider Provider, scheme, target string, ok bool) {
+	scheme, target, found := strings.Cut(value, ":")
+	if !found {
+		return nil, "", "", false
+	}
+	provider, ok = r.providers[scheme]
+	if !ok || strings.TrimSpace(target) == "" {
+		return nil, "", "", false
+	}
+	return provider, scheme, strings.TrimSpace(target), true
+}
+
+// IsRef reports whether value is a secret reference rather than a secret
+func (r *Resolver) IsRef(value string) bool {
+	_, _, _, ok := r.Parse(value)
+	return ok
+}
+
+// Resolve returns the secret value references to; plaintext values are
+// returned unchanged
+func (r *Resolver) Resolve(value string) (string, error) {
+	provider, _, target, ok := r.Parse(value)
+	if !ok {
+		return value, nil
+	}
+	secret, err := provider.Resolve(target)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", value, err)
+	}
+	return secret, nil
+}
+
+// Describe says where a value comes from, e.g. "keyring ewctl/cloudflare"
+func (r *Resolver) Describe(value string) string {
+	provider, _, target, ok := r.Parse(value)
+	if !ok {
+		return "plaintext"
+	}
+	return provider.Describe(target)
+}
+
+// Set stores value under name with the provider of scheme and returns the
+// reference that reads it back
+func (r *Resolver) Set(scheme, name, value string) (string, error) {
+	store, ok := r.providers[scheme].(Store)
+	if !ok {
+		return "", fmt.Errorf("cannot store secrets in %q; use keyring or age", scheme)
+	}
+	if err := store.Set(name, value); err != nil {
+		return "", fmt.Errorf("failed to store %s: %w", name, err)
+	}
+	return scheme + ":" + name, nil
+}
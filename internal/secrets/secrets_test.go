@@ -0,0 +1,154 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/zalando/go-keyring"
+)
+
+func TestResolverParse(t *testing.T) {
+	r := NewResolver("")
+	tests := []struct {
+		value  string
+		scheme string
+		target string
+	}{
+		{"keyring:ewctl/cloudflare", "keyring", "ewctl/cloudflare"},
+		{"cmd: pass show cf-token ", "cmd", "pass show cf-token"},
+		{"env:CF_TOKEN", "env", "CF_TOKEN"},
+		{"age:cloudflare", "age", "cloudflare"},
+		// Plaintext, including values that merely contain a colon
+		{"abc123", "", ""},
+		{"https://example.com", "", ""},
+		{"env:", "", ""},
+	}
+	for _, tt := range tests {
+		_, scheme, target, ok := r.Parse(tt.value)
+		if ok != (tt.scheme != "") || scheme != tt.scheme || target != tt.target {
+			t.Errorf("Parse(%q) = %q, %q, %v; want %q, %q", tt.value, scheme, target, ok, tt.scheme, tt.target)
+		}
+	}
+
+	if got, err := r.Resolve("abc123"); err != nil || got != "abc123" {
+		t.Errorf("Resolve(plaintext) = %q, %v", got, err)
+	}
+	if got := r.Describe("abc123"); got != "plaintext" {
+		t.Errorf("Describe(plaintext) = %q", got)
+	}
+	if _, err := r.Set("env", "X", "y"); err == nil {
+		t.Error("storing in env should fail")
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("EWCTL_TEST_SECRET", "s3cret")
+	r := NewResolver("")
+
+	if got, err := r.Resolve("env:EWCTL_TEST_SECRET"); err != nil || got != "s3cret" {
+		t.Errorf("Resolve = %q, %v", got, err)
+	}
+	if _, err := r.Resolve("env:EWCTL_TEST_UNSET"); err == nil {
+		t.Error("resolving an unset variable should fail")
+	}
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	r := NewResolver("")
+
+	if got, err := r.Resolve("cmd:printf 's3cret\\n'"); err != nil || got != "s3cret" {
+		t.Errorf("Resolve = %q, %v; want the output without the newline", got, err)
+	}
+	if _, err := r.Resolve("cmd:true"); err == nil {
+		t.Error("a command printing nothing should fail")
+	}
+	_, err := r.Resolve("cmd:echo denied >&2; exit 1")
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("failing command: err = %v, want its stderr", err)
+	}
+}
+
+func TestKeyring(t *testing.T) {
+	keyring.MockInit()
+	r := NewResolver("")
+
+	ref, err := r.Set("keyring", "cloudflare", "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref != "keyring:cloudflare" {
+		t.Errorf("Set returned %q", ref)
+	}
+	if got, err := r.Resolve(ref); err != nil || got != "s3cret" {
+		t.Errorf("Resolve = %q, %v", got, err)
+	}
+	if got, err := r.Resolve("keyring:ewctl/cloudflare"); err != nil || got != "s3cret" {
+		t.Errorf("Resolve with the default service spelled out = %q, %v", got, err)
+	}
+	if _, err := r.Resolve("keyring:other/cloudflare"); err == nil {
+		t.Error("resolving a missing entry should fail")
+	}
+}
+
+func TestAgeFileWithIdentity(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	identityPath := filepath.Join(dir, "key.txt")
+	if err := os.WriteFile(identityPath, []byte(identity.String()+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EWCTL_AGE_IDENTITY", identityPath)
+	path := filepath.Join(dir, "secrets", "secrets.age")
+
+	if _, err := NewAgeFile(path).Resolve("cloudflare"); !os.IsNotExist(err) {
+		t.Errorf("missing file: err = %v", err)
+	}
+	r := NewResolver(path)
+	for name, value := range map[string]string{"cloudflare": "cf", "zapi": "za"} {
+		if _, err := r.Set("age", name, value); err != nil {
+			t.Fatalf("Set(%s): %v", name, err)
+		}
+	}
+
+	// A fresh file reads both entries back from disk
+	file := NewAgeFile(path)
+	for name, want := range map[string]string{"cloudflare": "cf", "zapi": "za"} {
+		if got, err := file.Resolve(name); err != nil || got != want {
+			t.Errorf("Resolve(%s) = %q, %v", name, got, err)
+		}
+	}
+	if _, err := file.Resolve("missing"); err == nil {
+		t.Error("resolving a missing entry should fail")
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "cloudflare") {
+		t.Error("secrets file is not encrypted")
+	}
+}
+
+func TestAgeFileWithPassphrase(t *testing.T) {
+	t.Setenv("EWCTL_AGE_IDENTITY", "")
+	t.Setenv("EWCTL_SECRETS_PASSPHRASE", "correct horse")
+	path := filepath.Join(t.TempDir(), "secrets.age")
+
+	if err := NewAgeFile(path).Set("cloudflare", "cf"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := NewAgeFile(path).Resolve("cloudflare"); err != nil || got != "cf" {
+		t.Errorf("Resolve = %q, %v", got, err)
+	}
+
+	t.Setenv("EWCTL_SECRETS_PASSPHRASE", "wrong")
+	if _, err := NewAgeFile(path).Resolve("cloudflare"); err == nil {
+		t.Error("decrypting with the wrong passphrase should fail")
+	}
+}
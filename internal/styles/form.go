@@ -0,0 +1,46 @@
+package styles
+
+import (
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Form returns a huh theme in the colors of s, laid out like huh's charm
+// theme so forms look the same apart from the palette
+func (s *Styles) Form() *huh.Theme {
+	c := s.Colors
+	t := huh.ThemeBase()
+
+	t.Focused.Base = t.Focused.Base.BorderForeground(c.Border)
+	t.Focused.Card = t.Focused.Base
+	t.Focused.Title = t.Focused.Title.Foreground(c.Primary).Bold(true)
+	t.Focused.NoteTitle = t.Focused.NoteTitle.Foreground(c.Primary).Bold(true).MarginBottom(1)
+	t.Focused.Directory = t.Focused.Directory.Foreground(c.Primary)
+	t.Focused.Description = t.Focused.Description.Foreground(c.Muted)
+	t.Focused.ErrorIndicator = t.Focused.ErrorIndicator.Foreground(c.Error)
+	t.Focused.ErrorMessage = t.Focused.ErrorMessage.Foreground(c.Error)
+	t.Focused.SelectSelector = t.Focused.SelectSelector.Foreground(c.Secondary)
+	t.Focused.NextIndicator = t.Focused.NextIndicator.Foreground(c.Secondary)
+	t.Focused.PrevIndicator = t.Focused.PrevIndicator.Foreground(c.Secondary)
+	t.Focused.MultiSelectSelector = t.Focused.MultiSelectSelector.Foreground(c.Secondary)
+	t.Focused.SelectedOption = t.Focused.SelectedOption.Foreground(c.Success)
+	t.Focused.SelectedPrefix = lipgloss.NewStyle().Foreground(c.Success).SetString("✓ ")
+	t.Focused.UnselectedPrefix = lipgloss.NewStyle().Foreground(c.Muted).SetString("• ")
+	t.Focused.FocusedButton = t.Focused.FocusedButton.Foreground(c.BgPrimary).Background(c.Primary)
+	t.Focused.Next = t.Focused.FocusedButton
+	t.Focused.BlurredButton = t.Focused.BlurredButton.UnsetForeground().Background(c.BgSecondary)
+
+	t.Focused.TextInput.Cursor = t.Focused.TextInput.Cursor.Foreground(c.Success)
+	t.Focused.TextInput.Placeholder = t.Focused.TextInput.Placeholder.Foreground(c.Muted)
+	t.Focused.TextInput.Prompt = t.Focused.TextInput.Prompt.Foreground(c.Secondary)
+
+	t.Blurred = t.Focused
+	t.Blurred.Base = t.Focused.Base.BorderStyle(lipgloss.HiddenBorder())
+	t.Blurred.Card = t.Blurred.Base
+	t.Blurred.NextIndicator = lipgloss.NewStyle()
+	t.Blurred.PrevIndicator = lipgloss.NewStyle()
+
+	t.Group.Title = t.Focused.Title
+	t.Group.Description = t.Focused.Description
+	return t
+}
@@ -46,6 +46,8 @@ type Styles struct {
 	Spinner    lipgloss.Style
 	Progress   lipgloss.Style
 	Badge      lipgloss.Style
+	// Highlight marks rows a refresh added or changed
+	Highlight  lipgloss.Style
 	
 	// Colors (for reference)
 	Colors     ColorScheme
@@ -108,20 +110,25 @@ var (
 	}
 )
 
+// Themes lists the built-in theme names
+var Themes = []string{"auto", "charm", "dark", "light"}
+
+// NewStyles builds the styles of the named theme for the terminal's
+// background, falling back to charm for unknown names
 func NewStyles(theme string) *Styles {
-	var colors ColorScheme
-	
-	switch theme {
-	case "charm":
-		colors = CharmColors
-	case "dark":
-		colors = DarkColors
-	case "light", "default":
-		colors = DefaultColors
-	default:
-		colors = CharmColors
+	t, ok := Find(theme)
+	if !ok {
+		t, _ = Find("charm")
 	}
-	
+	colors := t.Dark
+	if t.Light != t.Dark && !HasDarkBackground() {
+		colors = t.Light
+	}
+	return NewStylesFor(colors)
+}
+
+// NewStylesFor builds styles from a color scheme
+func NewStylesFor(colors ColorScheme) *Styles {
 	s := &Styles{
 		Colors: colors,
 	}
@@ -254,6 +261,10 @@ func NewStyles(theme string) *Styles {
 		Background(colors.Primary).
 		Padding(0, 1).
 		MarginRight(1)
+		
+	s.Highlight = lipgloss.NewStyle().
+		Foreground(colors.Success).
+		Bold(true)
 	
 	return s
 }
@@ -0,0 +1,259 @@
+package styles
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Theme is a named color scheme with variants for dark and light
+// terminal backgrounds
+type Theme struct {
+	Name  string
+	Dark  ColorScheme
+	Light ColorScheme
+	// Path is the file a user theme was loaded from
+	Path string
+}
+
+// Roles are the color names theme files use, in ColorScheme order
+var Roles = []string{
+	"primary", "secondary", "success", "warning", "error", "info",
+	"muted", "bg_primary", "bg_secondary", "border",
+}
+
+// Color returns the color of a role, such as "bg_primary"
+func (c *ColorScheme) Color(role string) *lipgloss.Color {
+	switch role {
+	case "primary":
+		return &c.Primary
+	case "secondary":
+		return &c.Secondary
+	case "success":
+		return &c.Success
+	case "warning":
+		return &c.Warning
+	case "error":
+		return &c.Error
+	case "info":
+		return &c.Info
+	case "muted":
+		return &c.Muted
+	case "bg_primary":
+		return &c.BgPrimary
+	case "bg_secondary":
+		return &c.BgSecondary
+	case "border":
+		return &c.Border
+	}
+	return nil
+}
+
+var builtinThemes = []Theme{
+	// auto follows the terminal background
+	{Name: "auto", Dark: CharmColors, Light: DefaultColors},
+	{Name: "charm", Dark: CharmColors, Light: CharmColors},
+	{Name: "dark", Dark: DarkColors, Light: DarkColors},
+	{Name: "light", Dark: DefaultColors, Light: DefaultColors},
+}
+
+var (
+	mu         sync.RWMutex
+	userThemes []Theme
+)
+
+// Register makes user themes available by name. A user theme with the
+// name of a built-in one replaces it.
+func Register(themes []Theme) {
+	mu.Lock()
+	defer mu.Unlock()
+	userThemes = themes
+}
+
+// Find returns the named theme, looking at user themes first
+func Find(name string) (Theme, bool) {
+	if name == "default" {
+		name = "light"
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, theme := range userThemes {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+	for _, theme := range builtinThemes {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+	return Theme{}, false
+}
+
+// Names lists the built-in themes and then the user themes
+func Names() []string {
+	names := append([]string(nil), Themes...)
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, theme := range userThemes {
+		if !contains(names, theme.Name) {
+			names = append(names, theme.Name)
+		}
+	}
+	return names
+}
+
+// HasDarkBackground reports whether the terminal background is dark. The
+// terminal is asked once, before the TUI takes over its input.
+var HasDarkBackground = sync.OnceValue(lipgloss.HasDarkBackground)
+
+// themeFile is the layout of a theme file. Colors apply to both
+// backgrounds; dark and light replace some or all of them for one.
+type themeFile struct {
+	Name   string            `yaml:"name" toml:"name"`
+	Colors map[string]string `yaml:"colors" toml:"colors"`
+	Dark   map[string]string `yaml:"dark" toml:"dark"`
+	Light  map[string]string `yaml:"light" toml:"light"`
+}
+
+// themeExtensions are the theme file formats, by extension
+var themeExtensions = map[string]func([]byte, any) error{
+	".yaml": yaml.Unmarshal,
+	".yml":  yaml.Unmarshal,
+	".toml": toml.Unmarshal,
+}
+
+// LoadThemes reads every theme file in dir. Themes that parse and define
+// every role are returned; the problems with the others are joined in the
+// error. A missing dir has no themes.
+func LoadThemes(dir string) ([]Theme, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read themes: %w", err)
+	}
+
+	var (
+		themes []Theme
+		errs   []error
+	)
+	for _, entry := range entries {
+		if entry.IsDir() || themeExtensions[filepath.Ext(entry.Name())] == nil {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		theme, err := LoadTheme(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		themes = append(themes, theme)
+	}
+	sort.Slice(themes, func(i, j int) bool { return themes[i].Name < themes[j].Name })
+	return themes, errors.Join(errs...)
+}
+
+// LoadTheme reads a YAML or TOML theme file
+func LoadTheme(path string) (Theme, error) {
+	unmarshal := themeExtensions[filepath.Ext(path)]
+	if unmarshal == nil {
+		return Theme{}, fmt.Errorf("%s: theme files must be .yaml, .yml or .toml", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, fmt.Errorf("failed to read theme: %w", err)
+	}
+	var file themeFile
+	if err := unmarshal(data, &file); err != nil {
+		return Theme{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	theme := Theme{Name: file.Name, Path: path}
+	if theme.Name == "" {
+		theme.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	var problems []string
+	for _, colors := range []map[string]string{file.Colors, file.Dark, file.Light} {
+		for role, value := range colors {
+			var problem string
+			switch {
+			case new(ColorScheme).Color(role) == nil:
+				problem = "unknown role " + role
+			case !validColor(value):
+				problem = fmt.Sprintf("%s is %q, not a #RRGGBB color or ANSI number", role, value)
+			}
+			if problem != "" && !contains(problems, problem) {
+				problems = append(problems, problem)
+			}
+		}
+	}
+	var missingDark, missingLight []string
+	theme.Dark, missingDark = scheme(file.Colors, file.Dark)
+	theme.Light, missingLight = scheme(file.Colors, file.Light)
+	switch dark, light := strings.Join(missingDark, ", "), strings.Join(missingLight, ", "); {
+	case dark == light && dark != "":
+		problems = append(problems, "missing "+dark)
+	case dark != light:
+		if dark != "" {
+			problems = append(problems, "dark background is missing "+dark)
+		}
+		if light != "" {
+			problems = append(problems, "light background is missing "+light)
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return Theme{}, fmt.Errorf("%s: %s", path, strings.Join(problems, "; "))
+	}
+	return theme, nil
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// scheme builds the colors for one background from the shared colors and
+// that background's overrides, returning the roles neither sets
+func scheme(colors, overrides map[string]string) (ColorScheme, []string) {
+	var c ColorScheme
+	var missing []string
+	for _, role := range Roles {
+		value, ok := overrides[role]
+		if !ok {
+			value, ok = colors[role]
+		}
+		if !ok {
+			missing = append(missing, role)
+			continue
+		}
+		*c.Color(role) = lipgloss.Color(value)
+	}
+	return c, missing
+}
+
+func validColor(value string) bool {
+	if hexColor.MatchString(value) {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package styles
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fullColors sets every role in a theme file section
+const fullColors = `
+  primary: "#7D56F4"
+  secondary: "#EE6FF8"
+  success: "#02BA84"
+  warning: "#F4B400"
+  error: "#ED567A"
+  info: "#5A9CF8"
+  muted: "241"
+  bg_primary: "#1A1A2E"
+  bg_secondary: "#25253D"
+  border: "#3C3C5C"
+`
+
+func writeTheme(t *testing.T, dir, name, body string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadThemeMergesBackgrounds(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTheme(t, dir, "dusk.yaml", "colors:"+fullColors+"light:\n  bg_primary: \"#FFFFFF\"\n")
+
+	theme, err := LoadTheme(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Name != "dusk" || theme.Path != path {
+		t.Errorf("theme = %s from %s, want it named after the file", theme.Name, theme.Path)
+	}
+	if theme.Dark.BgPrimary != "#1A1A2E" || theme.Light.BgPrimary != "#FFFFFF" || theme.Light.Primary != "#7D56F4" {
+		t.Errorf("dark bg %s, light bg %s, light primary %s", theme.Dark.BgPrimary, theme.Light.BgPrimary, theme.Light.Primary)
+	}
+
+	toml := writeTheme(t, dir, "mono.toml", "name = \"Mono\"\n[colors]\n"+strings.ReplaceAll(strings.ReplaceAll(fullColors, ": ", " = "), "  ", ""))
+	if theme, err := LoadTheme(toml); err != nil || theme.Name != "Mono" || theme.Dark.Muted != "241" {
+		t.Errorf("LoadTheme(toml) = %+v, %v", theme, err)
+	}
+}
+
+func TestLoadThemeReportsEveryProblem(t *testing.T) {
+	dir := t.TempDir()
+	body := "colors:" + strings.Replace(fullColors, `"#ED567A"`, `"red"`, 1) + "  accent: \"#FFFFFF\"\ndark:\n  border: \"#000\"\n"
+	body = strings.Replace(body, "  info: \"#5A9CF8\"\n", "", 1)
+	_, err := LoadTheme(writeTheme(t, dir, "bad.yaml", body))
+	if err == nil {
+		t.Fatal("LoadTheme accepted a broken theme")
+	}
+	for _, want := range []string{`error is "red"`, "unknown role accent", "missing info"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	onlyDark := "colors:" + strings.Replace(fullColors, "  border: \"#3C3C5C\"\n", "", 1) + "dark:\n  border: \"#000000\"\n"
+	if _, err := LoadTheme(writeTheme(t, dir, "half.yaml", onlyDark)); err == nil || !strings.Contains(err.Error(), "light background is missing border") {
+		t.Errorf("LoadTheme = %v, want the light background's missing role", err)
+	}
+}
+
+func TestLoadThemesAndRegister(t *testing.T) {
+	if themes, err := LoadThemes(filepath.Join(t.TempDir(), "missing")); err != nil || themes != nil {
+		t.Errorf("LoadThemes of a missing dir = %v, %v", themes, err)
+	}
+
+	dir := t.TempDir()
+	writeTheme(t, dir, "zed.yml", "colors:"+fullColors)
+	writeTheme(t, dir, "dark.yaml", "colors:"+fullColors)
+	writeTheme(t, dir, "broken.yaml", "colors:\n  primary: \"#fff\"\n")
+	writeTheme(t, dir, "notes.txt", "not a theme")
+
+	themes, err := LoadThemes(dir)
+	if err == nil || !strings.Contains(err.Error(), "broken.yaml") {
+		t.Errorf("LoadThemes error = %v, want the broken file reported", err)
+	}
+	if len(themes) != 2 || themes[0].Name != "dark" || themes[1].Name != "zed" {
+		t.Fatalf("LoadThemes = %d themes, want dark and zed sorted", len(themes))
+	}
+
+	Register(themes)
+	t.Cleanup(func() { Register(nil) })
+	if theme, ok := Find("dark"); !ok || theme.Path == "" {
+		t.Error("a user theme did not replace the built-in one of the same name")
+	}
+	if theme, ok := Find("default"); !ok || theme.Name != "light" {
+		t.Error("default is not an alias of light")
+	}
+	names := Names()
+	if names[len(names)-1] != "zed" || strings.Count(strings.Join(names, ","), "dark") != 1 {
+		t.Errorf("Names = %v, want built-ins then new user themes, without duplicates", names)
+	}
+}
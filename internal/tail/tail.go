@@ -0,0 +1,161 @@
+// Package tail follows new submissions and deliveries as the worker
+// records them
+package tail
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+// pollLimit caps how many rows of each kind one poll reads
+const pollLimit = 100
+
+// Event kinds
+const (
+	KindSubmission = "submission"
+	KindDelivery   = "delivery"
+)
+
+// Event is one new submission or delivery
+type Event struct {
+	Kind       string               `json:"kind"`
+	ID         int                  `json:"id"`
+	FormID     string               `json:"form_id"`
+	Status     string               `json:"status"`
+	Time       time.Time            `json:"time"`
+	Submission *database.Submission `json:"submission,omitempty"`
+	Delivery   *database.Delivery   `json:"delivery,omitempty"`
+}
+
+// Failed reports whether the event is a delivery that did not reach
+// every recipient
+func (e Event) Failed() bool {
+	return e.Status == database.DeliveryFailed || e.Status == database.DeliveryPartial
+}
+
+// Summary describes the event in one line
+func (e Event) Summary(fields map[string][]database.Field) string {
+	switch {
+	case e.Submission != nil:
+		var values []string
+		for _, field := range e.Submission.LabeledFields(fields[e.FormID]) {
+			if field.Value != "" {
+				values = append(values, field.Value)
+			}
+			if len(values) == 3 {
+				break
+			}
+		}
+		return strings.Join(values, " • ")
+	case e.Delivery != nil:
+		var parts []string
+		for _, r := range e.Delivery.Recipients {
+			part := r.Phone + " " + r.Status
+			if r.LastError != "" {
+				part += ": " + r.LastError
+			}
+			parts = append(parts, part)
+		}
+		summary := fmt.Sprintf("%dms", e.Delivery.Duration)
+		if len(parts) > 0 {
+			summary += " • " + strings.Join(parts, ", ")
+		}
+		return summary
+	}
+	return ""
+}
+
+// Follower polls for rows newer than the last ones it returned
+type Follower struct {
+	db           *database.Client
+	filter       database.TailFilter
+	submissionID int
+	deliveryID   int
+}
+
+// NewFollower starts following after the last backlog matching rows of
+// each kind, which the first Poll returns
+func NewFollower(db *database.Client, filter database.TailFilter, backlog int) (*Follower, error) {
+	submissionID, deliveryID, err := db.GetTailStart(filter, backlog)
+	if err != nil {
+		return nil, err
+	}
+	return &Follower{db: db, filter: filter, submissionID: submissionID, deliveryID: deliveryID}, nil
+}
+
+// Poll returns the events recorded since the last poll, oldest first
+func (f *Follower) Poll() ([]Event, error) {
+	submissions, err := f.db.GetSubmissionsAfter(f.submissionID, f.filter, pollLimit)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := f.db.GetDeliveriesAfter(f.deliveryID, f.filter, pollLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(submissions)+len(deliveries))
+	for i := range submissions {
+		s := &submissions[i]
+		f.submissionID = max(f.submissionID, s.ID)
+		events = append(events, Event{
+			Kind:       KindSubmission,
+			ID:         s.ID,
+			FormID:     s.FormID,
+			Status:     s.DeliveryStatus,
+			Time:       s.CreatedAt,
+			Submission: s,
+		})
+	}
+	for i := range deliveries {
+		d := &deliveries[i]
+		f.deliveryID = max(f.deliveryID, d.ID)
+		events = append(events, Event{
+			Kind:     KindDelivery,
+			ID:       d.ID,
+			FormID:   d.FormID,
+			Status:   d.Status,
+			Time:     d.CreatedAt,
+			Delivery: d,
+		})
+	}
+
+	// A submission comes before the delivery it triggered
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Time.Equal(events[j].Time) {
+			return events[i].Time.Before(events[j].Time)
+		}
+		return events[i].Kind == KindSubmission && events[j].Kind == KindDelivery
+	})
+	return events, nil
+}
+
+// Follow polls every interval and hands each batch of events to emit
+// until ctx is done or emit fails. Failed polls are logged and retried.
+func (f *Follower) Follow(ctx context.Context, interval time.Duration, emit func([]Event) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := f.Poll()
+		if err != nil {
+			log.Error("Failed to poll for new rows", "error", err)
+		} else if len(events) > 0 {
+			if err := emit(events); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
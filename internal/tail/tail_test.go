@@ -0,0 +1,141 @@
+package tail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database/d1test"
+)
+
+// newDB returns a client on an empty database with two forms
+func newDB(t *testing.T) *database.Client {
+	t.Helper()
+	db, err := database.NewClient(d1test.New(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.InitSchema(); err != nil {
+		t.Fatal(err)
+	}
+	exec(t, db, "INSERT INTO forms (id, name) VALUES ('contact', 'Contact'), ('quote', 'Quote')")
+	return db
+}
+
+func exec(t *testing.T, db *database.Client, sql string, params ...interface{}) {
+	t.Helper()
+	if _, err := db.Query(sql, params...); err != nil {
+		t.Fatalf("%s: %v", sql, err)
+	}
+}
+
+// record stores a submission and the delivery it triggered, as the
+// worker does, at the given second
+func record(t *testing.T, db *database.Client, formID, status string, second int) {
+	t.Helper()
+	at := fmt.Sprintf("2026-03-10 12:00:%02d", second)
+	exec(t, db, "INSERT INTO submissions (form_id, fields, delivery_status, created_at) VALUES (?, '{}', ?, ?)", formID, status, at)
+	exec(t, db, "INSERT INTO webhook_logs (form_id, status, duration_ms, created_at) VALUES (?, ?, 120, ?)", formID, status, at)
+}
+
+func kinds(events []Event) []string {
+	var out []string
+	for _, e := range events {
+		out = append(out, fmt.Sprintf("%s %d", e.Kind, e.ID))
+	}
+	return out
+}
+
+func TestFollowerStartsWithTheBacklog(t *testing.T) {
+	db := newDB(t)
+	for second := range 3 {
+		record(t, db, "contact", database.DeliverySuccess, second)
+	}
+
+	f, err := NewFollower(db, database.TailFilter{}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := f.Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"submission 2", "delivery 2", "submission 3", "delivery 3"}
+	if fmt.Sprint(kinds(events)) != fmt.Sprint(want) {
+		t.Errorf("first poll = %v, want %v", kinds(events), want)
+	}
+
+	if events, _ := f.Poll(); len(events) != 0 {
+		t.Errorf("second poll returned %v, want nothing new", kinds(events))
+	}
+	record(t, db, "contact", database.DeliverySuccess, 5)
+	if events, _ := f.Poll(); fmt.Sprint(kinds(events)) != "[submission 4 delivery 4]" {
+		t.Errorf("poll after a new row = %v", kinds(events))
+	}
+}
+
+func TestFollowerFilters(t *testing.T) {
+	db := newDB(t)
+	f, err := NewFollower(db, database.TailFilter{FormID: "contact", Statuses: []string{database.DeliveryFailed}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record(t, db, "contact", database.DeliverySuccess, 0)
+	record(t, db, "quote", database.DeliveryFailed, 1)
+	record(t, db, "contact", database.DeliveryFailed, 2)
+
+	events, err := f.Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(kinds(events)) != "[submission 3 delivery 3]" {
+		t.Errorf("poll = %v, want only the failed contact rows", kinds(events))
+	}
+	for _, e := range events {
+		if !e.Failed() {
+			t.Errorf("%s %d not reported as failed", e.Kind, e.ID)
+		}
+	}
+}
+
+func TestFollowStopsWhenEmitFails(t *testing.T) {
+	db := newDB(t)
+	record(t, db, "contact", database.DeliverySuccess, 0)
+	f, err := NewFollower(db, database.TailFilter{}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := errors.New("stop")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := f.Follow(ctx, time.Millisecond, func([]Event) error { return stop }); err != stop {
+		t.Errorf("Follow = %v, want the emit error", err)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	fields := map[string][]database.Field{"contact": {
+		{ElementorID: "name", Label: "Name", Position: 1},
+		{ElementorID: "email", Label: "E-mail", Position: 2},
+		{ElementorID: "phone", Label: "Phone", Position: 3},
+		{ElementorID: "message", Label: "Message", Position: 4},
+	}}
+	submission := Event{FormID: "contact", Submission: &database.Submission{
+		Fields: map[string]string{"name": "Ana", "email": "", "phone": "5511999990001", "message": "Hi", "utm": "ads"},
+	}}
+	if got := submission.Summary(fields); got != "Ana • 5511999990001 • Hi" {
+		t.Errorf("submission summary = %q, want the first three non-empty values", got)
+	}
+
+	delivery := Event{Delivery: &database.Delivery{Duration: 120, Recipients: []database.DeliveryRecipient{
+		{Phone: "5511999990001", Status: "sent"},
+		{Phone: "5511999990002", Status: "failed", LastError: "timeout"},
+	}}}
+	if got := delivery.Summary(nil); got != "120ms • 5511999990001 sent, 5511999990002 failed: timeout" {
+		t.Errorf("delivery summary = %q", got)
+	}
+}
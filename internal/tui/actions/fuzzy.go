@@ -0,0 +1,96 @@
+package actions
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Result is an action that matched a palette query
+type Result struct {
+	Action Action
+	Score  int
+	// Positions are the matched rune indexes in the title
+	Positions []int
+}
+
+// Search ranks actions by how well query matches their title, hint and
+// keywords. An empty query returns every action in order.
+func Search(actions []Action, query string) []Result {
+	query = strings.TrimSpace(query)
+	results := make([]Result, 0, len(actions))
+	for _, action := range actions {
+		if query == "" {
+			results = append(results, Result{Action: action})
+			continue
+		}
+
+		score, positions, ok := Match(query, action.Title)
+		// Hints and keywords match too, ranked below title matches
+		for _, extra := range append([]string{action.Hint, action.Group}, action.Keywords...) {
+			if s, _, found := Match(query, extra); found && (!ok || s/2 > score) {
+				score, positions, ok = s/2, nil, true
+			}
+		}
+		if ok {
+			results = append(results, Result{Action: action, Score: score, Positions: positions})
+		}
+	}
+
+	if query != "" {
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Score > results[j].Score
+		})
+	}
+	return results
+}
+
+// Match reports whether every rune of query appears in target in order,
+// ignoring case, and scores the match: consecutive runes, word starts and
+// an early first match score higher
+func Match(query, target string) (score int, positions []int, ok bool) {
+	if query == "" || target == "" {
+		return 0, nil, false
+	}
+	q := []rune(strings.ToLower(query))
+	t := []rune(target)
+
+	qi := 0
+	last := -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if q[qi] == ' ' {
+			// Spaces in the query only separate words
+			qi++
+			ti--
+			continue
+		}
+		if unicode.ToLower(t[ti]) != q[qi] {
+			continue
+		}
+
+		score++
+		if ti == last+1 {
+			score += 5
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 8
+		} else if unicode.IsUpper(t[ti]) && unicode.IsLower(t[ti-1]) {
+			score += 4
+		}
+		if len(positions) == 0 {
+			score -= min(ti, 10)
+		}
+		positions = append(positions, ti)
+		last = ti
+		qi++
+	}
+	for qi < len(q) && q[qi] == ' ' {
+		qi++
+	}
+	if qi < len(q) {
+		return 0, nil, false
+	}
+	// Shorter targets are closer matches
+	score -= len(t) / 8
+	return score, positions, true
+}
@@ -0,0 +1,62 @@
+package actions
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	for _, tt := range []struct {
+		query, target string
+		ok            bool
+		positions     []int
+	}{
+		{"gf", "Go to Forms", true, []int{0, 6}},
+		{"FORMS", "Go to Forms", true, []int{6, 7, 8, 9, 10}},
+		{"go forms", "Go to Forms", true, []int{0, 1, 6, 7, 8, 9, 10}},
+		{"fg", "Go to Forms", false, nil},
+		{"x", "", false, nil},
+		{"", "Forms", false, nil},
+	} {
+		_, positions, ok := Match(tt.query, tt.target)
+		if ok != tt.ok || fmt.Sprint(positions) != fmt.Sprint(tt.positions) {
+			t.Errorf("Match(%q, %q) = %v, %v; want %v, %v", tt.query, tt.target, positions, ok, tt.positions, tt.ok)
+		}
+	}
+
+	// Word starts and runs of consecutive runes beat scattered matches
+	wordStart, _, _ := Match("del", "Delete form")
+	scattered, _, _ := Match("del", "Reload deliveries list")
+	if wordStart <= scattered {
+		t.Errorf("word-start score %d not above scattered score %d", wordStart, scattered)
+	}
+}
+
+func TestSearchRanksTitlesAboveHints(t *testing.T) {
+	actions := []Action{
+		{Title: "Open contact", Group: "Contacts", Hint: "5511999990001"},
+		{Title: "Settings", Group: "Navigation", Keywords: []string{"config", "preferences"}},
+		{Title: "Contacts", Group: "Navigation"},
+	}
+
+	titles := func(results []Result) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.Action.Title)
+		}
+		return out
+	}
+
+	if got := titles(Search(actions, "")); fmt.Sprint(got) != "[Open contact Settings Contacts]" {
+		t.Errorf("empty query = %v, want every action in order", got)
+	}
+	if got := titles(Search(actions, "contacts")); fmt.Sprint(got) != "[Contacts Open contact]" {
+		t.Errorf("contacts = %v, want the title match before the group match", got)
+	}
+	if results := Search(actions, "config"); len(results) != 1 || results[0].Action.Title != "Settings" || results[0].Positions != nil {
+		t.Errorf("config = %+v, want Settings matched by keyword without title positions", results)
+	}
+	if got := titles(Search(actions, "99990001")); fmt.Sprint(got) != "[Open contact]" {
+		t.Errorf("phone = %v, want the contact matched by its hint", got)
+	}
+}
@@ -0,0 +1,233 @@
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+)
+
+// paletteRows is how many results the palette shows at once
+const paletteRows = 10
+
+// Palette is the ctrl+k command palette. The app keeps one, routes every
+// message to Update while Active reports true and draws View in place of
+// the current view.
+type Palette struct {
+	registry *Registry
+	styles   *styles.Styles
+	input    textinput.Model
+	active   bool
+	// actions are the fixed actions plus the entities loaded so far
+	actions  []Action
+	results  []Result
+	selected int
+	offset   int
+	loading  bool
+	err      error
+	// generation discards entities loaded for an earlier opening
+	generation int
+}
+
+// NewPalette creates a closed palette over registry
+func NewPalette(registry *Registry, s *styles.Styles) Palette {
+	input := textinput.New()
+	input.Prompt = "› "
+	input.Placeholder = "Type a command, form or contact…"
+	input.CharLimit = 100
+	input.Width = 56
+	return Palette{registry: registry, styles: s, input: input}
+}
+
+// Open shows the palette and starts loading the entity sources
+func (p *Palette) Open() tea.Cmd {
+	p.active = true
+	p.generation++
+	p.input.Reset()
+	p.actions = p.registry.Actions()
+	p.loading = true
+	p.err = nil
+	p.filter()
+
+	registry, generation := p.registry, p.generation
+	load := func() tea.Msg {
+		actions, err := registry.Load()
+		return entitiesLoadedMsg{generation: generation, actions: actions, err: err}
+	}
+	return tea.Batch(p.input.Focus(), load)
+}
+
+// Close hides the palette
+func (p *Palette) Close() {
+	p.active = false
+	p.input.Blur()
+}
+
+// Active reports whether the palette is open and capturing keys
+func (p *Palette) Active() bool {
+	return p.active
+}
+
+// Update handles a message while the palette is open and returns the
+// chosen action's command, if any
+func (p *Palette) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case entitiesLoadedMsg:
+		if msg.generation != p.generation {
+			return nil
+		}
+		p.loading = false
+		p.err = msg.err
+		p.actions = append(p.actions, msg.actions...)
+		p.filter()
+		return nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+k":
+			p.Close()
+			return nil
+		case "up", "ctrl+p":
+			p.move(-1)
+			return nil
+		case "down", "ctrl+n", "tab":
+			p.move(1)
+			return nil
+		case "pgup":
+			p.move(-paletteRows)
+			return nil
+		case "pgdown":
+			p.move(paletteRows)
+			return nil
+		case "enter":
+			if p.selected >= len(p.results) {
+				return nil
+			}
+			action := p.results[p.selected].Action
+			p.Close()
+			return action.Run
+		}
+
+		query := p.input.Value()
+		var cmd tea.Cmd
+		p.input, cmd = p.input.Update(msg)
+		if p.input.Value() != query {
+			p.filter()
+		}
+		return cmd
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return cmd
+}
+
+func (p *Palette) filter() {
+	p.results = Search(p.actions, p.input.Value())
+	p.selected = 0
+	p.offset = 0
+}
+
+func (p *Palette) move(step int) {
+	if len(p.results) == 0 {
+		return
+	}
+	p.selected = max(0, min(len(p.results)-1, p.selected+step))
+	if p.selected < p.offset {
+		p.offset = p.selected
+	}
+	if p.selected >= p.offset+paletteRows {
+		p.offset = p.selected - paletteRows + 1
+	}
+}
+
+// View renders the palette box
+func (p *Palette) View() string {
+	const width = 64
+
+	lines := []string{p.input.View(), ""}
+
+	end := min(len(p.results), p.offset+paletteRows)
+	for i := p.offset; i < end; i++ {
+		lines = append(lines, p.renderResult(p.results[i], i == p.selected, width-4))
+	}
+	if len(p.results) == 0 && !p.loading {
+		lines = append(lines, p.styles.Muted.Render("  No matches"))
+	}
+
+	var status []string
+	if len(p.results) > paletteRows {
+		status = append(status, fmt.Sprintf("%d–%d of %d", p.offset+1, end, len(p.results)))
+	}
+	if p.loading {
+		status = append(status, "loading forms and contacts…")
+	}
+	lines = append(lines, "")
+	if len(status) > 0 {
+		lines = append(lines, p.styles.Muted.Render(strings.Join(status, " • ")))
+	}
+	if p.err != nil {
+		// Sources fail alike when the database is down; show the first
+		msg := strings.SplitN(p.err.Error(), "\n", 2)[0]
+		lines = append(lines, p.styles.Error.Render("Some entities failed to load: "+msg))
+	}
+	lines = append(lines, p.styles.Help.Render("↑↓: Select • Enter: Run • Esc: Close"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(p.styles.Colors.Primary).
+		Padding(0, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (p *Palette) renderResult(result Result, selected bool, width int) string {
+	matched := make(map[int]bool, len(result.Positions))
+	for _, pos := range result.Positions {
+		matched[pos] = true
+	}
+
+	base := p.styles.Text
+	if selected {
+		base = lipgloss.NewStyle().Foreground(p.styles.Colors.Primary).Bold(true)
+	}
+	highlight := base.Foreground(p.styles.Colors.Primary).Bold(true).Underline(true)
+
+	var title strings.Builder
+	for i, r := range []rune(result.Action.Title) {
+		if matched[i] {
+			title.WriteString(highlight.Render(string(r)))
+		} else {
+			title.WriteString(base.Render(string(r)))
+		}
+	}
+
+	prefix := "  "
+	if selected {
+		prefix = base.Render("▶ ")
+	}
+	line := prefix + title.String()
+	if result.Action.Hint != "" {
+		line += " " + p.styles.Muted.Render(result.Action.Hint)
+	}
+
+	group := p.styles.Muted.Render(result.Action.Group)
+	gap := width - lipgloss.Width(line) - lipgloss.Width(group)
+	if gap < 1 {
+		// Drop the hint before the group when the line is too long
+		line = prefix + title.String()
+		gap = max(1, width-lipgloss.Width(line)-lipgloss.Width(group))
+	}
+	return line + strings.Repeat(" ", gap) + group
+}
+
+// Message types
+
+type entitiesLoadedMsg struct {
+	generation int
+	actions    []Action
+	err        error
+}
@@ -0,0 +1,110 @@
+// Package actions is the registry behind the ctrl+k command palette. The
+// app registers navigation, and views register their own actions and the
+// entities (forms, contacts) the palette can jump to.
+package actions
+
+import (
+	"errors"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Action is one palette entry
+type Action struct {
+	Title string
+	// Group is shown next to the title, e.g. "Forms" or "Navigation"
+	Group string
+	// Hint is searched and shown muted, e.g. a form ID or phone number
+	Hint     string
+	Keywords []string
+	// Run produces the message that performs the action
+	Run tea.Cmd
+}
+
+// Source loads actions for entities, such as one per form. Sources run
+// off the UI goroutine each time the palette opens.
+type Source func() ([]Action, error)
+
+// Registrar is implemented by views that add actions to the palette
+type Registrar interface {
+	RegisterActions(r *Registry)
+}
+
+// Registry holds the palette's actions and entity sources
+type Registry struct {
+	mu      sync.Mutex
+	actions []Action
+	sources []Source
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds fixed actions
+func (r *Registry) Register(actions ...Action) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.actions = append(r.actions, actions...)
+}
+
+// RegisterSource adds a source of entity actions
+func (r *Registry) RegisterSource(source Source) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = append(r.sources, source)
+}
+
+// Actions returns the fixed actions
+func (r *Registry) Actions() []Action {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Action(nil), r.actions...)
+}
+
+// Load runs every source. Actions from the sources that worked are
+// returned along with the errors of those that failed.
+func (r *Registry) Load() ([]Action, error) {
+	r.mu.Lock()
+	sources := append([]Source(nil), r.sources...)
+	r.mu.Unlock()
+
+	var (
+		loaded []Action
+		errs   []error
+	)
+	for _, source := range sources {
+		actions, err := source()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		loaded = append(loaded, actions...)
+	}
+	return loaded, errors.Join(errs...)
+}
+
+// Message types
+
+// NavigateMsg asks the app to switch to a view. Data, if set, is passed
+// to the view after switching.
+type NavigateMsg struct {
+	View  View
+	Title string
+	Data  tea.Msg
+}
+
+// Navigate returns a command that switches to a view
+func Navigate(view View, title string, data tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return NavigateMsg{View: view, Title: title, Data: data}
+	}
+}
+
+// Send returns a command that produces msg
+func Send(msg tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return msg
+	}
+}
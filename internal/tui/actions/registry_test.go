@@ -0,0 +1,35 @@
+package actions
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegistryLoadKeepsWorkingSources(t *testing.T) {
+	r := NewRegistry()
+	r.Register(Action{Title: "Quit"})
+	r.RegisterSource(func() ([]Action, error) {
+		return []Action{{Title: "Form contact"}, {Title: "Form quote"}}, nil
+	})
+	failed := errors.New("d1 unreachable")
+	r.RegisterSource(func() ([]Action, error) { return nil, failed })
+
+	if actions := r.Actions(); len(actions) != 1 || actions[0].Title != "Quit" {
+		t.Errorf("Actions = %+v, want only the fixed action", actions)
+	}
+	loaded, err := r.Load()
+	if !errors.Is(err, failed) {
+		t.Errorf("Load error = %v, want the failed source's error", err)
+	}
+	if len(loaded) != 2 {
+		t.Errorf("Load returned %d actions, want the working source's 2", len(loaded))
+	}
+}
+
+func TestNavigate(t *testing.T) {
+	msg := Navigate(ViewForms, "Forms", "contact")()
+	nav, ok := msg.(NavigateMsg)
+	if !ok || nav.View != ViewForms || nav.Title != "Forms" || nav.Data != "contact" {
+		t.Errorf("Navigate produced %#v", msg)
+	}
+}
@@ -0,0 +1,26 @@
+package actions
+
+// View identifies a screen of the app. The IDs live here, next to
+// NavigateMsg, so views can navigate to each other without importing the
+// app.
+type View int
+
+const (
+	ViewDashboard View = iota
+	ViewForms
+	ViewFormCreate
+	ViewFormEdit
+	ViewContacts
+	ViewContactCreate
+	ViewContactEdit
+	ViewWebhook
+	ViewSettings
+	ViewDoctor
+	ViewDeliveries
+	ViewQueue
+	ViewSubmissions
+	ViewLeads
+	ViewAnalytics
+	ViewLogs
+	ViewMonitor
+)
@@ -2,30 +2,55 @@ package tui
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/actions"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/analytics"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/dashboard"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/deliveries"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/doctor"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/forms"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/leads"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/logs"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/monitor"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/queue"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/contacts"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/webhook"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/settings"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/submissions"
 )
 
-type View int
+// View identifies a screen; the IDs are shared with the views through
+// the actions package
+type View = actions.View
 
 const (
-	ViewDashboard View = iota
-	ViewForms
-	ViewFormCreate
-	ViewFormEdit
-	ViewContacts
-	ViewContactCreate
-	ViewContactEdit
-	ViewWebhook
-	ViewSettings
+	ViewDashboard     = actions.ViewDashboard
+	ViewForms         = actions.ViewForms
+	ViewFormCreate    = actions.ViewFormCreate
+	ViewFormEdit      = actions.ViewFormEdit
+	ViewContacts      = actions.ViewContacts
+	ViewContactCreate = actions.ViewContactCreate
+	ViewContactEdit   = actions.ViewContactEdit
+	ViewWebhook       = actions.ViewWebhook
+	ViewSettings      = actions.ViewSettings
+	ViewDoctor        = actions.ViewDoctor
+	ViewDeliveries    = actions.ViewDeliveries
+	ViewQueue         = actions.ViewQueue
+	ViewSubmissions   = actions.ViewSubmissions
+	ViewLeads         = actions.ViewLeads
+	ViewAnalytics     = actions.ViewAnalytics
+	ViewLogs          = actions.ViewLogs
+	ViewMonitor       = actions.ViewMonitor
 )
 
 type Model struct {
@@ -37,33 +62,134 @@ type Model struct {
 	styles      *styles.Styles
 	breadcrumbs []string
 	err         error
+	actions     *actions.Registry
+	palette     actions.Palette
+	keys        *keys.Map
+	// showHelp draws the ? overlay listing the current view's keys
+	showHelp bool
+	// transition counts down the frames revealing a view just switched to
+	transition   int
+	transitionID int
+	// refreshedAt is when the scheduler last reloaded the current view
+	refreshedAt time.Time
 }
 
+// A switched-to view is revealed top to bottom over transitionFrames
+const (
+	transitionFrames = 4
+	transitionFrame  = 30 * time.Millisecond
+)
+
+// reducedMotionFPS caps redraws in reduced motion mode
+const reducedMotionFPS = 10
+
+// clockInterval paces the auto-refresh checks and the "updated Xs ago"
+// footer
+const clockInterval = time.Second
+
 func NewModel(cfg *config.Config) *Model {
 	s := styles.NewStyles(cfg.UI.Theme)
+	// Unknown bindings are reported by config validate
+	km, _ := keys.New(cfg.UI.VimBindings, cfg.UI.Keys)
 	
 	m := &Model{
 		config:      cfg,
 		currentView: ViewDashboard,
 		styles:      s,
+		keys:        km,
 		breadcrumbs: []string{"Dashboard"},
 		views:       make(map[View]tea.Model),
 	}
 
 	// Initialize views
-	m.views[ViewDashboard] = dashboard.New(cfg, s)
-	m.views[ViewForms] = forms.NewListView(cfg, s)
+	m.views[ViewDashboard] = dashboard.New(cfg, s, km)
+	m.views[ViewForms] = forms.NewListView(cfg, s, km)
 	m.views[ViewFormCreate] = forms.NewCreateView(cfg, s)
 	// Note: FormEditView is created dynamically with form ID
-	m.views[ViewContacts] = contacts.NewListView(cfg, s)
+	m.views[ViewContacts] = contacts.NewListView(cfg, s, km)
 	m.views[ViewContactCreate] = contacts.NewCreateView(cfg, s)
 	// Note: ContactEditView is created dynamically with contact ID
 	m.views[ViewWebhook] = webhook.New(cfg, s)
-	m.views[ViewSettings] = settings.New(cfg, s)
+	m.views[ViewSettings] = settings.New(cfg, s, km)
+	m.views[ViewDoctor] = doctor.New(cfg, s, km)
+	m.views[ViewDeliveries] = deliveries.New(cfg, s, km)
+	m.views[ViewQueue] = queue.New(cfg, s, km)
+	m.views[ViewSubmissions] = submissions.New(cfg, s, km)
+	m.views[ViewLeads] = leads.New(cfg, s, km)
+	m.views[ViewAnalytics] = analytics.New(cfg, s, km)
+	m.views[ViewLogs] = logs.New(cfg, s, km)
+	m.views[ViewMonitor] = monitor.New(cfg, s, km)
+
+	// Command palette: navigation and profiles here, the rest from views
+	m.actions = actions.NewRegistry()
+	m.registerActions()
+	for view := ViewDashboard; view <= ViewMonitor; view++ {
+		if registrar, ok := m.views[view].(actions.Registrar); ok {
+			registrar.RegisterActions(m.actions)
+		}
+	}
+	m.palette = actions.NewPalette(m.actions, s)
 
 	return m
 }
 
+// viewTitles names the views the palette can navigate to
+var viewTitles = []struct {
+	View  View
+	Title string
+}{
+	{ViewDashboard, "Dashboard"},
+	{ViewForms, "Forms"},
+	{ViewContacts, "Contacts"},
+	{ViewWebhook, "Test Webhook"},
+	{ViewSettings, "Settings"},
+	{ViewDoctor, "Doctor"},
+	{ViewDeliveries, "Deliveries"},
+	{ViewQueue, "Queue"},
+	{ViewSubmissions, "Inbox"},
+	{ViewLeads, "Leads"},
+	{ViewAnalytics, "Analytics"},
+	{ViewLogs, "Live Tail"},
+	{ViewMonitor, "Monitoring"},
+}
+
+// registerActions adds the app-wide palette actions
+func (m *Model) registerActions() {
+	for _, v := range viewTitles {
+		m.actions.Register(actions.Action{
+			Title: "Go to " + v.Title,
+			Group: "Navigation",
+			Run:   actions.Navigate(v.View, v.Title, nil),
+		})
+	}
+
+	profiles := []string{""}
+	for name := range m.config.Profiles {
+		profiles = append(profiles, name)
+	}
+	sort.Strings(profiles[1:])
+	for _, name := range profiles {
+		label := name
+		if label == "" {
+			label = "default"
+		}
+		m.actions.Register(actions.Action{
+			Title:    "Switch profile to " + label,
+			Group:    "Profile",
+			Hint:     "this session",
+			Keywords: []string{"environment"},
+			Run:      actions.Send(profileSelectedMsg{Name: name}),
+		})
+	}
+
+	m.actions.Register(actions.Action{
+		Title: "Quit",
+		Group: "App",
+		Hint:  "exit ewctl",
+		Run:   tea.Quit,
+	})
+}
+
 func (m *Model) Init() tea.Cmd {
 	// Initialize all views
 	var cmds []tea.Cmd
@@ -72,12 +198,24 @@ func (m *Model) Init() tea.Cmd {
 			cmds = append(cmds, v.Init())
 		}
 	}
+	cmds = append(cmds, clock())
 	return tea.Batch(cmds...)
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// The open palette takes every key; other messages still reach views
+	if m.palette.Active() {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			if key.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, m.palette.Update(msg)
+		}
+		cmds = append(cmds, m.palette.Update(msg))
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -98,41 +236,54 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
+		// The help overlay closes on the next key
+		if m.showHelp {
+			m.showHelp = false
+			if key.Matches(msg, m.keys.Global.Quit) && m.currentView == ViewDashboard {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		// The command palette opens from every view
+		if key.Matches(msg, m.keys.Global.Palette) && !m.typing(msg) {
+			return m, m.palette.Open()
+		}
+		if key.Matches(msg, m.keys.Global.Help) && !m.typing(msg) {
+			if _, ok := m.views[m.currentView].(help.KeyMap); ok {
+				m.showHelp = true
+				return m, nil
+			}
+		}
 
 		// Dashboard navigation only works from dashboard
 		if m.currentView == ViewDashboard {
-			switch msg.String() {
-			case "q":
+			if key.Matches(msg, m.keys.Global.Quit) {
 				return m, tea.Quit
-			case "1":
-				cmd := m.switchView(ViewDashboard, "Dashboard")
-				cmds = append(cmds, cmd)
-			case "2":
-				cmd := m.switchView(ViewForms, "Forms")
-				cmds = append(cmds, cmd)
-			case "3":
-				cmd := m.switchView(ViewContacts, "Contacts")
-				cmds = append(cmds, cmd)
-			case "4":
-				cmd := m.switchView(ViewWebhook, "Test Webhook")
-				cmds = append(cmds, cmd)
-			case "5":
-				cmd := m.switchView(ViewSettings, "Settings")
-				cmds = append(cmds, cmd)
-			default:
-				// Pass other keys to dashboard
-				if currentView, ok := m.views[m.currentView].(tea.Model); ok {
-					updated, cmd := currentView.Update(msg)
-					m.views[m.currentView] = updated
-					cmds = append(cmds, cmd)
+			}
+			for _, shortcut := range m.shortcuts() {
+				if key.Matches(msg, *shortcut.key) {
+					return m, m.switchView(shortcut.view, shortcut.title)
 				}
 			}
+			// Pass other keys to dashboard
+			if currentView, ok := m.views[m.currentView].(tea.Model); ok {
+				updated, cmd := currentView.Update(msg)
+				m.views[m.currentView] = updated
+				cmds = append(cmds, cmd)
+			}
 		} else {
 			// For non-dashboard views, let them handle keys first
 			// Only handle ESC as a global "go back" if the view doesn't handle it
-			if msg.String() == "esc" {
+			if key.Matches(msg, m.keys.Global.Back) {
 				// Try to pass to current view first
 				if currentView, ok := m.views[m.currentView].(tea.Model); ok {
+					// A dialog inside the view gets Esc to close itself
+					if modal, ok := currentView.(modalView); ok && modal.HasModal() {
+						updated, cmd := currentView.Update(msg)
+						m.views[m.currentView] = updated
+						return m, cmd
+					}
+
 					updated, cmd := currentView.Update(msg)
 					m.views[m.currentView] = updated
 					
@@ -166,6 +317,49 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case actions.NavigateMsg:
+		cmds = append(cmds, m.switchView(msg.View, msg.Title))
+		if msg.Data != nil {
+			if currentView, ok := m.views[m.currentView].(tea.Model); ok {
+				updated, cmd := currentView.Update(msg.Data)
+				m.views[m.currentView] = updated
+				cmds = append(cmds, cmd)
+			}
+		}
+
+	case tea.MouseMsg:
+		// Overlays take no mouse input
+		if m.palette.Active() || m.showHelp || !m.config.UI.Mouse {
+			break
+		}
+		// Views get positions relative to their own output
+		msg.X -= m.styles.Content.GetPaddingLeft()
+		msg.Y -= lipgloss.Height(m.renderHeader()) + m.styles.Content.GetPaddingTop()
+		if msg.Y < 0 {
+			break
+		}
+		if currentView, ok := m.views[m.currentView].(tea.Model); ok {
+			updated, cmd := currentView.Update(msg)
+			m.views[m.currentView] = updated
+			cmds = append(cmds, cmd)
+		}
+
+	case clockMsg:
+		cmds = append(cmds, clock(), m.autoRefresh(time.Time(msg)))
+
+	case transitionMsg:
+		if msg.ID == m.transitionID && m.transition > 0 {
+			m.transition--
+			if m.transition > 0 {
+				cmds = append(cmds, m.nextFrame())
+			}
+		}
+
+	case profileSelectedMsg:
+		if err := m.config.SetProfile(msg.Name); err != nil {
+			m.err = err
+		}
+
 	case forms.SwitchToCreateMsg:
 		// Switch to form create view
 		cmd := m.switchView(ViewFormCreate, "Create Form")
@@ -173,7 +367,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case forms.SwitchToEditMsg:
 		// Create and switch to form edit view
-		m.views[ViewFormEdit] = forms.NewEditView(m.config, m.styles, msg.FormID)
+		m.views[ViewFormEdit] = forms.NewEditView(m.config, m.styles, m.keys, msg.FormID)
 		cmd := m.switchView(ViewFormEdit, "Edit Form")
 		// Initialize the edit view
 		if editView, ok := m.views[ViewFormEdit].(tea.Model); ok {
@@ -201,7 +395,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case contacts.SwitchToEditMsg:
 		// Create and switch to contact edit view
-		m.views[ViewContactEdit] = contacts.NewEditView(m.config, m.styles, msg.ContactID)
+		m.views[ViewContactEdit] = contacts.NewEditView(m.config, m.styles, m.keys, msg.ContactID)
 		cmd := m.switchView(ViewContactEdit, "Edit Contact")
 		// Initialize the edit view
 		if editView, ok := m.views[ViewContactEdit].(tea.Model); ok {
@@ -222,6 +416,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
+	case settings.ConfigSavedMsg:
+		// Views share the config and styles, so the new theme applies
+		// everywhere on the next render
+		*m.styles = *styles.NewStyles(msg.Config.UI.Theme)
+		km, _ := keys.New(msg.Config.UI.VimBindings, msg.Config.UI.Keys)
+		*m.keys = *km
+		if msg.Config.UI.Mouse {
+			cmds = append(cmds, tea.EnableMouseCellMotion)
+		} else {
+			cmds = append(cmds, tea.DisableMouse)
+		}
+
 	case error:
 		m.err = msg
 		return m, nil
@@ -247,6 +453,18 @@ func (m *Model) View() string {
 	if currentView, ok := m.views[m.currentView].(tea.Model); ok {
 		content = currentView.View()
 	}
+	if m.transition > 0 {
+		lines := strings.Split(content, "\n")
+		shown := len(lines) * (transitionFrames - m.transition + 1) / (transitionFrames + 1)
+		content = strings.Join(lines[:shown], "\n")
+	}
+	if m.palette.Active() {
+		content = lipgloss.Place(m.width, lipgloss.Height(content), lipgloss.Center, lipgloss.Top,
+			"\n"+m.palette.View())
+	} else if m.showHelp {
+		content = lipgloss.Place(m.width, lipgloss.Height(content), lipgloss.Center, lipgloss.Top,
+			"\n"+m.renderHelp())
+	}
 
 	// Build the full view with header and footer
 	header := m.renderHeader()
@@ -288,7 +506,7 @@ func (m *Model) renderHeader() string {
 	left := lipgloss.JoinVertical(lipgloss.Top, title, breadcrumbs)
 	
 	// Status info (right side)
-	status := fmt.Sprintf("Profile: %s", "default")
+	status := fmt.Sprintf("Profile: %s", m.config.ProfileName())
 	right := m.styles.StatusBar.Render(status)
 
 	// Join left and right with proper spacing
@@ -301,30 +519,114 @@ func (m *Model) renderHeader() string {
 }
 
 func (m *Model) renderFooter() string {
+	global := []key.Binding{m.keys.Global.Palette, m.keys.Global.Help, m.keys.Global.Back}
+	if m.currentView == ViewDashboard {
+		global = []key.Binding{m.keys.Global.Palette, m.keys.Global.Help, m.keys.Global.Quit}
+	}
+
 	var help string
-	
-	switch m.currentView {
-	case ViewDashboard:
-		help = "1-5: Navigate • ?: Help • q: Quit"
-	case ViewForms:
-		help = "↑↓/jk: Navigate • n: New • e: Edit • d: Delete • Enter: Select • Esc: Back"
-	case ViewFormCreate, ViewFormEdit:
-		help = "Tab: Next Field • Enter: Submit • Esc: Cancel"
-	case ViewContacts:
-		help = "↑↓/jk: Navigate • a: Add • e: Edit • d: Delete • Enter: View • Esc: Back"
-	case ViewContactCreate, ViewContactEdit:
-		help = "Tab: Next Field • Enter: Submit • Esc: Cancel"
-	case ViewWebhook:
-		help = "Tab: Next Field • Enter: Send • Esc: Back"
-	case ViewSettings:
-		help = "↑↓: Navigate • Enter: Edit • s: Save • Esc: Back"
-	default:
-		help = "?: Help • Esc: Back • q: Quit"
+	if view, ok := m.views[m.currentView].(shortHelper); ok {
+		help = keys.Help(append(view.ShortHelp(), global...)...)
+	} else {
+		// Forms handle their own keys
+		cancel := keys.Help(keys.Describe(m.keys.Global.Back, "Cancel"))
+		switch m.currentView {
+		case ViewWebhook:
+			help = "Tab: Next Field • Enter: Send • " + keys.Help(m.keys.Global.Back)
+		case ViewFormEdit, ViewContactEdit:
+			help = "Tab: Next Field • Enter: Submit • " + keys.Help(keys.Describe(m.keys.Edit.NextTab, "History")) + " • " + cancel
+		default:
+			help = "Tab: Next Field • Enter: Submit • " + cancel
+		}
+	}
+
+	// How old the data on screen is, on the right
+	if view, ok := m.views[m.currentView].(refresher); ok && !view.UpdatedAt().IsZero() {
+		updated := "updated " + ago(time.Since(view.UpdatedAt()))
+		gap := m.width - m.styles.Footer.GetHorizontalFrameSize() - lipgloss.Width(help) - lipgloss.Width(updated)
+		if gap >= 2 {
+			help += strings.Repeat(" ", gap) + updated
+		}
 	}
 
 	return m.styles.Footer.Width(m.width).Render(help)
 }
 
+// ago formats a duration as "12s ago", "5m ago" or "2h ago"
+func ago(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+}
+
+// renderHelp draws the ? overlay: every binding of the current view in
+// columns, then the global keys
+func (m *Model) renderHelp() string {
+	view, ok := m.views[m.currentView].(help.KeyMap)
+	if !ok {
+		return ""
+	}
+	global := []key.Binding{m.keys.Global.Palette, m.keys.Global.Help, m.keys.Global.Back}
+	if m.currentView == ViewDashboard {
+		global = []key.Binding{m.keys.Global.Palette, m.keys.Global.Help, m.keys.Global.Quit}
+	}
+	groups := append(view.FullHelp(), global)
+
+	var columns []string
+	for _, group := range groups {
+		var bindings []key.Binding
+		width := 0
+		for _, b := range group {
+			if b.Enabled() {
+				bindings = append(bindings, b)
+				width = max(width, lipgloss.Width(b.Help().Key))
+			}
+		}
+		if len(bindings) == 0 {
+			continue
+		}
+		var lines []string
+		for _, b := range bindings {
+			k := m.styles.Label.Width(width).Render(b.Help().Key)
+			lines = append(lines, k+"  "+m.styles.Text.Render(b.Help().Desc))
+		}
+		columns = append(columns, lipgloss.JoinVertical(lipgloss.Left, lines...))
+	}
+
+	// Lay the columns out in rows that fit the window
+	maxWidth := max(m.width-8, 40)
+	var rows, row []string
+	rowWidth := 0
+	for _, column := range columns {
+		width := lipgloss.Width(column) + 4
+		if len(row) > 0 && rowWidth+width > maxWidth {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...), "")
+			row, rowWidth = nil, 0
+		}
+		row = append(row, lipgloss.NewStyle().PaddingRight(4).Render(column))
+		rowWidth += width
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+
+	title := "Keys • " + m.breadcrumbs[len(m.breadcrumbs)-1]
+	if m.config.UI.VimBindings {
+		title += " (vim)"
+	}
+	lines := append([]string{m.styles.Subtitle.Render(title)}, rows...)
+	lines = append(lines, "", m.styles.Help.Render("Press any key to close"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Colors.Primary).
+		Padding(0, 2).
+		Render(strings.Join(lines, "\n"))
+}
+
 func (m *Model) renderError() string {
 	errorView := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
 	help := m.styles.Help.Render("Press any key to continue...")
@@ -344,8 +646,26 @@ func (m *Model) switchView(view View, title string) tea.Cmd {
 	if view != ViewDashboard {
 		m.breadcrumbs = append(m.breadcrumbs, title)
 	}
-	
-	// Send activation message to the view when switching
+
+	var transition tea.Cmd
+	if m.config.UI.Motion() {
+		m.transition = transitionFrames
+		m.transitionID++
+		transition = m.nextFrame()
+	}
+	return tea.Batch(transition, m.activate(view))
+}
+
+// nextFrame schedules the next frame of the view transition
+func (m *Model) nextFrame() tea.Cmd {
+	id := m.transitionID
+	return tea.Tick(transitionFrame, func(time.Time) tea.Msg {
+		return transitionMsg{ID: id}
+	})
+}
+
+// activate tells a view it was switched to, so it can load its data
+func (m *Model) activate(view View) tea.Cmd {
 	switch view {
 	case ViewForms:
 		if formsView, ok := m.views[ViewForms].(*forms.ListView); ok {
@@ -355,11 +675,126 @@ func (m *Model) switchView(view View, title string) tea.Cmd {
 		if contactsView, ok := m.views[ViewContacts].(*contacts.ListView); ok {
 			return contactsView.StartLoading()
 		}
+	case ViewDoctor:
+		if doctorView, ok := m.views[ViewDoctor].(*doctor.Model); ok {
+			return doctorView.StartLoading()
+		}
+	case ViewDeliveries:
+		if deliveriesView, ok := m.views[ViewDeliveries].(*deliveries.Model); ok {
+			return deliveriesView.StartLoading()
+		}
+	case ViewQueue:
+		if queueView, ok := m.views[ViewQueue].(*queue.Model); ok {
+			return queueView.StartLoading()
+		}
+	case ViewSubmissions:
+		if submissionsView, ok := m.views[ViewSubmissions].(*submissions.Model); ok {
+			return submissionsView.StartLoading()
+		}
+	case ViewLogs:
+		if logsView, ok := m.views[ViewLogs].(*logs.Model); ok {
+			return logsView.StartLoading()
+		}
+	case ViewAnalytics:
+		if analyticsView, ok := m.views[ViewAnalytics].(*analytics.Model); ok {
+			return analyticsView.StartLoading()
+		}
+	case ViewMonitor:
+		if monitorView, ok := m.views[ViewMonitor].(*monitor.Model); ok {
+			return monitorView.StartLoading()
+		}
+	case ViewLeads:
+		if leadsView, ok := m.views[ViewLeads].(*leads.Model); ok {
+			return leadsView.StartLoading()
+		}
 	}
 	
 	return nil
 }
 
+// autoRefresh reloads the current view in the background once its data
+// is ui.auto_refresh old. It holds off while the user is busy: a form
+// being edited, a dialog or search box, the palette or the help overlay.
+func (m *Model) autoRefresh(now time.Time) tea.Cmd {
+	interval := m.config.UI.AutoRefresh
+	if interval <= 0 || m.palette.Active() || m.showHelp {
+		return nil
+	}
+	// Form views do not refresh, so editing a form always pauses it
+	view, ok := m.views[m.currentView].(refresher)
+	if !ok {
+		return nil
+	}
+	if modal, ok := view.(modalView); ok && modal.HasModal() {
+		return nil
+	}
+	updated := view.UpdatedAt()
+	if updated.IsZero() || now.Sub(updated) < interval || now.Sub(m.refreshedAt) < interval {
+		return nil
+	}
+	m.refreshedAt = now
+	return view.Refresh()
+}
+
+// refresher is implemented by views the scheduler reloads every
+// ui.auto_refresh
+type refresher interface {
+	// Refresh reloads the data without replacing the view with a spinner,
+	// highlighting what changed
+	Refresh() tea.Cmd
+	// UpdatedAt is when the data on screen was loaded
+	UpdatedAt() time.Time
+}
+
+// shortHelper is implemented by views whose footer lists their keys
+type shortHelper interface {
+	ShortHelp() []key.Binding
+}
+
+// typing reports whether msg is text for the current view's input, so
+// bindings like ? or vim's : are typed instead of acting
+func (m *Model) typing(msg tea.KeyMsg) bool {
+	if msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace {
+		return false
+	}
+	switch m.currentView {
+	case ViewFormCreate, ViewFormEdit, ViewContactCreate, ViewContactEdit, ViewWebhook:
+		return true
+	}
+	modal, ok := m.views[m.currentView].(modalView)
+	return ok && modal.HasModal()
+}
+
+// shortcut is a dashboard key that opens a view
+type shortcut struct {
+	key   *key.Binding
+	view  View
+	title string
+}
+
+func (m *Model) shortcuts() []shortcut {
+	return []shortcut{
+		{&m.keys.Go.Forms, ViewForms, "Forms"},
+		{&m.keys.Go.Contacts, ViewContacts, "Contacts"},
+		{&m.keys.Go.Webhook, ViewWebhook, "Test Webhook"},
+		{&m.keys.Go.Settings, ViewSettings, "Settings"},
+		{&m.keys.Go.Doctor, ViewDoctor, "Doctor"},
+		{&m.keys.Go.Deliveries, ViewDeliveries, "Deliveries"},
+		{&m.keys.Go.Queue, ViewQueue, "Queue"},
+		{&m.keys.Go.Inbox, ViewSubmissions, "Inbox"},
+		{&m.keys.Go.Leads, ViewLeads, "Leads"},
+		{&m.keys.Go.Analytics, ViewAnalytics, "Analytics"},
+		{&m.keys.Go.Logs, ViewLogs, "Live Tail"},
+		{&m.keys.Go.Monitor, ViewMonitor, "Monitoring"},
+	}
+}
+
+// modalView is implemented by views that can open a dialog which must
+// receive Esc instead of the app navigating back
+type modalView interface {
+	HasModal() bool
+}
+
 // Message types
 type SwitchViewMsg struct {
 	View  View
@@ -367,18 +802,48 @@ type SwitchViewMsg struct {
 	Data  interface{}
 }
 
+// profileSelectedMsg switches the active profile from the palette
+type profileSelectedMsg struct {
+	Name string
+}
+
+// clockMsg ticks every clockInterval
+type clockMsg time.Time
+
+func clock() tea.Cmd {
+	return tea.Tick(clockInterval, func(t time.Time) tea.Msg {
+		return clockMsg(t)
+	})
+}
+
+// transitionMsg advances the view transition
+type transitionMsg struct {
+	ID int
+}
+
 // Run starts the TUI application
 func Run(cfg *config.Config) error {
 	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return fmt.Errorf("invalid configuration: %w (run ewctl init to set it up)", err)
 	}
+	// Broken theme files are reported by config validate
+	_ = LoadThemes()
 
-	m := NewModel(cfg)
-	
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	return run(cfg, NewModel(cfg))
+}
+
+func run(cfg *config.Config, m *Model) error {
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
 	if cfg.UI.Mouse {
-		p = tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	if cfg.UI.ReducedMotion {
+		opts = append(opts, tea.WithFPS(reducedMotionFPS))
 	}
+	// Ask the terminal for its background now: once the program reads
+	// input the answer would be taken for a key press
+	styles.HasDarkBackground()
+	p := tea.NewProgram(m, opts...)
 	
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("failed to run TUI: %w", err)
@@ -413,7 +878,11 @@ func RunWebhookTestView(cfg *config.Config, formID string) error {
 	return Run(cfg)
 }
 
+// RunConfigEditView opens the settings editor directly. It does not
+// require a valid configuration, so it can be used to fix one.
 func RunConfigEditView(cfg *config.Config) error {
-	// TODO: Implement direct config edit view
-	return Run(cfg)
+	m := NewModel(cfg)
+	m.currentView = ViewSettings
+	m.breadcrumbs = []string{"Dashboard", "Settings"}
+	return run(cfg, m)
 }
\ No newline at end of file
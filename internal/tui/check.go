@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+// LoadThemes registers the theme files in config.ThemesDir so they can be
+// chosen by name. The files that could not be loaded are joined in the
+// error; the others are registered anyway.
+func LoadThemes() error {
+	dir, err := config.ThemesDir()
+	if err != nil {
+		return err
+	}
+	themes, err := styles.LoadThemes(dir)
+	styles.Register(themes)
+	return err
+}
+
+// CheckUI reports the ui settings config.Check leaves to the TUI: unknown
+// themes and key bindings, and the theme files LoadThemes skipped
+func CheckUI(cfg *config.Config, themesErr error) []config.Problem {
+	var problems []config.Problem
+	warn := func(key, format string, args ...interface{}) {
+		problems = append(problems, config.Problem{Key: key, Message: fmt.Sprintf(format, args...), Warning: true})
+	}
+
+	names := styles.Names()
+	if theme := cfg.UI.Theme; theme != "" {
+		if _, ok := styles.Find(theme); !ok {
+			warn("ui.theme", "%q is not a theme (choose %s); using charm", theme, strings.Join(names, ", "))
+		}
+	}
+	if themesErr != nil {
+		for _, line := range strings.Split(themesErr.Error(), "\n") {
+			warn("ui.theme", "theme file skipped: %s", line)
+		}
+	}
+	for _, name := range keys.Unknown(cfg.UI.Keys) {
+		warn("ui.keys."+name, "is not a key binding; it is ignored")
+	}
+	return problems
+}
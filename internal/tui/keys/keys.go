@@ -0,0 +1,366 @@
+// Package keys is the TUI's keymap. Every view matches keys against the
+// shared Map instead of literal strings, so the footer, the ? overlay and
+// the user's overrides in config.yaml all describe the keys that work.
+package keys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// Map holds every binding, grouped by the views that use them
+type Map struct {
+	Global     GlobalKeys
+	Go         GoKeys
+	List       ListKeys
+	Item       ItemKeys
+	Filter     FilterKeys
+	Edit       EditKeys
+	Doctor     DoctorKeys
+	Deliveries DeliveryKeys
+	Queue      QueueKeys
+	Logs       LogKeys
+	Leads      LeadKeys
+	Contacts   ContactKeys
+}
+
+// GlobalKeys work in every view
+type GlobalKeys struct {
+	Quit    key.Binding
+	Back    key.Binding
+	Help    key.Binding
+	Palette key.Binding
+}
+
+// GoKeys open views from the dashboard
+type GoKeys struct {
+	Forms      key.Binding
+	Contacts   key.Binding
+	Webhook    key.Binding
+	Settings   key.Binding
+	Doctor     key.Binding
+	Deliveries key.Binding
+	Queue      key.Binding
+	Inbox      key.Binding
+	Leads      key.Binding
+	Analytics  key.Binding
+	Logs       key.Binding
+	Monitor    key.Binding
+}
+
+// ListKeys move through lists, tables and tabs
+type ListKeys struct {
+	Up           key.Binding
+	Down         key.Binding
+	Left         key.Binding
+	Right        key.Binding
+	PageUp       key.Binding
+	PageDown     key.Binding
+	HalfPageUp   key.Binding
+	HalfPageDown key.Binding
+	Top          key.Binding
+	Bottom       key.Binding
+	Open         key.Binding
+	Select       key.Binding
+	NextTab      key.Binding
+	PrevTab      key.Binding
+}
+
+// ItemKeys act on the selected item or the whole view
+type ItemKeys struct {
+	New          key.Binding
+	Edit         key.Binding
+	Delete       key.Binding
+	Undo         key.Binding
+	Archive      key.Binding
+	ShowArchived key.Binding
+	Pause        key.Binding
+	Refresh      key.Binding
+	Reload       key.Binding
+	Save         key.Binding
+	Search       key.Binding
+	Export       key.Binding
+}
+
+// FilterKeys narrow what a view shows
+type FilterKeys struct {
+	Form   key.Binding
+	Time   key.Binding
+	Status key.Binding
+	Clear  key.Binding
+}
+
+// EditKeys are used by the form and contact editors
+type EditKeys struct {
+	NextTab key.Binding
+}
+
+type DoctorKeys struct {
+	Fix    key.Binding
+	FixAll key.Binding
+}
+
+type DeliveryKeys struct {
+	SelectAll     key.Binding
+	Resend        key.Binding
+	ResendCurrent key.Binding
+}
+
+type QueueKeys struct {
+	Requeue    key.Binding
+	RequeueAll key.Binding
+}
+
+type LogKeys struct {
+	Pause key.Binding
+	Clear key.Binding
+}
+
+type ContactKeys struct {
+	SelectAll       key.Binding
+	Sort            key.Binding
+	AddToForms      key.Binding
+	RemoveFromForms key.Binding
+	Export          key.Binding
+}
+
+type LeadKeys struct {
+	MoveBack    key.Binding
+	MoveForward key.Binding
+	Note        key.Binding
+	FollowUp    key.Binding
+	Owner       key.Binding
+}
+
+// spec describes one binding: its name in config.yaml, its help text and
+// its keys in the default and vim presets
+type spec struct {
+	section string
+	name    string
+	binding *key.Binding
+	help    string
+	keys    []string
+	// vim replaces keys in the vim preset; nil keeps them
+	vim []string
+}
+
+func (m *Map) specs() []spec {
+	return []spec{
+		{"global", "quit", &m.Global.Quit, "Quit", []string{"q"}, nil},
+		{"global", "back", &m.Global.Back, "Back", []string{"esc"}, nil},
+		{"global", "help", &m.Global.Help, "Help", []string{"?"}, nil},
+		{"global", "palette", &m.Global.Palette, "Commands", []string{"ctrl+k"}, []string{"ctrl+k", ":"}},
+
+		{"go", "forms", &m.Go.Forms, "Forms", []string{"2"}, nil},
+		{"go", "contacts", &m.Go.Contacts, "Contacts", []string{"3"}, nil},
+		{"go", "webhook", &m.Go.Webhook, "Test webhook", []string{"4"}, nil},
+		{"go", "settings", &m.Go.Settings, "Settings", []string{"5"}, nil},
+		{"go", "doctor", &m.Go.Doctor, "Doctor", []string{"6"}, nil},
+		{"go", "deliveries", &m.Go.Deliveries, "Deliveries", []string{"7"}, nil},
+		{"go", "queue", &m.Go.Queue, "Queue", []string{"8"}, nil},
+		{"go", "inbox", &m.Go.Inbox, "Inbox", []string{"9"}, nil},
+		{"go", "leads", &m.Go.Leads, "Leads", []string{"0"}, nil},
+		{"go", "analytics", &m.Go.Analytics, "Analytics", []string{"a"}, nil},
+		{"go", "logs", &m.Go.Logs, "Live tail", []string{"l"}, nil},
+		{"go", "monitor", &m.Go.Monitor, "Monitoring", []string{"m"}, nil},
+
+		{"list", "up", &m.List.Up, "Up", []string{"up", "k"}, nil},
+		{"list", "down", &m.List.Down, "Down", []string{"down", "j"}, nil},
+		{"list", "left", &m.List.Left, "Left", []string{"left", "h"}, nil},
+		{"list", "right", &m.List.Right, "Right", []string{"right", "l"}, nil},
+		{"list", "page_up", &m.List.PageUp, "Page up", []string{"pgup"}, []string{"pgup", "ctrl+b"}},
+		{"list", "page_down", &m.List.PageDown, "Page down", []string{"pgdown"}, []string{"pgdown", "ctrl+f"}},
+		{"list", "half_page_up", &m.List.HalfPageUp, "Half page up", nil, []string{"ctrl+u"}},
+		{"list", "half_page_down", &m.List.HalfPageDown, "Half page down", nil, []string{"ctrl+d"}},
+		{"list", "top", &m.List.Top, "Top", []string{"home"}, []string{"home", "g"}},
+		{"list", "bottom", &m.List.Bottom, "Bottom", []string{"end", "G"}, nil},
+		{"list", "open", &m.List.Open, "Open", []string{"enter"}, nil},
+		{"list", "select", &m.List.Select, "Select", []string{" "}, nil},
+		{"list", "next_tab", &m.List.NextTab, "Next tab", []string{"tab"}, nil},
+		{"list", "prev_tab", &m.List.PrevTab, "Previous tab", []string{"shift+tab"}, nil},
+
+		{"item", "new", &m.Item.New, "New", []string{"a", "n"}, nil},
+		{"item", "edit", &m.Item.Edit, "Edit", []string{"e"}, nil},
+		{"item", "delete", &m.Item.Delete, "Delete", []string{"d"}, nil},
+		{"item", "undo", &m.Item.Undo, "Undo", []string{"u"}, nil},
+		{"item", "archive", &m.Item.Archive, "Archive", []string{"A"}, nil},
+		{"item", "show_archived", &m.Item.ShowArchived, "Show archived", []string{"v"}, nil},
+		{"item", "pause", &m.Item.Pause, "Pause", []string{"p"}, nil},
+		{"item", "refresh", &m.Item.Refresh, "Refresh", []string{"r"}, nil},
+		{"item", "reload", &m.Item.Reload, "Reload", []string{"ctrl+r"}, nil},
+		{"item", "save", &m.Item.Save, "Save", []string{"s"}, nil},
+		{"item", "search", &m.Item.Search, "Search", []string{"/"}, nil},
+		{"item", "export", &m.Item.Export, "Export", []string{"e"}, nil},
+
+		{"filter", "form", &m.Filter.Form, "Form", []string{"f"}, nil},
+		{"filter", "time", &m.Filter.Time, "Time range", []string{"t"}, nil},
+		{"filter", "status", &m.Filter.Status, "Status", []string{"s"}, nil},
+		{"filter", "clear", &m.Filter.Clear, "Clear filters", []string{"x"}, nil},
+
+		{"edit", "next_tab", &m.Edit.NextTab, "Next tab", []string{"ctrl+t"}, nil},
+
+		{"doctor", "fix", &m.Doctor.Fix, "Fix", []string{"f"}, nil},
+		{"doctor", "fix_all", &m.Doctor.FixAll, "Fix all", []string{"F"}, nil},
+
+		{"deliveries", "select_all", &m.Deliveries.SelectAll, "Select all", []string{"a"}, nil},
+		{"deliveries", "resend", &m.Deliveries.Resend, "Resend", []string{"r"}, nil},
+		{"deliveries", "resend_current", &m.Deliveries.ResendCurrent, "Resend to current", []string{"R"}, nil},
+
+		{"queue", "requeue", &m.Queue.Requeue, "Requeue", []string{"r"}, nil},
+		{"queue", "requeue_all", &m.Queue.RequeueAll, "Requeue all", []string{"R"}, nil},
+
+		{"logs", "pause", &m.Logs.Pause, "Pause", []string{" ", "p"}, nil},
+		{"logs", "clear", &m.Logs.Clear, "Clear", []string{"c"}, nil},
+
+		{"leads", "move_back", &m.Leads.MoveBack, "Move back", []string{"<", ","}, nil},
+		{"leads", "move_forward", &m.Leads.MoveForward, "Move forward", []string{">", "."}, nil},
+		{"leads", "note", &m.Leads.Note, "Note", []string{"n"}, nil},
+		{"leads", "follow_up", &m.Leads.FollowUp, "Follow-up", []string{"d"}, nil},
+		{"leads", "owner", &m.Leads.Owner, "Owner", []string{"o"}, nil},
+
+		{"contacts", "select_all", &m.Contacts.SelectAll, "Select all", []string{"*"}, nil},
+		{"contacts", "sort", &m.Contacts.Sort, "Sort", []string{"s"}, nil},
+		{"contacts", "add_to_forms", &m.Contacts.AddToForms, "Add to forms", []string{"+"}, nil},
+		{"contacts", "remove_from_forms", &m.Contacts.RemoveFromForms, "Remove from forms", []string{"-"}, nil},
+		{"contacts", "export", &m.Contacts.Export, "Export", []string{"E"}, nil},
+	}
+}
+
+// New builds the keymap from the default or vim preset, then applies
+// overrides, which map a section and binding name to its keys, e.g.
+// overrides["item"]["delete"] = []string{"x"}. An empty list disables the
+// binding. Unknown names are reported in the error and otherwise ignored,
+// so the returned map is always usable.
+func New(vim bool, overrides map[string]map[string][]string) (*Map, error) {
+	m := &Map{}
+	for _, s := range m.specs() {
+		keys := s.keys
+		if vim && s.vim != nil {
+			keys = s.vim
+		}
+		if custom, ok := overrides[s.section][s.name]; ok {
+			keys = custom
+		}
+		*s.binding = newBinding(keys, s.help)
+	}
+
+	if unknown := Unknown(overrides); len(unknown) > 0 {
+		return m, fmt.Errorf("unknown key bindings: %s", strings.Join(unknown, ", "))
+	}
+	return m, nil
+}
+
+// Names lists every binding as "section.name"
+func Names() []string {
+	var names []string
+	for _, s := range (&Map{}).specs() {
+		names = append(names, s.section+"."+s.name)
+	}
+	return names
+}
+
+// Unknown returns the overrides that name no binding, sorted
+func Unknown(overrides map[string]map[string][]string) []string {
+	known := make(map[string]bool)
+	for _, name := range Names() {
+		known[name] = true
+	}
+
+	var unknown []string
+	for section, bindings := range overrides {
+		for name := range bindings {
+			if !known[section+"."+name] {
+				unknown = append(unknown, section+"."+name)
+			}
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Table returns the bindings a bubbles table moves its cursor with
+func (m *Map) Table() table.KeyMap {
+	return table.KeyMap{
+		LineUp:       m.List.Up,
+		LineDown:     m.List.Down,
+		PageUp:       m.List.PageUp,
+		PageDown:     m.List.PageDown,
+		HalfPageUp:   m.List.HalfPageUp,
+		HalfPageDown: m.List.HalfPageDown,
+		GotoTop:      m.List.Top,
+		GotoBottom:   m.List.Bottom,
+	}
+}
+
+func newBinding(keys []string, help string) key.Binding {
+	if len(keys) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(Label(keys...), help))
+}
+
+// keyLabels are the symbols shown for keys in help
+var keyLabels = map[string]string{
+	"up":        "↑",
+	"down":      "↓",
+	"left":      "←",
+	"right":     "→",
+	" ":         "space",
+	"enter":     "Enter",
+	"esc":       "Esc",
+	"tab":       "Tab",
+	"shift+tab": "Shift+Tab",
+}
+
+// Label renders keys the way help shows them, e.g. "↑/k"
+func Label(keys ...string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		if label, ok := keyLabels[k]; ok {
+			labels[i] = label
+		} else {
+			labels[i] = k
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// Join combines bindings into one help entry labelled with the first key
+// of each, e.g. up and down into "↑/↓ navigate". Disabled bindings are
+// left out.
+func Join(help string, bindings ...key.Binding) key.Binding {
+	var keys, first []string
+	for _, b := range bindings {
+		if b.Enabled() {
+			keys = append(keys, b.Keys()...)
+			first = append(first, b.Keys()[0])
+		}
+	}
+	if len(keys) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(Label(first...), help))
+}
+
+// Describe returns b with another help text, for views that give a key a
+// more specific meaning, e.g. archive as "Restore" in the archived list
+func Describe(b key.Binding, help string) key.Binding {
+	b.SetHelp(b.Help().Key, help)
+	return b
+}
+
+// Help renders bindings as a help line, e.g. "n: New • d: Delete".
+// Disabled bindings are left out.
+func Help(bindings ...key.Binding) string {
+	var parts []string
+	for _, b := range bindings {
+		if b.Enabled() {
+			parts = append(parts, b.Help().Key+": "+b.Help().Desc)
+		}
+	}
+	return strings.Join(parts, " • ")
+}
@@ -0,0 +1,92 @@
+package keys
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+func TestNewPresetsAndOverrides(t *testing.T) {
+	m, err := New(false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.List.Top.Keys(); strings.Join(got, ",") != "home" {
+		t.Errorf("default top = %v", got)
+	}
+	if m.List.HalfPageDown.Enabled() {
+		t.Error("half page down is enabled without the vim preset")
+	}
+
+	m, err = New(true, map[string]map[string][]string{
+		"item": {"delete": {"x"}, "undo": {}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.List.Top.Keys(); strings.Join(got, ",") != "home,g" {
+		t.Errorf("vim top = %v", got)
+	}
+	if got := m.List.Up.Keys(); strings.Join(got, ",") != "up,k" {
+		t.Errorf("vim preset changed up to %v, want the default kept", got)
+	}
+	if got := m.Item.Delete.Keys(); strings.Join(got, ",") != "x" || m.Item.Delete.Help().Key != "x" {
+		t.Errorf("overridden delete = %v labelled %q", got, m.Item.Delete.Help().Key)
+	}
+	if m.Item.Undo.Enabled() {
+		t.Error("an empty override left undo enabled")
+	}
+}
+
+func TestNewReportsUnknownOverrides(t *testing.T) {
+	m, err := New(false, map[string]map[string][]string{
+		"item":  {"delete": {"x"}, "destroy": {"X"}},
+		"bogus": {"quit": {"Q"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "bogus.quit, item.destroy") {
+		t.Errorf("New = %v, want the unknown bindings sorted", err)
+	}
+	if m == nil || strings.Join(m.Item.Delete.Keys(), ",") != "x" {
+		t.Error("the known overrides were not applied alongside the error")
+	}
+}
+
+func TestNamesAreUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, name := range Names() {
+		if seen[name] {
+			t.Errorf("binding %s is defined twice", name)
+		}
+		seen[name] = true
+	}
+	if !seen["global.quit"] || !seen["contacts.export"] {
+		t.Error("Names is missing bindings")
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	if got := Label("up", "k", " ", "shift+tab"); got != "↑/k/space/Shift+Tab" {
+		t.Errorf("Label = %q", got)
+	}
+
+	up := key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "Up"))
+	down := key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "Down"))
+	off := key.NewBinding(key.WithDisabled())
+
+	nav := Join("navigate", up, off, down)
+	if nav.Help().Key != "↑/↓" || strings.Join(nav.Keys(), ",") != "up,k,down,j" {
+		t.Errorf("Join = %q with keys %v", nav.Help().Key, nav.Keys())
+	}
+	if Join("nothing", off).Enabled() {
+		t.Error("Join of disabled bindings is enabled")
+	}
+
+	restore := Describe(up, "Restore")
+	if restore.Help().Desc != "Restore" || up.Help().Desc != "Up" {
+		t.Error("Describe should only change the copy it returns")
+	}
+	if got := Help(up, off, restore); got != "↑/k: Up • ↑/k: Restore" {
+		t.Errorf("Help = %q", got)
+	}
+}
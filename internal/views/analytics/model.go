@@ -0,0 +1,373 @@
+package analytics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	metrics "github.com/thalysguimaraes/elementor-whatsapp/internal/analytics"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+// topN caps the rows of each bar chart
+const topN = 8
+
+// timeRange is a preset span and the bucket width it is charted with
+type timeRange struct {
+	label  string
+	span   time.Duration
+	bucket metrics.Bucket
+}
+
+var timeRanges = []timeRange{
+	{"Last 24 hours", 24 * time.Hour, metrics.Hour},
+	{"Last 7 days", 7 * 24 * time.Hour, metrics.Day},
+	{"Last 30 days", 30 * 24 * time.Hour, metrics.Day},
+	{"Last 12 weeks", 12 * 7 * 24 * time.Hour, metrics.Week},
+}
+
+// Model charts submissions and deliveries over time
+type Model struct {
+	config  *config.Config
+	styles  *styles.Styles
+	keys    *keys.Map
+	spinner spinner.Model
+	db      *database.Client
+	report  *metrics.Report
+	forms   []string
+	// formFilter indexes forms; -1 covers every form
+	formFilter int
+	rangeIndex int
+	loading    bool
+	err        error
+	width      int
+	height     int
+}
+
+func New(cfg *config.Config, s *styles.Styles, km *keys.Map) *Model {
+	sp := components.NewSpinner(cfg, s)
+
+	// Create database client
+	db, err := database.NewClient(cfg)
+	if err != nil {
+		log.Error("Failed to create database client", "error", err)
+	}
+
+	return &Model{
+		config:     cfg,
+		styles:     s,
+		keys:       km,
+		spinner:    sp,
+		db:         db,
+		formFilter: -1,
+		rangeIndex: 1,
+		err:        err,
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// StartLoading computes the report when the view becomes active
+func (m *Model) StartLoading() tea.Cmd {
+	if m.loading || m.db == nil {
+		return nil
+	}
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, m.load())
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Filter.Time):
+			m.rangeIndex = (m.rangeIndex + 1) % len(timeRanges)
+			m.loading = true
+			return m, m.load()
+		case key.Matches(msg, m.keys.Filter.Form):
+			// Cycle through the forms, then back to every form
+			m.formFilter++
+			if m.formFilter >= len(m.forms) {
+				m.formFilter = -1
+			}
+			m.loading = true
+			return m, m.load()
+		case key.Matches(msg, m.keys.Item.Refresh):
+			m.loading = true
+			return m, m.load()
+		}
+
+	case ReportLoadedMsg:
+		m.loading = false
+		m.err = msg.Error
+		if msg.Error == nil {
+			m.report = msg.Report
+			m.forms = msg.Forms
+		}
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) View() string {
+	if m.err != nil {
+		return m.renderError()
+	}
+
+	title := m.styles.Title.Render("📈 Analytics")
+	if m.report == nil {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "", m.spinner.View()+" Computing metrics...")
+	}
+
+	filters := m.styles.Muted.Render(fmt.Sprintf("%s, per %s • Form: %s",
+		timeRanges[m.rangeIndex].label, m.report.Bucket, m.formLabel()))
+	if m.loading {
+		filters += " " + m.spinner.View()
+	}
+
+	parts := []string{
+		title,
+		filters,
+		"",
+		m.renderCounters(),
+		"",
+		m.renderSeries(),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, m.renderForms(), "    ", m.renderRecipients()),
+		"",
+		m.styles.Help.Render(keys.Help(m.keys.Filter.Time, m.keys.Filter.Form, m.keys.Item.Refresh, m.keys.Global.Back)),
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Top, parts...)
+}
+
+// ShortHelp lists the keys shown in the footer
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		m.keys.Filter.Time,
+		m.keys.Filter.Form,
+		m.keys.Item.Refresh,
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.Filter.Time, m.keys.Filter.Form, m.keys.Item.Refresh},
+	}
+}
+
+func (m *Model) renderCounters() string {
+	counter := func(label, value string, style lipgloss.Style) string {
+		return lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(m.styles.Colors.Border).
+			Padding(0, 2).
+			MarginRight(1).
+			Render(lipgloss.JoinVertical(lipgloss.Left,
+				m.styles.Muted.Render(label),
+				style.Render(value),
+			))
+	}
+
+	report := m.report
+	rateStyle := m.styles.Success
+	switch {
+	case report.SuccessRate < 0:
+		rateStyle = m.styles.Muted
+	case report.SuccessRate < 0.9:
+		rateStyle = m.styles.Error
+	case report.SuccessRate < 0.99:
+		rateStyle = m.styles.Warning
+	}
+
+	latency := "-"
+	if report.Latency.Samples > 0 {
+		latency = fmt.Sprintf("%dms / %dms", report.Latency.P50, report.Latency.P95)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		counter("Submissions", fmt.Sprintf("%d", report.Submissions), m.styles.Info),
+		counter("Deliveries", fmt.Sprintf("%d", report.Deliveries), m.styles.Text),
+		counter("Success rate", formatRate(report.SuccessRate), rateStyle),
+		counter("Latency p50 / p95", latency, m.styles.Text),
+	)
+}
+
+func (m *Model) renderSeries() string {
+	series := m.report.Series
+	// Keep the most recent buckets that fit the screen
+	if width := m.width - 24; width > 0 && len(series) > width {
+		series = series[len(series)-width:]
+	}
+
+	submissions := make([]float64, len(series))
+	deliveries := make([]float64, len(series))
+	rates := make([]float64, len(series))
+	for i, point := range series {
+		submissions[i] = float64(point.Submissions)
+		deliveries[i] = float64(point.Deliveries)
+		rates[i] = point.SuccessRate()
+	}
+
+	row := func(label string, values []float64, style lipgloss.Style) string {
+		return fmt.Sprintf("%s %s", m.styles.Label.Render(fmt.Sprintf("%-14s", label)), style.Render(components.Sparkline(values)))
+	}
+
+	lines := []string{
+		m.styles.Subtitle.Render("Over time"),
+		row("Submissions", submissions, m.styles.Info),
+		row("Deliveries", deliveries, m.styles.Text),
+		row("Success rate", rates, m.styles.Success),
+	}
+	if len(series) > 0 {
+		layout := "Jan 02"
+		if m.report.Bucket == metrics.Hour {
+			layout = "Jan 02 15:04"
+		}
+		lines = append(lines, m.styles.Muted.Render(fmt.Sprintf("%-14s %s → %s", "",
+			series[0].Start.Format(layout), series[len(series)-1].Start.Format(layout))))
+	}
+	return lipgloss.JoinVertical(lipgloss.Top, lines...)
+}
+
+func (m *Model) renderForms() string {
+	lines := []string{m.styles.Subtitle.Render("Deliveries by form")}
+	if len(m.report.ByForm) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Top, append(lines, m.styles.Muted.Render("No deliveries"))...)
+	}
+
+	top := m.report.ByForm[0].Count
+	for i, volume := range m.report.ByForm {
+		if i == topN {
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%-20s %s %d",
+			truncate(volume.Name, 20), m.styles.Info.Render(fmt.Sprintf("%-20s", components.Bar(volume.Count, top, 20))), volume.Count))
+	}
+	return lipgloss.JoinVertical(lipgloss.Top, lines...)
+}
+
+func (m *Model) renderRecipients() string {
+	lines := []string{m.styles.Subtitle.Render("Messages by recipient")}
+	if len(m.report.ByRecipient) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Top, append(lines, m.styles.Muted.Render("No messages"))...)
+	}
+
+	top := 0
+	for _, volume := range m.report.ByRecipient {
+		top = max(top, volume.Sent+volume.Failed)
+	}
+	for i, volume := range m.report.ByRecipient {
+		if i == topN {
+			break
+		}
+		sent := components.Bar(volume.Sent, top, 20)
+		failed := components.Bar(volume.Failed, top, 20)
+		bar := m.styles.Success.Render(sent) + m.styles.Error.Render(failed)
+		padding := max(20-lipgloss.Width(sent+failed), 0)
+		lines = append(lines, fmt.Sprintf("%-16s %s%*s %d/%d", volume.Phone, bar, padding, "", volume.Sent, volume.Failed))
+	}
+	lines = append(lines, m.styles.Muted.Render("sent/failed"))
+	return lipgloss.JoinVertical(lipgloss.Top, lines...)
+}
+
+func (m *Model) renderError() string {
+	errorView := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
+	help := m.styles.Help.Render(fmt.Sprintf("Press '%s' to retry", m.keys.Item.Refresh.Help().Key))
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		errorView,
+		help,
+	)
+}
+
+func (m *Model) formLabel() string {
+	if m.formFilter < 0 || m.formFilter >= len(m.forms) {
+		return "all"
+	}
+	return m.forms[m.formFilter]
+}
+
+func (m *Model) load() tea.Cmd {
+	preset := timeRanges[m.rangeIndex]
+	opts := metrics.Options{
+		Since:  time.Now().Add(-preset.span),
+		Bucket: preset.bucket,
+	}
+	if m.formFilter >= 0 && m.formFilter < len(m.forms) {
+		opts.FormID = m.forms[m.formFilter]
+	}
+
+	return func() tea.Msg {
+		if m.db == nil {
+			return ReportLoadedMsg{Error: fmt.Errorf("database client not initialized")}
+		}
+
+		report, err := metrics.Compute(m.db, opts)
+		if err != nil {
+			return ReportLoadedMsg{Error: err}
+		}
+
+		forms, err := m.db.GetAllForms()
+		if err != nil {
+			return ReportLoadedMsg{Error: err}
+		}
+		var formIDs []string
+		for _, form := range forms {
+			formIDs = append(formIDs, form.ID)
+		}
+
+		return ReportLoadedMsg{Report: report, Forms: formIDs}
+	}
+}
+
+func formatRate(rate float64) string {
+	if rate < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", rate*100)
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// Message types
+type ReportLoadedMsg struct {
+	Report *metrics.Report
+	Forms  []string
+	Error  error
+}
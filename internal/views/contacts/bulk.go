@@ -0,0 +1,316 @@
+package contacts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+)
+
+// formPicker asks which forms a bulk add or remove applies to
+type formPicker struct {
+	form    *huh.Form
+	add     bool
+	forms   []string
+	targets []database.ContactWithStats
+}
+
+// toggleSelected selects or deselects a contact
+func (m *ListView) toggleSelected(contact database.ContactWithStats) {
+	if _, ok := m.selected[contact.ID]; ok {
+		delete(m.selected, contact.ID)
+		return
+	}
+	m.selected[contact.ID] = contact
+}
+
+// selectAll selects every visible contact, or clears the selection when
+// they all are selected
+func (m *ListView) selectAll() {
+	all := len(m.visible) > 0
+	for _, contact := range m.visible {
+		if _, ok := m.selected[contact.ID]; !ok {
+			all = false
+			break
+		}
+	}
+	if all {
+		m.selected = make(map[int]database.ContactWithStats)
+		return
+	}
+	for _, contact := range m.visible {
+		m.selected[contact.ID] = contact
+	}
+}
+
+// targets are the selected contacts by name, or the one under the cursor.
+// The selection survives searches, so it can hold hidden contacts.
+func (m *ListView) targets() []database.ContactWithStats {
+	if len(m.selected) == 0 {
+		if contact, ok := m.current(); ok {
+			return []database.ContactWithStats{contact}
+		}
+		return nil
+	}
+	targets := make([]database.ContactWithStats, 0, len(m.selected))
+	for _, contact := range m.selected {
+		targets = append(targets, contact)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return strings.ToLower(targets[i].Name) < strings.ToLower(targets[j].Name)
+	})
+	return targets
+}
+
+// names lists the first contacts by name for a dialog
+func names(contacts []database.ContactWithStats) []string {
+	const shown = 5
+	var list []string
+	for i, contact := range contacts {
+		if i == shown {
+			list = append(list, fmt.Sprintf("and %d more", len(contacts)-shown))
+			break
+		}
+		list = append(list, contact.Name)
+	}
+	return list
+}
+
+// pickForms opens the form picker for the targets. Removing only offers
+// the forms one of them is on.
+func (m *ListView) pickForms(add bool) tea.Cmd {
+	targets := m.targets()
+	if len(targets) == 0 {
+		return nil
+	}
+	on := make(map[string]bool)
+	for _, contact := range targets {
+		for _, id := range contact.FormIDs {
+			on[id] = true
+		}
+	}
+
+	var options []huh.Option[string]
+	for _, form := range m.forms {
+		if add || on[form.ID] {
+			options = append(options, huh.NewOption(fmt.Sprintf("%s (%s)", form.Name, form.ID), form.ID))
+		}
+	}
+	if len(options) == 0 {
+		m.status = "No forms to choose from"
+		if !add {
+			m.status = "The contacts are not on any form"
+		}
+		return nil
+	}
+
+	title := fmt.Sprintf("Add %d contacts to forms", len(targets))
+	if !add {
+		title = fmt.Sprintf("Remove %d contacts from forms", len(targets))
+	}
+	p := &formPicker{add: add, targets: targets}
+	p.form = huh.NewForm(huh.NewGroup(
+		huh.NewMultiSelect[string]().
+			Title(title).
+			Description("Space toggles a form, Enter applies, Esc cancels").
+			Options(options...).
+			Value(&p.forms),
+	)).WithTheme(m.styles.Form()).WithWidth(60).WithShowHelp(false)
+	m.picker = p
+	m.status = ""
+	return p.form.Init()
+}
+
+// updatePicker routes a message to the open form picker
+func (m *ListView) updatePicker(msg tea.Msg) tea.Cmd {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && key.Matches(keyMsg, m.keys.Global.Back) {
+		m.picker = nil
+		return nil
+	}
+	form, cmd := m.picker.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.picker.form = f
+	}
+	switch m.picker.form.State {
+	case huh.StateCompleted:
+		p := m.picker
+		m.picker = nil
+		if len(p.forms) == 0 {
+			return nil
+		}
+		m.loading = true
+		return m.linkContacts(p.add, p.forms, p.targets)
+	case huh.StateAborted:
+		m.picker = nil
+		return nil
+	}
+	return cmd
+}
+
+// linkContacts adds the contacts to or removes them from every form
+func (m *ListView) linkContacts(add bool, forms []string, targets []database.ContactWithStats) tea.Cmd {
+	contacts := make([]database.Contact, len(targets))
+	ids := make([]int, len(targets))
+	for i, target := range targets {
+		contacts[i] = target.Contact
+		ids[i] = target.ID
+	}
+	return func() tea.Msg {
+		msg := ContactsLinkedMsg{Add: add, Contacts: contacts, Changed: make(map[string][]int)}
+		for _, formID := range forms {
+			var changed []int
+			var err error
+			if add {
+				changed, err = m.db.AddContactsToForm(formID, contacts)
+			} else {
+				changed, err = m.db.RemoveContactsFromForm(formID, ids)
+			}
+			if len(changed) > 0 {
+				msg.Changed[formID] = changed
+			}
+			if err != nil {
+				msg.Error = err
+				break
+			}
+		}
+		return msg
+	}
+}
+
+// unlinkContacts reverses a bulk add or remove for undo
+func (m *ListView) unlinkContacts(msg ContactsLinkedMsg) func() error {
+	byID := make(map[int]database.Contact, len(msg.Contacts))
+	for _, contact := range msg.Contacts {
+		byID[contact.ID] = contact
+	}
+	return func() error {
+		for formID, ids := range msg.Changed {
+			var err error
+			if msg.Add {
+				_, err = m.db.RemoveContactsFromForm(formID, ids)
+			} else {
+				contacts := make([]database.Contact, 0, len(ids))
+				for _, id := range ids {
+					contacts = append(contacts, byID[id])
+				}
+				_, err = m.db.AddContactsToForm(formID, contacts)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// deleteContacts deletes the contacts, snapshotting each for undo
+func (m *ListView) deleteContacts(targets []database.ContactWithStats) tea.Cmd {
+	return func() tea.Msg {
+		var msg ContactsDeletedMsg
+		for _, contact := range targets {
+			snapshot, err := m.db.SnapshotContact(contact.ID)
+			if err != nil {
+				msg.Error = err
+				break
+			}
+			if err := m.db.DeleteContact(contact.ID); err != nil {
+				msg.Error = err
+				break
+			}
+			msg.Snapshots = append(msg.Snapshots, snapshot)
+		}
+		return msg
+	}
+}
+
+// exportFileName suggests a file name for the export
+func (m *ListView) exportFileName() string {
+	return fmt.Sprintf("contacts-%s.csv", time.Now().Format("2006-01-02"))
+}
+
+// export writes the targets, or every visible contact when nothing is
+// selected, as CSV or JSON depending on the extension
+func (m *ListView) export(path string) tea.Cmd {
+	contacts := m.visible
+	if len(m.selected) > 0 {
+		contacts = m.targets()
+	}
+	return func() tea.Msg {
+		var (
+			data []byte
+			err  error
+		)
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			data, err = database.ContactsCSV(contacts)
+		case ".json":
+			data, err = json.MarshalIndent(contacts, "", "  ")
+		default:
+			return ContactsExportedMsg{Path: path, Error: fmt.Errorf("unknown format, use a .csv or .json file")}
+		}
+		if err != nil {
+			return ContactsExportedMsg{Path: path, Error: err}
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return ContactsExportedMsg{Path: path, Error: fmt.Errorf("failed to write %s: %w", path, err)}
+		}
+		return ContactsExportedMsg{Path: path, Count: len(contacts)}
+	}
+}
+
+// linkedSummary describes the outcome of a bulk add or remove
+func linkedSummary(msg ContactsLinkedMsg) string {
+	links := 0
+	for _, ids := range msg.Changed {
+		links += len(ids)
+	}
+	switch {
+	case links == 0 && msg.Add:
+		return "The contacts were already on those forms"
+	case links == 0:
+		return "The contacts were not on those forms"
+	case msg.Add:
+		return fmt.Sprintf("Added %d recipients to %d forms", links, len(msg.Changed))
+	}
+	return fmt.Sprintf("Removed %d recipients from %d forms", links, len(msg.Changed))
+}
+
+// restoreContacts restores deleted contacts from their snapshots
+func (m *ListView) restoreContacts(snapshots []*database.ContactSnapshot) func() error {
+	return func() error {
+		for _, snapshot := range snapshots {
+			if err := m.db.RestoreContact(snapshot); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Message types
+type ContactsDeletedMsg struct {
+	Snapshots []*database.ContactSnapshot
+	Error     error
+}
+
+type ContactsLinkedMsg struct {
+	Add      bool
+	Contacts []database.Contact
+	// Changed maps each form to the contacts added to or removed from it
+	Changed map[string][]int
+	Error   error
+}
+
+type ContactsExportedMsg struct {
+	Path  string
+	Count int
+	Error error
+}
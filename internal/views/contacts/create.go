@@ -110,7 +110,7 @@ func (v *CreateView) buildForm() {
 		),
 	)
 
-	v.form.WithTheme(huh.ThemeCharm())
+	v.form.WithTheme(v.styles.Form())
 	v.form.WithWidth(60)
 }
 
@@ -3,23 +3,33 @@ package contacts
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
 )
 
+// Tabs of the edit view, switched with ctrl+t
+var editTabs = []string{"Edit", "History"}
+
 type EditView struct {
 	config          *config.Config
 	styles          *styles.Styles
+	keys            *keys.Map
 	db              *database.Client
 	form            *huh.Form
 	contactData     ContactData
 	originalContact *database.Contact
+	history         components.History
+	showHistory     bool // history tab is shown instead of the form
 	err             error
 	width           int
 	height          int
@@ -27,7 +37,7 @@ type EditView struct {
 	loading         bool
 }
 
-func NewEditView(cfg *config.Config, s *styles.Styles, contactID int) *EditView {
+func NewEditView(cfg *config.Config, s *styles.Styles, km *keys.Map, contactID int) *EditView {
 	// Create database client
 	db, err := database.NewClient(cfg)
 	if err != nil {
@@ -35,6 +45,7 @@ func NewEditView(cfg *config.Config, s *styles.Styles, contactID int) *EditView
 		return &EditView{
 			config: cfg,
 			styles: s,
+			keys:   km,
 			err:    err,
 		}
 	}
@@ -42,7 +53,9 @@ func NewEditView(cfg *config.Config, s *styles.Styles, contactID int) *EditView
 	v := &EditView{
 		config:  cfg,
 		styles:  s,
+		keys:    km,
 		db:      db,
+		history: components.NewHistory(s, km),
 		loading: true,
 	}
 
@@ -62,6 +75,13 @@ func (v *EditView) loadContactData(contactID int) {
 	}
 	v.originalContact = contact
 
+	// Load the audit trail for the history tab
+	v.history.SetEntries(v.db.GetAuditEntries(database.AuditFilter{
+		EntityType: database.AuditContact,
+		EntityID:   strconv.Itoa(contactID),
+		Limit:      50,
+	}))
+
 	// Convert to ContactData
 	v.contactData.Name = contact.Name
 	v.contactData.PhoneNumber = contact.PhoneNumber
@@ -131,7 +151,7 @@ func (v *EditView) buildForm() {
 		),
 	)
 
-	v.form.WithTheme(huh.ThemeCharm())
+	v.form.WithTheme(v.styles.Form())
 	v.form.WithWidth(60)
 }
 
@@ -163,14 +183,41 @@ func (v *EditView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		v.width = msg.Width
 		v.height = msg.Height
+		v.history.SetHeight(v.height - 12)
+
+	case tea.MouseMsg:
+		if components.Clicked(msg) {
+			tab := 0
+			if v.showHistory {
+				tab = 1
+			}
+			if clicked := components.TabAt(v.View(), v.styles, editTabs, tab, msg.X, msg.Y); clicked >= 0 {
+				v.showHistory = clicked == 1
+				return v, nil
+			}
+		}
+		if step := components.Wheel(msg); step != 0 && v.showHistory {
+			v.history.Scroll(step)
+			return v, nil
+		}
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
+		switch {
+		case key.Matches(msg, v.keys.Global.Back):
 			// Go back to list view
 			return v, func() tea.Msg {
 				return GoBackToListMsg{}
 			}
+		case key.Matches(msg, v.keys.Edit.NextTab):
+			// Switch between the edit form and its history
+			v.showHistory = !v.showHistory
+			return v, nil
+		}
+
+		// The history tab takes every other key for scrolling
+		if v.showHistory {
+			v.history.Update(msg)
+			return v, nil
 		}
 
 	case ContactUpdatedMsg:
@@ -215,15 +262,29 @@ func (v *EditView) View() string {
 
 	title := v.styles.Title.Render(fmt.Sprintf("✏️ Edit Contact: %s", v.contactData.Name))
 	
-	// Form view
+	// Form view, or the history tab
+	tab := 0
 	formView := v.form.View()
-
-	// Help text
-	help := v.styles.Help.Render("Tab: Next Field • Shift+Tab: Previous • Enter: Submit • Esc: Cancel")
+	// Tab and Enter belong to the form itself
+	help := v.styles.Help.Render("Tab: Next Field • Shift+Tab: Previous • Enter: Submit • " + keys.Help(
+		keys.Describe(v.keys.Edit.NextTab, "History"),
+		keys.Describe(v.keys.Global.Back, "Cancel"),
+	))
+	if v.showHistory {
+		tab = 1
+		formView = v.history.View()
+		help = v.styles.Help.Render(keys.Help(
+			keys.Join("Scroll", v.keys.List.Up, v.keys.List.Down),
+			keys.Describe(v.keys.Edit.NextTab, "Back to Edit"),
+			keys.Describe(v.keys.Global.Back, "Cancel"),
+		))
+	}
+	tabs := components.RenderTabs(v.styles, editTabs, tab)
 
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
 		title,
+		tabs,
 		"",
 		formView,
 		"",
@@ -2,33 +2,109 @@ package contacts
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 	
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/actions"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
 )
 
 type ListView struct {
 	config   *config.Config
 	styles   *styles.Styles
+	keys     *keys.Map
 	table    table.Model
 	spinner  spinner.Model
 	db       *database.Client
 	contacts []database.ContactWithStats
+	// visible are the contacts left by the filter, in sort order; table
+	// rows index into it
+	visible  []database.ContactWithStats
+	forms    []database.FormWithStats
+	// archived switches the list to archived contacts
+	archived bool
+	// search narrows the contacts as it is typed
+	search    textinput.Model
+	searching bool
+	// searchID discards searches overtaken by further typing
+	searchID  int
+	// filter is filterAll, filterUnassigned or filterForms plus the
+	// index of a form
+	filter    int
+	// sortBy indexes sortOrders
+	sortBy    int
+	// selected holds the contacts bulk actions apply to, by ID
+	selected  map[int]database.ContactWithStats
+	// picker chooses the forms of a bulk add or remove
+	picker    *formPicker
+	// exportPath asks where to export the contacts
+	exportPath textinput.Model
+	exporting  bool
+	status     string
+	confirm  components.Confirm
+	undo     components.Undo
+	// changes highlights contacts a background refresh added or changed
+	changes  components.Changes
 	loading  bool
+	// refreshing is a background reload that keeps the table on screen
+	refreshing bool
+	updatedAt  time.Time
 	err      error
 	width    int
 	height   int
 }
 
-func NewListView(cfg *config.Config, s *styles.Styles) *ListView {
+// searchDelay is how long typing pauses before the search runs
+const searchDelay = 250 * time.Millisecond
+
+// Filters are cycled with the form filter key: every contact, those on
+// no form, then each form in turn
+const (
+	filterAll = iota
+	filterUnassigned
+	filterForms
+)
+
+// sortOrders are the orders the sort key cycles through. Ties keep the
+// database's order, by name.
+var sortOrders = []struct {
+	label string
+	less  func(a, b database.ContactWithStats) bool
+}{
+	{"name", func(a, b database.ContactWithStats) bool {
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	}},
+	{"company", func(a, b database.ContactWithStats) bool {
+		// Contacts without a company go last
+		if (a.Company == "") != (b.Company == "") {
+			return b.Company == ""
+		}
+		return strings.ToLower(a.Company) < strings.ToLower(b.Company)
+	}},
+	{"forms", func(a, b database.ContactWithStats) bool {
+		return a.FormCount > b.FormCount
+	}},
+	{"newest", func(a, b database.ContactWithStats) bool {
+		return a.CreatedAt.After(b.CreatedAt)
+	}},
+}
+
+func NewListView(cfg *config.Config, s *styles.Styles, km *keys.Map) *ListView {
 	// Create table with empty data initially
 	columns := []table.Column{
+		{Title: "", Width: 3},
 		{Title: "Name", Width: 25},
 		{Title: "Phone", Width: 20},
 		{Title: "Company", Width: 25},
@@ -56,9 +132,16 @@ func NewListView(cfg *config.Config, s *styles.Styles) *ListView {
 	t.SetStyles(tableStyle)
 	
 	// Create spinner
-	sp := spinner.New()
-	sp.Spinner = spinner.Dot
-	sp.Style = s.Spinner
+	sp := components.NewSpinner(cfg, s)
+	
+	search := textinput.New()
+	search.Placeholder = "name, company, phone..."
+	search.Prompt = "/ "
+	search.CharLimit = 100
+	
+	exportPath := textinput.New()
+	exportPath.Prompt = "Export to: "
+	exportPath.CharLimit = 255
 	
 	// Create database client
 	db, err := database.NewClient(cfg)
@@ -69,9 +152,15 @@ func NewListView(cfg *config.Config, s *styles.Styles) *ListView {
 	return &ListView{
 		config:  cfg,
 		styles:  s,
+		keys:     km,
 		table:   t,
 		spinner: sp,
 		db:      db,
+		search:  search,
+		exportPath: exportPath,
+		selected: make(map[int]database.ContactWithStats),
+		confirm: components.NewConfirm(s),
+		undo:    components.NewUndo(s, km),
 		loading: false,  // Don't start loading immediately
 		err:     err,
 	}
@@ -104,60 +193,301 @@ func (m *ListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.table.SetHeight(m.height - 10)
+		m.table.SetHeight(m.height - 12)
+		
+	case tea.MouseMsg:
+		if m.loading || m.HasModal() {
+			return m, nil
+		}
+		components.TableMouse(&m.table, m.View(), msg)
+		return m, nil
 		
 	case tea.KeyMsg:
 		if m.loading {
 			return m, nil
 		}
+
+		// The confirmation dialog captures every key while open
+		if m.confirm.Active() {
+			return m, m.confirm.Update(msg)
+		}
+		if m.picker != nil {
+			return m, m.updatePicker(msg)
+		}
 		
-		switch msg.String() {
-		case "a":
+		if m.searching {
+			switch {
+			case key.Matches(msg, m.keys.List.Open):
+				// Keep the search and go back to the table
+				m.searching = false
+				m.search.Blur()
+				return m, nil
+			case key.Matches(msg, m.keys.Global.Back):
+				m.searching = false
+				m.search.Blur()
+				return m, m.setSearch("")
+			}
+			previous := m.search.Value()
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			if m.search.Value() == previous {
+				return m, cmd
+			}
+			return m, tea.Batch(cmd, m.scheduleSearch())
+		}
+		
+		if m.exporting {
+			switch {
+			case key.Matches(msg, m.keys.List.Open):
+				path := strings.TrimSpace(m.exportPath.Value())
+				m.exporting = false
+				m.exportPath.Blur()
+				if path == "" {
+					return m, nil
+				}
+				m.status = "Exporting to " + path + "..."
+				return m, m.export(path)
+			case key.Matches(msg, m.keys.Global.Back):
+				m.exporting = false
+				m.exportPath.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.exportPath, cmd = m.exportPath.Update(msg)
+			return m, cmd
+		}
+		
+		switch {
+		case key.Matches(msg, m.keys.Item.Search):
+			m.searching = true
+			m.status = ""
+			return m, m.search.Focus()
+		case key.Matches(msg, m.keys.Filter.Form):
+			// Cycle through every contact, unassigned ones and each form
+			m.filter++
+			if m.filter >= filterForms+len(m.forms) {
+				m.filter = filterAll
+			}
+			m.table.SetCursor(0)
+			return m, m.updateTable(false)
+		case key.Matches(msg, m.keys.Filter.Clear):
+			m.filter = filterAll
+			m.table.SetCursor(0)
+			if m.search.Value() != "" {
+				return m, m.setSearch("")
+			}
+			return m, m.updateTable(false)
+		case key.Matches(msg, m.keys.Contacts.Sort):
+			m.sortBy = (m.sortBy + 1) % len(sortOrders)
+			return m, m.updateTable(false)
+		case key.Matches(msg, m.keys.List.Select):
+			if contact, ok := m.current(); ok {
+				m.toggleSelected(contact)
+				m.table.MoveDown(1)
+				return m, m.updateTable(false)
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Contacts.SelectAll):
+			m.selectAll()
+			return m, m.updateTable(false)
+		case key.Matches(msg, m.keys.Contacts.AddToForms):
+			return m, m.pickForms(true)
+		case key.Matches(msg, m.keys.Contacts.RemoveFromForms):
+			return m, m.pickForms(false)
+		case key.Matches(msg, m.keys.Contacts.Export):
+			m.exporting = true
+			m.status = ""
+			m.exportPath.SetValue(m.exportFileName())
+			m.exportPath.CursorEnd()
+			return m, m.exportPath.Focus()
+		case key.Matches(msg, m.keys.Item.Delete) && len(m.selected) > 0:
+			// Delete every selected contact
+			targets := m.targets()
+			return m, m.confirm.Ask(m.config.UI.ConfirmDestructive, components.Prompt{
+				Title:   fmt.Sprintf("Delete %d contacts?", len(targets)),
+				Action:  "Delete",
+				Details: append(names(targets), "removes them from every form they are on"),
+			}, m.deleteContacts(targets))
+		case key.Matches(msg, m.keys.Item.New):
 			// Add new contact
 			return m, func() tea.Msg {
 				return SwitchToCreateMsg{}
 			}
-		case "e":
+		case key.Matches(msg, m.keys.Item.Edit):
 			// Edit selected contact
-			if len(m.contacts) > 0 {
-				selectedIdx := m.table.Cursor()
-				if selectedIdx < len(m.contacts) {
-					return m, func() tea.Msg {
-						return SwitchToEditMsg{ContactID: m.contacts[selectedIdx].ID}
-					}
+			if contact, ok := m.current(); ok {
+				return m, func() tea.Msg {
+					return SwitchToEditMsg{ContactID: contact.ID}
 				}
 			}
-		case "d":
+		case key.Matches(msg, m.keys.Item.Delete):
 			// Delete selected contact
-			if len(m.contacts) > 0 {
-				selectedIdx := m.table.Cursor()
-				if selectedIdx < len(m.contacts) {
-					// TODO: Show confirmation dialog
-					// For now, just delete directly
-					return m, m.deleteContact(m.contacts[selectedIdx].ID)
+			if contact, ok := m.current(); ok {
+				details := []string{fmt.Sprintf("removes this contact from %d forms", contact.FormCount)}
+				if contact.FormCount > 0 {
+					details = append(details, fmt.Sprintf("forms: %s", strings.Join(contact.FormIDs, ", ")))
 				}
+				return m, m.confirm.Ask(m.config.UI.ConfirmDestructive, components.Prompt{
+					Title:   fmt.Sprintf("Delete contact %q?", contact.Name),
+					Action:  "Delete",
+					Details: details,
+				}, m.deleteContact(contact.ID))
+			}
+		case key.Matches(msg, m.keys.Item.Archive):
+			// Archive the selected contact, or restore it in the archived list
+			if contact, ok := m.current(); ok {
+				if m.archived {
+					m.loading = true
+					return m, m.restoreContact(contact)
+				}
+				return m, m.confirm.Ask(m.config.UI.ConfirmDestructive, components.Prompt{
+					Title:  fmt.Sprintf("Archive contact %q?", contact.Name),
+					Action: "Archive",
+					Details: []string{
+						fmt.Sprintf("stops WhatsApp notifications from %d forms", contact.FormCount),
+						"form links are kept until the contact is purged",
+					},
+				}, m.archiveContact(contact))
+			}
+		case key.Matches(msg, m.keys.Item.ShowArchived):
+			// Toggle between active and archived contacts
+			m.archived = !m.archived
+			m.selected = make(map[int]database.ContactWithStats)
+			m.table.SetCursor(0)
+			m.loading = true
+			return m, m.loadContacts
+		case key.Matches(msg, m.keys.Item.Undo):
+			// Undo the last deletion or archive
+			if m.undo.Active() {
+				m.loading = true
+				return m, m.undo.Trigger()
 			}
-		case "enter":
+		case key.Matches(msg, m.keys.List.Open):
 			// View contact details (for now, same as edit)
-			if len(m.contacts) > 0 {
-				selectedIdx := m.table.Cursor()
-				if selectedIdx < len(m.contacts) {
-					return m, func() tea.Msg {
-						return SwitchToEditMsg{ContactID: m.contacts[selectedIdx].ID}
-					}
+			if contact, ok := m.current(); ok {
+				return m, func() tea.Msg {
+					return SwitchToEditMsg{ContactID: contact.ID}
 				}
 			}
-		case "r":
+		case key.Matches(msg, m.keys.Item.Refresh):
 			// Refresh
 			m.loading = true
 			return m, m.loadContacts
 		}
 		
+	case searchMsg:
+		// Only the search for what was typed last runs
+		if msg.ID == m.searchID {
+			cmds = append(cmds, m.loadContacts)
+		}
+
 	case ContactsLoadedMsg:
 		m.loading = false
+		refreshing := m.refreshing
+		m.refreshing = false
+		if msg.Query != m.search.Value() {
+			// Typing overtook this load; the search for the new text follows
+			break
+		}
+		if msg.Error != nil && refreshing {
+			// Keep the contacts on screen; the footer shows how old they are
+			log.Warn("Failed to refresh contacts", "error", msg.Error)
+			break
+		}
 		m.contacts = msg.Contacts
 		m.err = msg.Error
-		m.updateTable()
+		if msg.Error == nil {
+			m.updatedAt = time.Now()
+			m.forms = msg.Forms
+			if m.filter >= filterForms+len(m.forms) {
+				m.filter = filterAll
+			}
+			m.syncSelection(msg.Query == "")
+		}
+		cmds = append(cmds, m.updateTable(refreshing))
+
+	case ContactDeletedMsg:
+		if msg.Error != nil {
+			m.loading = false
+			m.err = msg.Error
+			return m, nil
+		}
+		snapshot := msg.Snapshot
+		m.loading = true
+		cmds = append(cmds,
+			m.undo.Offer(fmt.Sprintf("Deleted contact %q", snapshot.Contact.Name), func() error {
+				return m.db.RestoreContact(snapshot)
+			}),
+			m.loadContacts,
+		)
+
+	case ContactsDeletedMsg:
+		m.selected = make(map[int]database.ContactWithStats)
+		switch {
+		case msg.Error != nil && len(msg.Snapshots) == 0:
+			m.status = fmt.Sprintf("Delete failed: %v", msg.Error)
+		case msg.Error != nil:
+			m.status = fmt.Sprintf("Deleted %d contacts, then failed: %v", len(msg.Snapshots), msg.Error)
+		}
+		m.loading = true
+		if len(msg.Snapshots) > 0 {
+			cmds = append(cmds, m.undo.Offer(fmt.Sprintf("Deleted %d contacts", len(msg.Snapshots)), m.restoreContacts(msg.Snapshots)))
+		}
+		cmds = append(cmds, m.loadContacts)
+
+	case ContactsLinkedMsg:
+		m.status = linkedSummary(msg)
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("%s, then failed: %v", m.status, msg.Error)
+		}
+		m.loading = true
+		if len(msg.Changed) > 0 {
+			cmds = append(cmds, m.undo.Offer(linkedSummary(msg), m.unlinkContacts(msg)))
+		}
+		cmds = append(cmds, m.loadContacts)
+
+	case ContactsExportedMsg:
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Export failed: %v", msg.Error)
+		} else {
+			m.status = fmt.Sprintf("Exported %d contacts to %s", msg.Count, msg.Path)
+		}
+
+	case ContactArchivedMsg:
+		if msg.Error != nil {
+			m.loading = false
+			m.err = msg.Error
+			return m, nil
+		}
+		contactID := msg.ContactID
+		m.loading = true
+		cmds = append(cmds,
+			m.undo.Offer(fmt.Sprintf("Archived contact %q", msg.Name), func() error {
+				return m.db.UnarchiveContact(contactID)
+			}),
+			m.loadContacts,
+		)
+
+	case ContactRestoredMsg:
+		if msg.Error != nil {
+			m.loading = false
+			m.err = msg.Error
+			return m, nil
+		}
+		m.loading = true
+		cmds = append(cmds, m.loadContacts)
+
+	case components.UndoExpiredMsg:
+		m.undo.Expire(msg)
+
+	case components.UndoCompletedMsg:
+		if msg.Error != nil {
+			m.loading = false
+			m.err = msg.Error
+			return m, nil
+		}
+		m.loading = true
+		cmds = append(cmds, m.loadContacts)
 		
 	case spinner.TickMsg:
 		if m.loading {
@@ -167,6 +497,13 @@ func (m *ListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 	
+	// The form picker's own messages, such as its cursor blinking
+	if _, ok := msg.(tea.KeyMsg); !ok && m.picker != nil {
+		cmds = append(cmds, m.updatePicker(msg))
+	}
+	
+	// The keymap changes when settings are saved
+	m.table.KeyMap = m.keys.Table()
 	var cmd tea.Cmd
 	m.table, cmd = m.table.Update(msg)
 	cmds = append(cmds, cmd)
@@ -184,29 +521,92 @@ func (m *ListView) View() string {
 	}
 	
 	title := m.styles.Title.Render("📞 Contacts Management")
+	if m.archived {
+		title = m.styles.Title.Render("🗄  Archived Contacts")
+	}
 	
 	// Stats bar
 	totalForms := 0
-	for _, contact := range m.contacts {
+	for _, contact := range m.visible {
 		totalForms += contact.FormCount
 	}
-	stats := m.styles.Muted.Render(fmt.Sprintf("%d contacts • %d form associations", len(m.contacts), totalForms))
+	stats := fmt.Sprintf("%d contacts • %d form associations", len(m.visible), totalForms)
+	if m.archived {
+		stats = fmt.Sprintf("%d archived contacts • not receiving notifications", len(m.visible))
+	}
+	view := []string{"Sort: " + sortOrders[m.sortBy].label, "Filter: " + m.filterLabel()}
+	if q := m.search.Value(); q != "" {
+		view = append(view, fmt.Sprintf("Search: %q", q))
+	}
+	if len(m.selected) > 0 {
+		view = append(view, fmt.Sprintf("%d selected", len(m.selected)))
+	}
+	stats = m.styles.Muted.Render(stats + " • " + strings.Join(view, " • "))
 	
-	// Table
+	// Table, or the confirmation dialog or form picker while open
 	tableView := m.table.View()
+	if m.changes.Active() {
+		tableView = components.HighlightTable(m.table, m.styles.Highlight, func(row int) bool {
+			return row < len(m.visible) && m.changes.Changed(fmt.Sprint(m.visible[row].ID))
+		})
+	}
+	if len(m.visible) == 0 && (m.filter != filterAll || m.search.Value() != "") {
+		tableView = m.styles.Muted.Render("No contacts match these filters")
+	}
+	if m.confirm.Active() {
+		tableView = m.confirm.View()
+	}
+	if m.picker != nil {
+		tableView = m.picker.form.View()
+	}
 	
 	// Actions hint
-	actions := m.styles.Help.Render("a: Add • e: Edit • d: Delete • Enter: View • r: Refresh")
+	actions := m.styles.Help.Render(keys.Help(
+		keys.Describe(m.keys.Item.New, "Add"),
+		m.keys.Item.Edit,
+		m.keys.Item.Archive,
+		m.keys.Item.Delete,
+		keys.Describe(m.keys.Item.ShowArchived, "Archived"),
+		keys.Describe(m.keys.List.Open, "View"),
+		m.keys.Item.Refresh,
+	)) + "\n" + m.styles.Help.Render(keys.Help(
+		m.keys.Item.Search,
+		keys.Describe(m.keys.Filter.Form, "Filter"),
+		m.keys.Filter.Clear,
+		m.keys.Contacts.Sort,
+		m.keys.List.Select,
+		m.keys.Contacts.SelectAll,
+		m.keys.Contacts.AddToForms,
+		m.keys.Contacts.RemoveFromForms,
+		m.keys.Contacts.Export,
+	))
+	if m.archived {
+		actions = m.styles.Help.Render(keys.Help(
+			keys.Describe(m.keys.Item.Archive, "Restore"),
+			keys.Describe(m.keys.Item.Delete, "Delete permanently"),
+			m.keys.Item.Edit,
+			keys.Describe(m.keys.Item.ShowArchived, "Active contacts"),
+			m.keys.Item.Refresh,
+		))
+	}
+	if undo := m.undo.View(); undo != "" {
+		actions = lipgloss.JoinVertical(lipgloss.Top, undo, actions)
+	}
 	
-	return lipgloss.JoinVertical(
-		lipgloss.Top,
-		title,
-		stats,
-		"",
-		tableView,
-		"",
-		actions,
-	)
+	parts := []string{title, stats, ""}
+	if m.searching {
+		parts = append(parts, m.search.View(), "")
+	}
+	parts = append(parts, tableView, "")
+	if m.exporting {
+		parts = append(parts, m.exportPath.View(),
+			m.styles.Muted.Render("The extension picks the format: .csv or .json"), "")
+	} else if m.status != "" {
+		parts = append(parts, m.styles.Info.Render(m.status), "")
+	}
+	parts = append(parts, actions)
+	
+	return lipgloss.JoinVertical(lipgloss.Top, parts...)
 }
 
 func (m *ListView) renderLoading() string {
@@ -218,7 +618,7 @@ func (m *ListView) renderLoading() string {
 
 func (m *ListView) renderError() string {
 	errorView := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
-	help := m.styles.Help.Render("Press 'r' to retry")
+	help := m.styles.Help.Render(fmt.Sprintf("Press '%s' to retry", m.keys.Item.Refresh.Help().Key))
 	
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -234,22 +634,145 @@ func (m *ListView) loadContacts() tea.Msg {
 		}
 	}
 	
-	contacts, err := m.db.GetContactsWithStats()
+	query := m.search.Value()
+	load := m.db.GetContactsWithStats
+	switch {
+	case m.archived:
+		load = m.db.GetArchivedContacts
+	case query != "":
+		load = func() ([]database.ContactWithStats, error) { return m.db.SearchContacts(query) }
+	}
+	
+	contacts, err := load()
 	if err != nil {
 		log.Error("Failed to load contacts", "error", err)
 		return ContactsLoadedMsg{
+			Query: query,
 			Error: err,
 		}
 	}
+	if m.archived && query != "" {
+		// Archived contacts are searched here, the way SearchContacts would
+		contacts = matching(contacts, query)
+	}
+	
+	// The forms feed the form filter and the bulk form picker
+	forms, err := m.db.GetAllForms()
+	if err != nil {
+		return ContactsLoadedMsg{Query: query, Error: err}
+	}
 	
 	return ContactsLoadedMsg{
 		Contacts: contacts,
+		Forms:    forms,
+		Query:    query,
 	}
 }
 
-func (m *ListView) updateTable() {
-	var rows []table.Row
+// matching returns the contacts whose name, company or phone contains query
+func matching(contacts []database.ContactWithStats, query string) []database.ContactWithStats {
+	query = strings.ToLower(query)
+	var found []database.ContactWithStats
+	for _, contact := range contacts {
+		for _, value := range []string{contact.Name, contact.Company, contact.PhoneNumber} {
+			if strings.Contains(strings.ToLower(value), query) {
+				found = append(found, contact)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// current returns the contact under the cursor
+func (m *ListView) current() (database.ContactWithStats, bool) {
+	if i := m.table.Cursor(); i >= 0 && i < len(m.visible) {
+		return m.visible[i], true
+	}
+	return database.ContactWithStats{}, false
+}
+
+// scheduleSearch runs the search once typing pauses
+func (m *ListView) scheduleSearch() tea.Cmd {
+	m.searchID++
+	id := m.searchID
+	return tea.Tick(searchDelay, func(time.Time) tea.Msg {
+		return searchMsg{ID: id}
+	})
+}
+
+// setSearch replaces the search text and reloads when it changed
+func (m *ListView) setSearch(query string) tea.Cmd {
+	if m.search.Value() == query {
+		return nil
+	}
+	m.search.SetValue(query)
+	m.searchID++
+	m.loading = true
+	return m.loadContacts
+}
+
+// syncSelection refreshes the selected contacts from a load. A load of
+// every contact also drops the selected ones that are gone.
+func (m *ListView) syncSelection(complete bool) {
+	loaded := make(map[int]database.ContactWithStats, len(m.contacts))
 	for _, contact := range m.contacts {
+		loaded[contact.ID] = contact
+	}
+	for id := range m.selected {
+		if contact, ok := loaded[id]; ok {
+			m.selected[id] = contact
+		} else if complete {
+			delete(m.selected, id)
+		}
+	}
+}
+
+// filterLabel names the active filter
+func (m *ListView) filterLabel() string {
+	switch {
+	case m.filter == filterUnassigned:
+		return "unassigned"
+	case m.filter >= filterForms && m.filter-filterForms < len(m.forms):
+		return "on " + m.forms[m.filter-filterForms].Name
+	}
+	return "all"
+}
+
+// shown reports whether a contact passes the filter
+func (m *ListView) shown(contact database.ContactWithStats) bool {
+	switch {
+	case m.filter == filterUnassigned:
+		return contact.FormCount == 0
+	case m.filter >= filterForms && m.filter-filterForms < len(m.forms):
+		formID := m.forms[m.filter-filterForms].ID
+		for _, id := range contact.FormIDs {
+			if id == formID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// updateTable fills the table with the loaded contacts and, when
+// highlight is set, highlights the ones that changed since the last load
+func (m *ListView) updateTable(highlight bool) tea.Cmd {
+	// A fresh slice, since exports keep the previous one
+	m.visible = nil
+	for _, contact := range m.contacts {
+		if m.shown(contact) {
+			m.visible = append(m.visible, contact)
+		}
+	}
+	sort.SliceStable(m.visible, func(i, j int) bool {
+		return sortOrders[m.sortBy].less(m.visible[i], m.visible[j])
+	})
+	
+	var rows []table.Row
+	prints := make(map[string]string, len(m.visible))
+	for _, contact := range m.visible {
 		company := contact.Company
 		if company == "" {
 			company = "-"
@@ -259,24 +782,43 @@ func (m *ListView) updateTable() {
 			role = "-"
 		}
 		
+		check := "[ ]"
+		if _, ok := m.selected[contact.ID]; ok {
+			check = "[x]"
+		}
+		
 		rows = append(rows, table.Row{
+			check,
 			contact.Name,
 			contact.PhoneNumber,
 			company,
 			role,
 			fmt.Sprintf("%d", contact.FormCount),
 		})
+		// Selecting a contact does not count as a change
+		prints[fmt.Sprint(contact.ID)] = strings.Join(rows[len(rows)-1][1:], "\t")
 	}
 	
 	m.table.SetRows(rows)
+	if m.table.Cursor() >= len(rows) {
+		m.table.SetCursor(max(len(rows)-1, 0))
+	}
+	return m.changes.Track(prints, highlight)
 }
 
 // Message types
 type ContactsLoadedMsg struct {
 	Contacts []database.ContactWithStats
+	Forms    []database.FormWithStats
+	// Query is the search the contacts were loaded for
+	Query    string
 	Error    error
 }
 
+type searchMsg struct {
+	ID int
+}
+
 type ViewActivatedMsg struct{}
 
 type SwitchToCreateMsg struct{}
@@ -286,6 +828,18 @@ type SwitchToEditMsg struct {
 }
 
 type ContactDeletedMsg struct {
+	ContactID int
+	Snapshot  *database.ContactSnapshot
+	Error     error
+}
+
+type ContactArchivedMsg struct {
+	ContactID int
+	Name      string
+	Error     error
+}
+
+type ContactRestoredMsg struct {
 	ContactID int
 	Error     error
 }
@@ -296,14 +850,106 @@ func (m *ListView) ForceReload() tea.Cmd {
 	return m.loadContacts
 }
 
+// Refresh reloads the contacts in the background
+func (m *ListView) Refresh() tea.Cmd {
+	if m.loading || m.refreshing {
+		return nil
+	}
+	m.refreshing = true
+	return m.loadContacts
+}
+
+// UpdatedAt reports when the contacts on screen were loaded
+func (m *ListView) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// HasModal reports whether the confirmation dialog, form picker, search
+// or export prompt is capturing keys
+func (m *ListView) HasModal() bool {
+	return m.confirm.Active() || m.picker != nil || m.searching || m.exporting
+}
+
+// ShortHelp lists the keys shown in the footer
+func (m *ListView) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Join("Navigate", m.keys.List.Up, m.keys.List.Down),
+		m.keys.Item.New,
+		m.keys.Item.Edit,
+		m.keys.Item.Delete,
+		m.keys.Item.Undo,
+		m.keys.List.Open,
+		m.keys.Item.Search,
+		m.keys.List.Select,
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *ListView) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.List.Up, m.keys.List.Down, m.keys.List.Top, m.keys.List.Bottom},
+		{m.keys.List.PageUp, m.keys.List.PageDown, m.keys.List.HalfPageUp, m.keys.List.HalfPageDown},
+		{m.keys.List.Open, m.keys.Item.New, m.keys.Item.Edit, m.keys.Item.Delete, m.keys.Item.Undo},
+		{m.keys.Item.Archive, m.keys.Item.ShowArchived, m.keys.Item.Refresh},
+		{m.keys.Item.Search, keys.Describe(m.keys.Filter.Form, "Filter"), m.keys.Filter.Clear, m.keys.Contacts.Sort},
+		{m.keys.List.Select, m.keys.Contacts.SelectAll, m.keys.Contacts.AddToForms, m.keys.Contacts.RemoveFromForms, m.keys.Contacts.Export},
+	}
+}
+
+// RegisterActions adds contact commands and every contact to the palette
+func (m *ListView) RegisterActions(r *actions.Registry) {
+	r.Register(actions.Action{
+		Title:    "Add contact",
+		Group:    "Contacts",
+		Keywords: []string{"new contact", "create contact"},
+		Run:      actions.Send(SwitchToCreateMsg{}),
+	})
+	r.RegisterSource(func() ([]actions.Action, error) {
+		if m.db == nil {
+			return nil, fmt.Errorf("database client not initialized")
+		}
+		contacts, err := m.db.GetContactsWithStats()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load contacts: %w", err)
+		}
+		found := make([]actions.Action, 0, len(contacts))
+		for _, contact := range contacts {
+			found = append(found, actions.Action{
+				Title:    "Edit contact " + contact.Name,
+				Group:    "Contacts",
+				Hint:     contact.PhoneNumber,
+				Keywords: []string{contact.Company},
+				Run:      actions.Send(SwitchToEditMsg{ContactID: contact.ID}),
+			})
+		}
+		return found, nil
+	})
+}
+
 func (m *ListView) deleteContact(contactID int) tea.Cmd {
 	return func() tea.Msg {
-		err := m.db.DeleteContact(contactID)
+		// Snapshot first so the deletion can be undone
+		snapshot, err := m.db.SnapshotContact(contactID)
 		if err != nil {
 			return ContactDeletedMsg{ContactID: contactID, Error: err}
 		}
-		// Reload contacts after deletion
-		contacts, err := m.db.GetContactsWithStats()
-		return ContactsLoadedMsg{Contacts: contacts, Error: err}
+		if err := m.db.DeleteContact(contactID); err != nil {
+			return ContactDeletedMsg{ContactID: contactID, Error: err}
+		}
+		return ContactDeletedMsg{ContactID: contactID, Snapshot: snapshot}
+	}
+}
+
+func (m *ListView) archiveContact(contact database.ContactWithStats) tea.Cmd {
+	return func() tea.Msg {
+		err := m.db.ArchiveContact(contact.ID)
+		return ContactArchivedMsg{ContactID: contact.ID, Name: contact.Name, Error: err}
 	}
-}
\ No newline at end of file
+}
+
+func (m *ListView) restoreContact(contact database.ContactWithStats) tea.Cmd {
+	return func() tea.Msg {
+		err := m.db.UnarchiveContact(contact.ID)
+		return ContactRestoredMsg{ContactID: contact.ID, Error: err}
+	}
+}
@@ -2,21 +2,32 @@ package dashboard
 
 import (
 	"fmt"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/actions"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
 )
 
 type Model struct {
 	config   *config.Config
 	styles   *styles.Styles
+	keys     *keys.Map
 	spinner  spinner.Model
 	loading  bool
+	// refreshing is a background reload that keeps the stats on screen
+	refreshing bool
+	updatedAt  time.Time
+	// changes highlights stat cards a background refresh changed
+	changes  components.Changes
 	stats    *database.Stats
 	db       *database.Client
 	menuItems []MenuItem
@@ -30,43 +41,98 @@ type MenuItem struct {
 	Title       string
 	Description string
 	Icon        string
-	Key         string
-	ViewID      int
+	// Key opens the item; the app matches it, the menu shows it
+	Key         *key.Binding
+	ViewID      actions.View
 }
 
-func New(cfg *config.Config, s *styles.Styles) *Model {
-	sp := spinner.New()
-	sp.Spinner = spinner.Dot
-	sp.Style = s.Spinner
+func New(cfg *config.Config, s *styles.Styles, km *keys.Map) *Model {
+	sp := components.NewSpinner(cfg, s)
 	
 	menuItems := []MenuItem{
 		{
 			Title:       "Forms",
 			Description: "Manage webhook forms and configurations",
 			Icon:        "📝",
-			Key:         "2",
-			ViewID:      1, // ViewForms
+			Key:         &km.Go.Forms,
+			ViewID:      actions.ViewForms,
 		},
 		{
 			Title:       "Contacts",
 			Description: "Organize contacts and recipients",
 			Icon:        "📞",
-			Key:         "3",
-			ViewID:      2, // ViewContacts
+			Key:         &km.Go.Contacts,
+			ViewID:      actions.ViewContacts,
 		},
 		{
 			Title:       "Test Webhook",
 			Description: "Test webhook endpoints with sample data",
 			Icon:        "🧪",
-			Key:         "4",
-			ViewID:      3, // ViewWebhook
+			Key:         &km.Go.Webhook,
+			ViewID:      actions.ViewWebhook,
 		},
 		{
 			Title:       "Settings",
 			Description: "Configure application settings",
 			Icon:        "⚙️",
-			Key:         "5",
-			ViewID:      4, // ViewSettings
+			Key:         &km.Go.Settings,
+			ViewID:      actions.ViewSettings,
+		},
+		{
+			Title:       "Doctor",
+			Description: "Audit and repair data integrity",
+			Icon:        "🩺",
+			Key:         &km.Go.Doctor,
+			ViewID:      actions.ViewDoctor,
+		},
+		{
+			Title:       "Deliveries",
+			Description: "Resend failed WhatsApp deliveries",
+			Icon:        "📮",
+			Key:         &km.Go.Deliveries,
+			ViewID:      actions.ViewDeliveries,
+		},
+		{
+			Title:       "Queue",
+			Description: "Monitor pending, in-flight and dead messages",
+			Icon:        "📤",
+			Key:         &km.Go.Queue,
+			ViewID:      actions.ViewQueue,
+		},
+		{
+			Title:       "Inbox",
+			Description: "Browse every stored form submission",
+			Icon:        "📥",
+			Key:         &km.Go.Inbox,
+			ViewID:      actions.ViewSubmissions,
+		},
+		{
+			Title:       "Leads",
+			Description: "Move leads through statuses on a board",
+			Icon:        "🗂",
+			Key:         &km.Go.Leads,
+			ViewID:      actions.ViewLeads,
+		},
+		{
+			Title:       "Analytics",
+			Description: "Chart submissions, success rate and latency",
+			Icon:        "📈",
+			Key:         &km.Go.Analytics,
+			ViewID:      actions.ViewAnalytics,
+		},
+		{
+			Title:       "Live Tail",
+			Description: "Watch submissions and deliveries arrive",
+			Icon:        "📡",
+			Key:         &km.Go.Logs,
+			ViewID:      actions.ViewLogs,
+		},
+		{
+			Title:       "Monitoring",
+			Description: "Z-API connection uptime and outages",
+			Icon:        "🩺",
+			Key:         &km.Go.Monitor,
+			ViewID:      actions.ViewMonitor,
 		},
 	}
 	
@@ -79,6 +145,7 @@ func New(cfg *config.Config, s *styles.Styles) *Model {
 	return &Model{
 		config:    cfg,
 		styles:    s,
+		keys:      km,
 		spinner:   sp,
 		loading:   true,
 		menuItems: menuItems,
@@ -108,32 +175,50 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		
-		switch msg.String() {
-		case "up", "k":
+		switch {
+		case key.Matches(msg, m.keys.List.Up):
 			if m.selected > 0 {
 				m.selected--
 			}
-		case "down", "j":
+		case key.Matches(msg, m.keys.List.Down):
 			if m.selected < len(m.menuItems)-1 {
 				m.selected++
 			}
-		case "enter":
+		case key.Matches(msg, m.keys.List.Open):
 			// Send switch view message
 			item := m.menuItems[m.selected]
 			return m, m.switchView(item.ViewID, item.Title)
-		case "2", "3", "4", "5":
-			// Direct navigation
-			for _, item := range m.menuItems {
-				if item.Key == msg.String() {
-					return m, m.switchView(item.ViewID, item.Title)
-				}
+		}
+		
+	case tea.MouseMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		// The wheel moves the selection; a click opens the item
+		if step := components.Wheel(msg); step != 0 {
+			m.selected = max(0, min(len(m.menuItems)-1, m.selected+step))
+		} else if components.Clicked(msg) {
+			if i := m.itemAt(msg.X, msg.Y); i >= 0 {
+				m.selected = i
+				item := m.menuItems[i]
+				return m, m.switchView(item.ViewID, item.Title)
 			}
 		}
 		
 	case StatsLoadedMsg:
 		m.loading = false
+		refreshing := m.refreshing
+		m.refreshing = false
 		m.stats = msg.Stats
 		m.err = msg.Error
+		if msg.Error == nil {
+			m.updatedAt = time.Now()
+			prints := make(map[string]string)
+			for _, card := range m.statCards() {
+				prints[card.title] = card.value
+			}
+			cmds = append(cmds, m.changes.Track(prints, refreshing))
+		}
 		
 	case spinner.TickMsg:
 		if m.loading {
@@ -183,11 +268,9 @@ func (m *Model) renderStats() string {
 	}
 	
 	// Create stat cards
-	cards := []string{
-		m.renderStatCard("Forms", fmt.Sprintf("%d active / %d total", m.stats.ActiveForms, m.stats.TotalForms), m.styles.Colors.Primary),
-		m.renderStatCard("Contacts", fmt.Sprintf("%d total", m.stats.TotalContacts), m.styles.Colors.Secondary),
-		m.renderStatCard("Webhooks Today", fmt.Sprintf("%d sent", m.stats.WebhooksToday), m.styles.Colors.Info),
-		m.renderStatCard("Connection", m.stats.ConnectionStatus, m.getStatusColor()),
+	var cards []string
+	for _, card := range m.statCards() {
+		cards = append(cards, m.renderStatCard(card.title, card.value, card.color))
 	}
 	
 	// Join cards horizontally
@@ -203,9 +286,39 @@ func (m *Model) renderStats() string {
 	)
 }
 
+// statCard is one of the figures at the top of the dashboard
+type statCard struct {
+	title string
+	value string
+	color lipgloss.Color
+}
+
+func (m *Model) statCards() []statCard {
+	if m.stats == nil {
+		return nil
+	}
+	cards := []statCard{
+		{"Forms", fmt.Sprintf("%d active / %d paused", m.stats.ActiveForms, m.stats.PausedForms), m.styles.Colors.Primary},
+		{"Contacts", fmt.Sprintf("%d total", m.stats.TotalContacts), m.styles.Colors.Secondary},
+		{"Webhooks", fmt.Sprintf("%d today / %d this week", m.stats.WebhooksToday, m.stats.WebhooksThisWeek), m.styles.Colors.Info},
+		{"Connection", m.stats.ConnectionStatus, m.getStatusColor()},
+	}
+	if leads := m.stats.Leads; leads != nil {
+		cards = append(cards, statCard{"Leads", fmt.Sprintf("%d new / %d contacted\n%d qualified / %d lost",
+			leads[database.LeadNew], leads[database.LeadContacted], leads[database.LeadQualified], leads[database.LeadLost]),
+			m.styles.Colors.Success})
+	}
+	return cards
+}
+
 func (m *Model) renderStatCard(title, value string, color lipgloss.Color) string {
+	// A card a refresh changed gets a heavier border for a moment
+	border := lipgloss.RoundedBorder()
+	if m.changes.Changed(title) {
+		border = lipgloss.ThickBorder()
+	}
 	cardStyle := lipgloss.NewStyle().
-		BorderStyle(lipgloss.RoundedBorder()).
+		BorderStyle(border).
 		BorderForeground(color).
 		Padding(1, 2).
 		Width(25).
@@ -228,39 +341,9 @@ func (m *Model) renderStatCard(title, value string, color lipgloss.Color) string
 }
 
 func (m *Model) renderMenu() string {
-	title := m.styles.Title.Render("🚀 Quick Actions")
-	
 	var items []string
-	for i, item := range m.menuItems {
-		var itemView string
-		
-		itemTitle := fmt.Sprintf("%s %s", item.Icon, item.Title)
-		itemDesc := m.styles.Muted.Render(item.Description)
-		itemKey := m.styles.Badge.Render(item.Key)
-		
-		itemContent := lipgloss.JoinVertical(
-			lipgloss.Top,
-			lipgloss.JoinHorizontal(lipgloss.Top, itemTitle, " ", itemKey),
-			itemDesc,
-		)
-		
-		if i == m.selected {
-			itemStyle := lipgloss.NewStyle().
-				BorderStyle(lipgloss.RoundedBorder()).
-				BorderForeground(m.styles.Colors.Primary).
-				Padding(1, 2).
-				Width(60)
-			itemView = itemStyle.Render(itemContent)
-		} else {
-			itemStyle := lipgloss.NewStyle().
-				BorderStyle(lipgloss.RoundedBorder()).
-				BorderForeground(m.styles.Colors.Border).
-				Padding(1, 2).
-				Width(60)
-			itemView = itemStyle.Render(itemContent)
-		}
-		
-		items = append(items, itemView)
+	for i := range m.menuItems {
+		items = append(items, m.renderItem(i))
 	}
 	
 	menu := lipgloss.JoinVertical(
@@ -270,11 +353,53 @@ func (m *Model) renderMenu() string {
 	
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
-		title,
+		m.renderMenuTitle(),
 		menu,
 	)
 }
 
+func (m *Model) renderMenuTitle() string {
+	return m.styles.Title.Render("🚀 Quick Actions")
+}
+
+func (m *Model) renderItem(i int) string {
+	item := m.menuItems[i]
+	itemTitle := fmt.Sprintf("%s %s", item.Icon, item.Title)
+	itemDesc := m.styles.Muted.Render(item.Description)
+	itemKey := m.styles.Badge.Render(item.Key.Help().Key)
+	
+	itemContent := lipgloss.JoinVertical(
+		lipgloss.Top,
+		lipgloss.JoinHorizontal(lipgloss.Top, itemTitle, " ", itemKey),
+		itemDesc,
+	)
+	
+	border := m.styles.Colors.Border
+	if i == m.selected {
+		border = m.styles.Colors.Primary
+	}
+	itemStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(1, 2).
+		Width(60)
+	return itemStyle.Render(itemContent)
+}
+
+// itemAt returns the menu item drawn at x, y of the view, or -1
+func (m *Model) itemAt(x, y int) int {
+	top := lipgloss.Height(m.renderStats()) + lipgloss.Height("\n") + lipgloss.Height(m.renderMenuTitle())
+	for i := range m.menuItems {
+		item := m.renderItem(i)
+		bottom := top + lipgloss.Height(item)
+		if y >= top && y < bottom && x < lipgloss.Width(item) {
+			return i
+		}
+		top = bottom
+	}
+	return -1
+}
+
 func (m *Model) getStatusColor() lipgloss.Color {
 	if m.stats == nil {
 		return m.styles.Colors.Warning
@@ -301,6 +426,20 @@ func (m *Model) renderError() string {
 	)
 }
 
+// Refresh reloads the stats in the background
+func (m *Model) Refresh() tea.Cmd {
+	if m.loading || m.refreshing {
+		return nil
+	}
+	m.refreshing = true
+	return m.loadStats
+}
+
+// UpdatedAt reports when the stats on screen were loaded
+func (m *Model) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
 func (m *Model) loadStats() tea.Msg {
 	if m.db == nil {
 		return StatsLoadedMsg{
@@ -325,14 +464,28 @@ func (m *Model) loadStats() tea.Msg {
 	}
 }
 
-func (m *Model) switchView(viewID int, title string) tea.Cmd {
-	return func() tea.Msg {
-		// This will be caught by the main app model
-		type SwitchViewMsg struct {
-			View  int
-			Title string
-		}
-		return SwitchViewMsg{View: viewID, Title: title}
+func (m *Model) switchView(viewID actions.View, title string) tea.Cmd {
+	// This will be caught by the main app model
+	return actions.Navigate(viewID, title, nil)
+}
+
+// ShortHelp lists the keys shown in the footer
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Join("Navigate", m.keys.List.Up, m.keys.List.Down),
+		m.keys.List.Open,
+		m.keys.Go.Analytics,
+		m.keys.Go.Logs,
+		m.keys.Go.Monitor,
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.List.Up, m.keys.List.Down, m.keys.List.Open},
+		{m.keys.Go.Forms, m.keys.Go.Contacts, m.keys.Go.Webhook, m.keys.Go.Settings, m.keys.Go.Doctor, m.keys.Go.Deliveries},
+		{m.keys.Go.Queue, m.keys.Go.Inbox, m.keys.Go.Leads, m.keys.Go.Analytics, m.keys.Go.Logs, m.keys.Go.Monitor},
 	}
 }
 
@@ -0,0 +1,397 @@
+package deliveries
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/replay"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+	"github.com/thalysguimaraes/elementor-whatsapp/pkg/zapi"
+)
+
+// Model lists failed deliveries and resends the selected ones
+type Model struct {
+	config     *config.Config
+	styles     *styles.Styles
+	keys       *keys.Map
+	spinner    spinner.Model
+	db         *database.Client
+	sender     *zapi.Client
+	deliveries []database.Delivery
+	selected   map[int]bool
+	cursor     int
+	loading    bool
+	// refreshing is a background reload that keeps the list on screen
+	refreshing bool
+	updatedAt  time.Time
+	// changes highlights deliveries a background refresh added or changed
+	changes components.Changes
+	status  string
+	width   int
+	height  int
+	err     error
+}
+
+func New(cfg *config.Config, s *styles.Styles, km *keys.Map) *Model {
+	sp := components.NewSpinner(cfg, s)
+
+	// Create database client
+	db, err := database.NewClient(cfg)
+	if err != nil {
+		log.Error("Failed to create database client", "error", err)
+	}
+
+	return &Model{
+		config:   cfg,
+		styles:   s,
+		keys:     km,
+		spinner:  sp,
+		db:       db,
+		sender:   zapi.NewClient(cfg.ZAPI.InstanceID, cfg.ZAPI.InstanceToken, cfg.ZAPI.ClientToken).WithBaseURL(cfg.ZAPI.BaseURL),
+		selected: make(map[int]bool),
+		err:      err,
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Refresh reloads the failed deliveries in the background
+func (m *Model) Refresh() tea.Cmd {
+	if m.loading || m.refreshing || m.db == nil {
+		return nil
+	}
+	m.refreshing = true
+	return m.load("")
+}
+
+// UpdatedAt reports when the deliveries on screen were loaded
+func (m *Model) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// StartLoading loads the failed deliveries when the view becomes active
+func (m *Model) StartLoading() tea.Cmd {
+	if m.loading || m.db == nil {
+		return nil
+	}
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, m.load(""))
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.MouseMsg:
+		if step := components.Wheel(msg); step != 0 && !m.loading {
+			m.cursor = max(0, min(len(m.deliveries)-1, m.cursor+step))
+		}
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.List.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, m.keys.List.Down):
+			if m.cursor < len(m.deliveries)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, m.keys.List.Select):
+			if m.cursor < len(m.deliveries) {
+				id := m.deliveries[m.cursor].ID
+				if m.selected[id] {
+					delete(m.selected, id)
+				} else {
+					m.selected[id] = true
+				}
+			}
+		case key.Matches(msg, m.keys.Deliveries.SelectAll):
+			// Select all, or clear the selection when everything is selected
+			if len(m.selected) == len(m.deliveries) {
+				m.selected = make(map[int]bool)
+			} else {
+				for _, d := range m.deliveries {
+					m.selected[d.ID] = true
+				}
+			}
+		case key.Matches(msg, m.keys.Deliveries.Resend, m.keys.Deliveries.ResendCurrent):
+			targets := m.targets()
+			if len(targets) == 0 {
+				return m, nil
+			}
+			if !m.sender.Configured() {
+				m.status = "Z-API credentials are not configured"
+				return m, nil
+			}
+			m.loading = true
+			opts := replay.Options{CurrentRecipients: key.Matches(msg, m.keys.Deliveries.ResendCurrent)}
+			return m, tea.Batch(m.spinner.Tick, m.resend(targets, opts))
+		case key.Matches(msg, m.keys.Item.Reload):
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.load(""))
+		}
+
+	case DeliveriesLoadedMsg:
+		m.loading = false
+		refreshing := m.refreshing
+		m.refreshing = false
+		if msg.Error != nil {
+			if refreshing {
+				// Keep the list on screen; the footer shows how old it is
+				log.Warn("Failed to refresh deliveries", "error", msg.Error)
+				return m, nil
+			}
+			m.status = fmt.Sprintf("Failed to load deliveries: %v", msg.Error)
+			return m, nil
+		}
+		m.deliveries = msg.Deliveries
+		m.updatedAt = time.Now()
+		prints := make(map[string]string, len(m.deliveries))
+		for _, d := range m.deliveries {
+			prints[fmt.Sprint(d.ID)] = d.Status + " " + strings.Join(failedPhones(d), ", ")
+		}
+		cmds = append(cmds, m.changes.Track(prints, refreshing))
+		if msg.Status != "" {
+			m.status = msg.Status
+		}
+		// Drop selections for deliveries that were fully resent
+		present := make(map[int]bool, len(m.deliveries))
+		for _, d := range m.deliveries {
+			present[d.ID] = true
+		}
+		for id := range m.selected {
+			if !present[id] {
+				delete(m.selected, id)
+			}
+		}
+		if m.cursor >= len(m.deliveries) {
+			m.cursor = max(len(m.deliveries)-1, 0)
+		}
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) View() string {
+	if m.err != nil {
+		return m.renderError()
+	}
+
+	title := m.styles.Title.Render("📮 Failed Deliveries")
+	description := m.styles.Muted.Render("Webhooks the worker could not deliver to every recipient")
+
+	if m.loading {
+		return lipgloss.JoinVertical(
+			lipgloss.Top,
+			title,
+			description,
+			"",
+			m.spinner.View()+" Working...",
+		)
+	}
+
+	parts := []string{title, description, ""}
+
+	if len(m.deliveries) == 0 {
+		parts = append(parts, m.styles.Success.Render("Every delivery reached its recipients"))
+	} else {
+		header := fmt.Sprintf("    %-7s %-17s %-20s %-8s %s", "ID", "Received", "Form", "Status", "Failed recipients")
+		rows := []string{m.styles.TableHeader.Render(header)}
+		for i, d := range m.deliveries {
+			check := "[ ]"
+			if m.selected[d.ID] {
+				check = "[x]"
+			}
+			row := fmt.Sprintf("%s #%-6d %-17s %-20s %-8s %s",
+				check, d.ID,
+				d.CreatedAt.Local().Format("2006-01-02 15:04"),
+				truncate(d.FormID, 20), d.Status,
+				strings.Join(failedPhones(d), ", "),
+			)
+			switch {
+			case i == m.cursor:
+				rows = append(rows, m.styles.ActiveItem.Render(row))
+			case m.changes.Changed(fmt.Sprint(d.ID)):
+				rows = append(rows, m.styles.Highlight.Render(row))
+			default:
+				rows = append(rows, m.styles.Text.Render(row))
+			}
+		}
+		parts = append(parts, lipgloss.JoinVertical(lipgloss.Top, rows...), "", m.renderDetail())
+	}
+
+	if m.status != "" {
+		parts = append(parts, "", m.styles.Info.Render(m.status))
+	}
+	parts = append(parts, "", m.styles.Help.Render(keys.Help(
+		m.keys.List.Select,
+		m.keys.Deliveries.SelectAll,
+		m.keys.Deliveries.Resend,
+		keys.Describe(m.keys.Deliveries.ResendCurrent, "Resend to current recipients"),
+		m.keys.Item.Reload,
+		m.keys.Global.Back,
+	)))
+
+	return lipgloss.JoinVertical(lipgloss.Top, parts...)
+}
+
+// ShortHelp lists the keys shown in the footer
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Join("Navigate", m.keys.List.Up, m.keys.List.Down),
+		m.keys.List.Select,
+		m.keys.Deliveries.Resend,
+		m.keys.Deliveries.ResendCurrent,
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.List.Up, m.keys.List.Down, m.keys.List.Select, m.keys.Deliveries.SelectAll},
+		{m.keys.Deliveries.Resend, keys.Describe(m.keys.Deliveries.ResendCurrent, "Resend to current recipients"), m.keys.Item.Reload},
+	}
+}
+
+func (m *Model) renderDetail() string {
+	if m.cursor >= len(m.deliveries) {
+		return ""
+	}
+	d := m.deliveries[m.cursor]
+
+	lines := []string{m.styles.Subtitle.Render("Message")}
+	lines = append(lines, strings.Split(d.Message, "\n")...)
+	lines = append(lines, "", m.styles.Subtitle.Render("Recipients"))
+	for _, r := range d.Recipients {
+		line := fmt.Sprintf("%s  %s (%d attempt(s))", r.Phone, r.Status, r.Attempts)
+		if r.LastError != "" {
+			line += " — " + truncate(r.LastError, 60)
+		}
+		if r.Status == database.RecipientSent {
+			lines = append(lines, m.styles.Success.Render(line))
+		} else {
+			lines = append(lines, m.styles.Error.Render(line))
+		}
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Colors.Border).
+		Padding(0, 2).
+		Width(90).
+		Render(lipgloss.JoinVertical(lipgloss.Top, lines...))
+}
+
+func (m *Model) renderError() string {
+	errorView := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
+	help := m.styles.Help.Render("Check your configuration and try again")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		errorView,
+		help,
+	)
+}
+
+// targets returns the selected deliveries, or the one under the cursor
+func (m *Model) targets() []database.Delivery {
+	var targets []database.Delivery
+	for _, d := range m.deliveries {
+		if m.selected[d.ID] {
+			targets = append(targets, d)
+		}
+	}
+	if len(targets) == 0 && m.cursor < len(m.deliveries) {
+		targets = append(targets, m.deliveries[m.cursor])
+	}
+	return targets
+}
+
+func (m *Model) load(status string) tea.Cmd {
+	return func() tea.Msg {
+		deliveries, err := m.db.GetFailedDeliveries(database.DeliveryFilter{Limit: 200})
+		return DeliveriesLoadedMsg{Deliveries: deliveries, Status: status, Error: err}
+	}
+}
+
+func (m *Model) resend(targets []database.Delivery, opts replay.Options) tea.Cmd {
+	return func() tea.Msg {
+		var sent, failed, skipped int
+		for _, d := range targets {
+			outcomes, err := replay.Resend(m.db, m.sender, d, opts)
+			if err != nil {
+				log.Error("Resend failed", "delivery", d.ID, "error", err)
+				failed++
+				continue
+			}
+			for _, o := range outcomes {
+				switch {
+				case o.Err != nil:
+					log.Error("Resend failed", "delivery", d.ID, "phone", o.Phone, "error", o.Err)
+					failed++
+				case o.Skipped != "":
+					skipped++
+				default:
+					if o.RecordErr != nil {
+						log.Warn("Resend not recorded", "delivery", d.ID, "phone", o.Phone, "error", o.RecordErr)
+					}
+					sent++
+				}
+			}
+		}
+
+		status := fmt.Sprintf("Resent to %d recipient(s), %d failed, %d skipped", sent, failed, skipped)
+		return m.load(status)()
+	}
+}
+
+// failedPhones lists the recipients a delivery did not reach
+func failedPhones(d database.Delivery) []string {
+	var failed []string
+	for _, r := range d.Failed() {
+		failed = append(failed, r.Phone)
+	}
+	return failed
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
+
+// Message types
+type DeliveriesLoadedMsg struct {
+	Deliveries []database.Delivery
+	Status     string
+	Error      error
+}
@@ -0,0 +1,354 @@
+package doctor
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/doctor"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+type Model struct {
+	config   *config.Config
+	styles   *styles.Styles
+	keys     *keys.Map
+	spinner  spinner.Model
+	confirm  components.Confirm
+	db       *database.Client
+	results  []doctor.Result
+	selected int
+	loading  bool
+	status   string
+	width    int
+	height   int
+	err      error
+}
+
+func New(cfg *config.Config, s *styles.Styles, km *keys.Map) *Model {
+	sp := components.NewSpinner(cfg, s)
+
+	// Create database client
+	db, err := database.NewClient(cfg)
+	if err != nil {
+		log.Error("Failed to create database client", "error", err)
+	}
+
+	return &Model{
+		config:  cfg,
+		styles:  s,
+		keys:    km,
+		spinner: sp,
+		confirm: components.NewConfirm(s),
+		db:      db,
+		err:     err,
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// StartLoading runs the check suite when the view becomes active
+func (m *Model) StartLoading() tea.Cmd {
+	if m.loading || m.db == nil {
+		return nil
+	}
+	m.loading = true
+	m.status = ""
+	return tea.Batch(m.spinner.Tick, m.runChecks)
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.MouseMsg:
+		if step := components.Wheel(msg); step != 0 && !m.loading {
+			m.selected = max(0, min(len(m.results)-1, m.selected+step))
+		}
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+		// The confirmation dialog captures every key while open
+		if m.confirm.Active() {
+			return m, m.confirm.Update(msg)
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.List.Up):
+			if m.selected > 0 {
+				m.selected--
+			}
+		case key.Matches(msg, m.keys.List.Down):
+			if m.selected < len(m.results)-1 {
+				m.selected++
+			}
+		case key.Matches(msg, m.keys.Item.Refresh):
+			return m, m.StartLoading()
+		case key.Matches(msg, m.keys.Doctor.Fix):
+			// Fix the selected check, asking first when the fix reaches
+			// beyond the database
+			if m.selected < len(m.results) && !m.results[m.selected].OK() && m.results[m.selected].Check.Fix != nil {
+				check := m.results[m.selected].Check
+				return m, m.confirm.Ask(check.Confirm != "", components.Prompt{
+					Title:   fmt.Sprintf("%s: %s?", check.Name, check.FixDescription),
+					Action:  "Fix",
+					Details: []string{check.Confirm},
+				}, requestFix(check))
+			}
+		case key.Matches(msg, m.keys.Doctor.FixAll):
+			// Fix every failing check; those needing confirmation are
+			// left to be fixed one at a time
+			var failing []doctor.Check
+			var skipped []string
+			for _, result := range m.results {
+				if result.OK() || result.Err != nil || result.Check.Fix == nil {
+					continue
+				}
+				if result.Check.Confirm != "" {
+					skipped = append(skipped, result.Check.Name)
+					continue
+				}
+				failing = append(failing, result.Check)
+			}
+			if len(failing) > 0 {
+				m.loading = true
+				return m, tea.Batch(m.spinner.Tick, m.fix(failing, skipped))
+			}
+			if len(skipped) > 0 {
+				m.status = skippedStatus(skipped)
+			}
+		}
+
+	case fixRequestedMsg:
+		m.loading = true
+		return m, tea.Batch(m.spinner.Tick, m.fix(msg.checks, nil))
+
+	case ChecksCompletedMsg:
+		m.loading = false
+		m.results = msg.Results
+		if msg.Status != "" {
+			m.status = msg.Status
+		}
+		if m.selected >= len(m.results) {
+			m.selected = 0
+		}
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) View() string {
+	if m.err != nil {
+		return m.renderError()
+	}
+
+	title := m.styles.Title.Render("🩺 Doctor")
+	description := m.styles.Muted.Render("Referential integrity checks for forms, recipients and contacts")
+
+	if m.loading {
+		return lipgloss.JoinVertical(
+			lipgloss.Top,
+			title,
+			description,
+			"",
+			m.spinner.View()+" Running checks...",
+		)
+	}
+
+	failing := 0
+	var rows []string
+	for i, result := range m.results {
+		var marker string
+		switch {
+		case result.Err != nil:
+			marker = m.styles.RenderError(result.Check.Name + ": " + result.Err.Error())
+		case result.OK():
+			marker = m.styles.RenderSuccess(result.Check.Name)
+		default:
+			marker = m.styles.RenderWarning(fmt.Sprintf("%s: %d issue(s)", result.Check.Name, len(result.Issues)))
+		}
+		if !result.OK() {
+			failing++
+		}
+
+		if i == m.selected {
+			rows = append(rows, m.styles.ActiveItem.Render("▶ "+marker))
+		} else {
+			rows = append(rows, "  "+marker)
+		}
+	}
+
+	summary := m.styles.Success.Render("All checks passed")
+	if failing > 0 {
+		summary = m.styles.Warning.Render(fmt.Sprintf("%d of %d checks need attention", failing, len(m.results)))
+	}
+
+	parts := []string{
+		title,
+		description,
+		"",
+		summary,
+		"",
+		lipgloss.JoinVertical(lipgloss.Top, rows...),
+		"",
+		m.renderDetail(),
+	}
+	// The confirmation dialog replaces the detail box while open
+	if m.confirm.Active() {
+		parts[len(parts)-1] = m.confirm.View()
+	}
+	if m.status != "" {
+		parts = append(parts, "", m.styles.Info.Render(m.status))
+	}
+	parts = append(parts, "", m.styles.Help.Render(keys.Help(
+		keys.Describe(m.keys.Doctor.Fix, "Fix selected"),
+		m.keys.Doctor.FixAll,
+		keys.Describe(m.keys.Item.Refresh, "Re-run"),
+		m.keys.Global.Back,
+	)))
+
+	return lipgloss.JoinVertical(lipgloss.Top, parts...)
+}
+
+// HasModal reports whether the confirmation dialog is open, so global keys
+// reach it instead of switching views
+func (m *Model) HasModal() bool {
+	return m.confirm.Active()
+}
+
+// ShortHelp lists the keys shown in the footer
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Join("Navigate", m.keys.List.Up, m.keys.List.Down),
+		m.keys.Doctor.Fix,
+		m.keys.Doctor.FixAll,
+		keys.Describe(m.keys.Item.Refresh, "Re-run"),
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.List.Up, m.keys.List.Down},
+		{keys.Describe(m.keys.Doctor.Fix, "Fix selected"), m.keys.Doctor.FixAll, keys.Describe(m.keys.Item.Refresh, "Re-run")},
+	}
+}
+
+func (m *Model) renderDetail() string {
+	if m.selected >= len(m.results) {
+		return ""
+	}
+	result := m.results[m.selected]
+
+	lines := []string{
+		m.styles.Subtitle.Render(result.Check.Description),
+	}
+
+	// Keep the detail box within the screen on large datasets
+	limit := m.height - 20 - len(m.results)
+	if limit < 5 {
+		limit = 5
+	}
+	for i, issue := range result.Issues {
+		if i == limit {
+			lines = append(lines, m.styles.Muted.Render(fmt.Sprintf("… and %d more", len(result.Issues)-limit)))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", m.styles.Label.Render(issue.Subject+":"), issue.Detail))
+	}
+	if !result.OK() && result.Err == nil {
+		lines = append(lines, "", m.styles.Muted.Render("Fix: "+result.Check.FixDescription))
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Colors.Border).
+		Padding(0, 2).
+		Width(90).
+		Render(lipgloss.JoinVertical(lipgloss.Top, lines...))
+}
+
+func (m *Model) renderError() string {
+	errorView := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
+	help := m.styles.Help.Render("Check your configuration and try again")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		errorView,
+		help,
+	)
+}
+
+func (m *Model) runChecks() tea.Msg {
+	return ChecksCompletedMsg{Results: doctor.Run(m.db)}
+}
+
+// fix runs the fixes of checks and re-runs the suite. skipped names checks
+// left out because they need confirmation, for the status line.
+func (m *Model) fix(checks []doctor.Check, skipped []string) tea.Cmd {
+	return func() tea.Msg {
+		fixed := 0
+		var failed []string
+		for _, check := range checks {
+			n, err := check.Fix(m.db)
+			fixed += n
+			if err != nil {
+				log.Error("Doctor fix failed", "check", check.Name, "error", err)
+				failed = append(failed, check.Name)
+			}
+		}
+
+		status := fmt.Sprintf("Fixed %d issue(s)", fixed)
+		if len(failed) > 0 {
+			status += fmt.Sprintf("; fix failed for %v", failed)
+		}
+		if len(skipped) > 0 {
+			status += "; " + skippedStatus(skipped)
+		}
+
+		return ChecksCompletedMsg{Results: doctor.Run(m.db), Status: status}
+	}
+}
+
+func skippedStatus(skipped []string) string {
+	return fmt.Sprintf("%v need confirmation, select and fix them one at a time", skipped)
+}
+
+func requestFix(check doctor.Check) tea.Cmd {
+	return func() tea.Msg {
+		return fixRequestedMsg{checks: []doctor.Check{check}}
+	}
+}
+
+// Message types
+type ChecksCompletedMsg struct {
+	Results []doctor.Result
+	Status  string
+}
+
+// fixRequestedMsg starts fixes once any confirmation was given
+type fixRequestedMsg struct {
+	checks []doctor.Check
+}
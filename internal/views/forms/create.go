@@ -57,11 +57,13 @@ func NewCreateView(cfg *config.Config, s *styles.Styles) *CreateView {
 	}
 
 	// Initialize with some default fields
-	v.formData.Fields = []FieldData{
-		{ElementorID: "name", Label: "Name", Type: "text", Required: true},
-		{ElementorID: "email", Label: "Email", Type: "email", Required: true},
-		{ElementorID: "phone", Label: "Phone", Type: "tel", Required: false},
-		{ElementorID: "message", Label: "Message", Type: "textarea", Required: false},
+	for _, field := range database.DefaultFields() {
+		v.formData.Fields = append(v.formData.Fields, FieldData{
+			ElementorID: field.ElementorID,
+			Label:       field.Label,
+			Type:        field.Type,
+			Required:    field.Required,
+		})
 	}
 
 	v.buildForm()
@@ -137,7 +139,7 @@ func (v *CreateView) buildForm() {
 				Affirmative("Yes, create it!").
 				Negative("No, go back"),
 		),
-	).WithTheme(huh.ThemeCharm())
+	).WithTheme(v.styles.Form())
 }
 
 func (v *CreateView) Init() tea.Cmd {
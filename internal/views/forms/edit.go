@@ -5,22 +5,29 @@ import (
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
 )
 
 type EditView struct {
 	config       *config.Config
 	styles       *styles.Styles
+	keys         *keys.Map
 	db           *database.Client
 	form         *huh.Form
 	formData     FormData
 	originalForm *database.Form
 	contacts     []database.Contact
+	history      components.History
+	versions     versionsPane
+	tab          int
 	err          error
 	width        int
 	height       int
@@ -28,7 +35,16 @@ type EditView struct {
 	loading      bool
 }
 
-func NewEditView(cfg *config.Config, s *styles.Styles, formID string) *EditView {
+// Tabs of the edit view, cycled with ctrl+t
+const (
+	editTab = iota
+	historyTab
+	versionsTab
+)
+
+var editTabs = []string{"Edit", "History", "Versions"}
+
+func NewEditView(cfg *config.Config, s *styles.Styles, km *keys.Map, formID string) *EditView {
 	// Create database client
 	db, err := database.NewClient(cfg)
 	if err != nil {
@@ -36,15 +52,19 @@ func NewEditView(cfg *config.Config, s *styles.Styles, formID string) *EditView
 		return &EditView{
 			config: cfg,
 			styles: s,
+			keys:   km,
 			err:    err,
 		}
 	}
 
 	v := &EditView{
-		config:  cfg,
-		styles:  s,
-		db:      db,
-		loading: true,
+		config:   cfg,
+		styles:   s,
+		keys:     km,
+		db:       db,
+		history:  components.NewHistory(s, km),
+		versions: newVersionsPane(s, km),
+		loading:  true,
 	}
 
 	// Load the form data
@@ -63,6 +83,14 @@ func (v *EditView) loadFormData(formID string) {
 	}
 	v.originalForm = form
 
+	// Load the audit trail for the history tab
+	v.history.SetEntries(v.db.GetAuditEntries(database.AuditFilter{
+		EntityType: database.AuditForm,
+		EntityID:   formID,
+		Limit:      50,
+	}))
+	v.versions.setVersions(v.db.GetFormVersions(formID))
+
 	// Convert to FormData
 	v.formData.ID = form.ID
 	v.formData.Name = form.Name
@@ -198,7 +226,7 @@ func (v *EditView) buildForm() {
 		),
 	)
 
-	v.form.WithTheme(huh.ThemeCharm())
+	v.form.WithTheme(v.styles.Form())
 	v.form.WithWidth(80)
 }
 
@@ -230,14 +258,48 @@ func (v *EditView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		v.width = msg.Width
 		v.height = msg.Height
+		v.history.SetHeight(v.height - 12)
+		v.versions.width = v.width
+
+	case tea.MouseMsg:
+		if components.Clicked(msg) {
+			if tab := components.TabAt(v.View(), v.styles, editTabs, v.tab, msg.X, msg.Y); tab >= 0 {
+				v.tab = tab
+				return v, nil
+			}
+		}
+		// The wheel scrolls the history and moves through the versions
+		if step := components.Wheel(msg); step != 0 {
+			switch v.tab {
+			case historyTab:
+				v.history.Scroll(step)
+			case versionsTab:
+				v.versions.cursor = max(0, min(len(v.versions.versions)-1, v.versions.cursor+step))
+			}
+			return v, nil
+		}
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
+		switch {
+		case key.Matches(msg, v.keys.Global.Back):
 			// Go back to list view
 			return v, func() tea.Msg {
 				return GoBackToListMsg{}
 			}
+		case key.Matches(msg, v.keys.Edit.NextTab):
+			// Cycle through the edit form, its history and its versions
+			v.tab = (v.tab + 1) % len(editTabs)
+			return v, nil
+		}
+
+		// The history and versions tabs take every other key
+		switch v.tab {
+		case historyTab:
+			v.history.Update(msg)
+			return v, nil
+		case versionsTab:
+			v.versions.update(msg)
+			return v, nil
 		}
 
 	case FormUpdatedMsg:
@@ -282,15 +344,37 @@ func (v *EditView) View() string {
 
 	title := v.styles.Title.Render(fmt.Sprintf("✏️ Edit Form: %s", v.formData.Name))
 	
-	// Form view
-	formView := v.form.View()
-
-	// Help text
-	help := v.styles.Help.Render("Tab: Next Field • Shift+Tab: Previous • Enter: Submit • Esc: Cancel")
+	// Form view, or the history and versions tabs
+	var formView, help string
+	switch v.tab {
+	case historyTab:
+		formView = v.history.View()
+		help = v.styles.Help.Render(keys.Help(
+			keys.Join("Scroll", v.keys.List.Up, v.keys.List.Down),
+			keys.Describe(v.keys.Edit.NextTab, "Versions"),
+			keys.Describe(v.keys.Global.Back, "Cancel"),
+		))
+	case versionsTab:
+		formView = v.versions.view()
+		help = v.styles.Help.Render(keys.Help(
+			keys.Join("Select Version", v.keys.List.Up, v.keys.List.Down),
+			keys.Describe(v.keys.Edit.NextTab, "Back to Edit"),
+			keys.Describe(v.keys.Global.Back, "Cancel"),
+		))
+	default:
+		formView = v.form.View()
+		// Tab and Enter belong to the form itself
+		help = v.styles.Help.Render("Tab: Next Field • Shift+Tab: Previous • Enter: Submit • " + keys.Help(
+			keys.Describe(v.keys.Edit.NextTab, "History"),
+			keys.Describe(v.keys.Global.Back, "Cancel"),
+		))
+	}
+	tabs := components.RenderTabs(v.styles, editTabs, v.tab)
 
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
 		title,
+		tabs,
 		"",
 		formView,
 		"",
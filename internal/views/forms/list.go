@@ -2,37 +2,57 @@ package forms
 
 import (
 	"fmt"
+	"strings"
+	"time"
 	
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/actions"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/views/webhook"
 )
 
 type ListView struct {
 	config  *config.Config
 	styles  *styles.Styles
+	keys    *keys.Map
 	table   table.Model
 	spinner spinner.Model
 	db      *database.Client
 	forms   []database.FormWithStats
-	loading bool
-	err     error
-	width   int
-	height  int
+	// buffered counts submissions held for each paused form
+	buffered map[string]int
+	// archived switches the list to archived forms
+	archived bool
+	confirm  components.Confirm
+	undo     components.Undo
+	// changes highlights forms a background refresh added or changed
+	changes  components.Changes
+	loading  bool
+	// refreshing is a background reload that keeps the table on screen
+	refreshing bool
+	updatedAt  time.Time
+	err      error
+	width    int
+	height   int
 }
 
-func NewListView(cfg *config.Config, s *styles.Styles) *ListView {
+func NewListView(cfg *config.Config, s *styles.Styles, km *keys.Map) *ListView {
 	// Create table with empty data initially
 	columns := []table.Column{
 		{Title: "ID", Width: 20},
 		{Title: "Name", Width: 30},
 		{Title: "Fields", Width: 10},
 		{Title: "Recipients", Width: 12},
+		{Title: "Status", Width: 20},
 		{Title: "Created", Width: 20},
 	}
 	
@@ -56,9 +76,7 @@ func NewListView(cfg *config.Config, s *styles.Styles) *ListView {
 	t.SetStyles(tableStyle)
 	
 	// Create spinner
-	sp := spinner.New()
-	sp.Spinner = spinner.Dot
-	sp.Style = s.Spinner
+	sp := components.NewSpinner(cfg, s)
 	
 	// Create database client
 	db, err := database.NewClient(cfg)
@@ -69,9 +87,12 @@ func NewListView(cfg *config.Config, s *styles.Styles) *ListView {
 	return &ListView{
 		config:  cfg,
 		styles:  s,
+		keys:    km,
 		table:   t,
 		spinner: sp,
 		db:      db,
+		confirm: components.NewConfirm(s),
+		undo:    components.NewUndo(s, km),
 		loading: false,  // Don't start loading immediately
 		err:     err,
 	}
@@ -97,18 +118,30 @@ func (m *ListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		m.table.SetHeight(m.height - 10)
 		
+	case tea.MouseMsg:
+		if m.loading || m.confirm.Active() {
+			return m, nil
+		}
+		components.TableMouse(&m.table, m.View(), msg)
+		return m, nil
+		
 	case tea.KeyMsg:
 		if m.loading {
 			return m, nil
 		}
+
+		// The confirmation dialog captures every key while open
+		if m.confirm.Active() {
+			return m, m.confirm.Update(msg)
+		}
 		
-		switch msg.String() {
-		case "n":
+		switch {
+		case key.Matches(msg, m.keys.Item.New):
 			// Create new form
 			return m, func() tea.Msg {
 				return SwitchToCreateMsg{}
 			}
-		case "e":
+		case key.Matches(msg, m.keys.Item.Edit):
 			// Edit selected form
 			if len(m.forms) > 0 {
 				selectedIdx := m.table.Cursor()
@@ -118,17 +151,65 @@ func (m *ListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
-		case "d":
+		case key.Matches(msg, m.keys.Item.Delete):
 			// Delete selected form
 			if len(m.forms) > 0 {
 				selectedIdx := m.table.Cursor()
 				if selectedIdx < len(m.forms) {
-					// TODO: Show confirmation dialog
-					// For now, just delete directly
-					return m, m.deleteForm(m.forms[selectedIdx].ID)
+					form := m.forms[selectedIdx]
+					return m, m.confirm.Ask(m.config.UI.ConfirmDestructive, components.Prompt{
+						Title:  fmt.Sprintf("Delete form %q?", form.Name),
+						Action: "Delete",
+						Details: []string{
+							fmt.Sprintf("removes its %d fields and %d recipients", form.FieldCount, form.NumberCount),
+							"drops its delivery logs, buffered submissions and queued messages (undo brings them back)",
+							fmt.Sprintf("/webhook/%s stops accepting submissions", form.ID),
+						},
+					}, m.deleteForm(form))
+				}
+			}
+		case key.Matches(msg, m.keys.Item.Archive):
+			// Archive the selected form, or restore it in the archived list
+			if len(m.forms) > 0 {
+				selectedIdx := m.table.Cursor()
+				if selectedIdx < len(m.forms) {
+					form := m.forms[selectedIdx]
+					if m.archived {
+						m.loading = true
+						return m, m.restoreForm(form)
+					}
+					return m, m.confirm.Ask(m.config.UI.ConfirmDestructive, components.Prompt{
+						Title:  fmt.Sprintf("Archive form %q?", form.Name),
+						Action: "Archive",
+						Details: []string{
+							fmt.Sprintf("/webhook/%s stops accepting submissions", form.ID),
+							"fields and recipients are kept until the form is purged",
+						},
+					}, m.archiveForm(form))
+				}
+			}
+		case key.Matches(msg, m.keys.Item.Pause):
+			// Pause or resume the selected form
+			if len(m.forms) > 0 && !m.archived {
+				selectedIdx := m.table.Cursor()
+				if selectedIdx < len(m.forms) {
+					m.loading = true
+					return m, m.togglePause(m.forms[selectedIdx])
 				}
 			}
-		case "enter":
+		case key.Matches(msg, m.keys.Item.ShowArchived):
+			// Toggle between active and archived forms
+			m.archived = !m.archived
+			m.table.SetCursor(0)
+			m.loading = true
+			return m, m.loadForms
+		case key.Matches(msg, m.keys.Item.Undo):
+			// Undo the last deletion or archive
+			if m.undo.Active() {
+				m.loading = true
+				return m, m.undo.Trigger()
+			}
+		case key.Matches(msg, m.keys.List.Open):
 			// View form details (for now, same as edit)
 			if len(m.forms) > 0 {
 				selectedIdx := m.table.Cursor()
@@ -138,7 +219,7 @@ func (m *ListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
-		case "r":
+		case key.Matches(msg, m.keys.Item.Refresh):
 			// Refresh
 			m.loading = true
 			return m, m.loadForms
@@ -146,9 +227,80 @@ func (m *ListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 	case FormsLoadedMsg:
 		m.loading = false
+		refreshing := m.refreshing
+		m.refreshing = false
+		if msg.Error != nil && refreshing {
+			// Keep the forms on screen; the footer shows how old they are
+			log.Warn("Failed to refresh forms", "error", msg.Error)
+			break
+		}
 		m.forms = msg.Forms
+		m.buffered = msg.Buffered
 		m.err = msg.Error
-		m.updateTable()
+		if msg.Error == nil {
+			m.updatedAt = time.Now()
+		}
+		cmds = append(cmds, m.updateTable(refreshing))
+
+	case FormDeletedMsg:
+		if msg.Error != nil {
+			m.loading = false
+			m.err = msg.Error
+			return m, nil
+		}
+		snapshot := msg.Snapshot
+		m.loading = true
+		cmds = append(cmds,
+			m.undo.Offer(fmt.Sprintf("Deleted form %q", snapshot.Form.Name), func() error {
+				return m.db.RestoreForm(snapshot)
+			}),
+			m.loadForms,
+		)
+
+	case FormArchivedMsg:
+		if msg.Error != nil {
+			m.loading = false
+			m.err = msg.Error
+			return m, nil
+		}
+		formID := msg.FormID
+		m.loading = true
+		cmds = append(cmds,
+			m.undo.Offer(fmt.Sprintf("Archived form %q", msg.Name), func() error {
+				return m.db.UnarchiveForm(formID)
+			}),
+			m.loadForms,
+		)
+
+	case FormPauseToggledMsg:
+		if msg.Error != nil {
+			m.loading = false
+			m.err = msg.Error
+			return m, nil
+		}
+		m.loading = true
+		cmds = append(cmds, m.loadForms)
+
+	case FormRestoredMsg:
+		if msg.Error != nil {
+			m.loading = false
+			m.err = msg.Error
+			return m, nil
+		}
+		m.loading = true
+		cmds = append(cmds, m.loadForms)
+
+	case components.UndoExpiredMsg:
+		m.undo.Expire(msg)
+
+	case components.UndoCompletedMsg:
+		if msg.Error != nil {
+			m.loading = false
+			m.err = msg.Error
+			return m, nil
+		}
+		m.loading = true
+		cmds = append(cmds, m.loadForms)
 		
 	case spinner.TickMsg:
 		if m.loading {
@@ -158,6 +310,8 @@ func (m *ListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 	
+	// The keymap changes when settings are saved
+	m.table.KeyMap = m.keys.Table()
 	var cmd tea.Cmd
 	m.table, cmd = m.table.Update(msg)
 	cmds = append(cmds, cmd)
@@ -175,6 +329,9 @@ func (m *ListView) View() string {
 	}
 	
 	title := m.styles.Title.Render("📝 Forms Management")
+	if m.archived {
+		title = m.styles.Title.Render("🗄  Archived Forms")
+	}
 	
 	// Stats bar
 	totalFields := 0
@@ -183,13 +340,52 @@ func (m *ListView) View() string {
 		totalFields += form.FieldCount
 		totalRecipients += form.NumberCount
 	}
-	stats := m.styles.Muted.Render(fmt.Sprintf("%d forms • %d total fields • %d recipients", len(m.forms), totalFields, totalRecipients))
+	paused := 0
+	now := time.Now()
+	for _, form := range m.forms {
+		if form.IsPaused(now) {
+			paused++
+		}
+	}
+	stats := m.styles.Muted.Render(fmt.Sprintf("%d forms (%d paused) • %d total fields • %d recipients", len(m.forms), paused, totalFields, totalRecipients))
+	if m.archived {
+		stats = m.styles.Muted.Render(fmt.Sprintf("%d archived forms • not receiving submissions", len(m.forms)))
+	}
 	
-	// Table
+	// Table, or the confirmation dialog while it is open
 	tableView := m.table.View()
+	if m.changes.Active() {
+		tableView = components.HighlightTable(m.table, m.styles.Highlight, func(row int) bool {
+			return row < len(m.forms) && m.changes.Changed(m.forms[row].ID)
+		})
+	}
+	if m.confirm.Active() {
+		tableView = m.confirm.View()
+	}
 	
 	// Actions hint
-	actions := m.styles.Help.Render("n: New • e: Edit • d: Delete • Enter: View • r: Refresh")
+	actions := m.styles.Help.Render(keys.Help(
+		m.keys.Item.New,
+		m.keys.Item.Edit,
+		keys.Describe(m.keys.Item.Pause, "Pause/Resume"),
+		m.keys.Item.Archive,
+		m.keys.Item.Delete,
+		keys.Describe(m.keys.Item.ShowArchived, "Archived"),
+		keys.Describe(m.keys.List.Open, "View"),
+		m.keys.Item.Refresh,
+	))
+	if m.archived {
+		actions = m.styles.Help.Render(keys.Help(
+			keys.Describe(m.keys.Item.Archive, "Restore"),
+			keys.Describe(m.keys.Item.Delete, "Delete permanently"),
+			m.keys.Item.Edit,
+			keys.Describe(m.keys.Item.ShowArchived, "Active forms"),
+			m.keys.Item.Refresh,
+		))
+	}
+	if undo := m.undo.View(); undo != "" {
+		actions = lipgloss.JoinVertical(lipgloss.Top, undo, actions)
+	}
 	
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
@@ -211,7 +407,7 @@ func (m *ListView) renderLoading() string {
 
 func (m *ListView) renderError() string {
 	errorView := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
-	help := m.styles.Help.Render("Press 'r' to retry")
+	help := m.styles.Help.Render(fmt.Sprintf("Press '%s' to retry", m.keys.Item.Refresh.Help().Key))
 	
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -227,7 +423,12 @@ func (m *ListView) loadForms() tea.Msg {
 		}
 	}
 	
-	forms, err := m.db.GetAllForms()
+	load := m.db.GetAllForms
+	if m.archived {
+		load = m.db.GetArchivedForms
+	}
+	
+	forms, err := load()
 	if err != nil {
 		log.Error("Failed to load forms", "error", err)
 		return FormsLoadedMsg{
@@ -235,30 +436,49 @@ func (m *ListView) loadForms() tea.Msg {
 		}
 	}
 	
+	// Older databases have no buffer table yet; show forms without counts
+	buffered, err := m.db.GetBufferedCounts()
+	if err != nil {
+		log.Warn("Failed to load buffered submissions", "error", err)
+	}
+	
 	return FormsLoadedMsg{
-		Forms: forms,
+		Forms:    forms,
+		Buffered: buffered,
 	}
 }
 
-func (m *ListView) updateTable() {
+// updateTable fills the table with the loaded forms and, when highlight
+// is set, highlights the ones that changed since the last load
+func (m *ListView) updateTable(highlight bool) tea.Cmd {
 	var rows []table.Row
+	prints := make(map[string]string, len(m.forms))
 	for _, form := range m.forms {
+		// The archived list shows when each form was archived instead
+		date := form.CreatedAt
+		if m.archived && form.ArchivedAt != nil {
+			date = *form.ArchivedAt
+		}
 		rows = append(rows, table.Row{
 			form.ID,
 			form.Name,
 			fmt.Sprintf("%d", form.FieldCount),
 			fmt.Sprintf("%d", form.NumberCount),
-			form.CreatedAt.Format("2006-01-02 15:04"),
+			m.formStatus(form.Form),
+			date.Format("2006-01-02 15:04"),
 		})
+		prints[form.ID] = strings.Join(rows[len(rows)-1], "\t")
 	}
 	
 	m.table.SetRows(rows)
+	return m.changes.Track(prints, highlight)
 }
 
 // Message types
 type FormsLoadedMsg struct {
-	Forms []database.FormWithStats
-	Error error
+	Forms    []database.FormWithStats
+	Buffered map[string]int
+	Error    error
 }
 
 type ViewActivatedMsg struct{}
@@ -270,6 +490,24 @@ type SwitchToEditMsg struct {
 }
 
 type FormDeletedMsg struct {
+	FormID   string
+	Snapshot *database.FormSnapshot
+	Error    error
+}
+
+type FormArchivedMsg struct {
+	FormID string
+	Name   string
+	Error  error
+}
+
+type FormPauseToggledMsg struct {
+	FormID string
+	Paused bool
+	Error  error
+}
+
+type FormRestoredMsg struct {
 	FormID string
 	Error  error
 }
@@ -289,14 +527,138 @@ func (m *ListView) ForceReload() tea.Cmd {
 	return m.loadForms
 }
 
-func (m *ListView) deleteForm(formID string) tea.Cmd {
+// Refresh reloads the forms in the background
+func (m *ListView) Refresh() tea.Cmd {
+	if m.loading || m.refreshing {
+		return nil
+	}
+	m.refreshing = true
+	return m.loadForms
+}
+
+// UpdatedAt reports when the forms on screen were loaded
+func (m *ListView) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// HasModal reports whether the confirmation dialog is capturing keys
+func (m *ListView) HasModal() bool {
+	return m.confirm.Active()
+}
+
+// ShortHelp lists the keys shown in the footer
+func (m *ListView) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Join("Navigate", m.keys.List.Up, m.keys.List.Down),
+		m.keys.Item.New,
+		m.keys.Item.Edit,
+		m.keys.Item.Delete,
+		m.keys.Item.Undo,
+		m.keys.List.Open,
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *ListView) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.List.Up, m.keys.List.Down, m.keys.List.Top, m.keys.List.Bottom},
+		{m.keys.List.PageUp, m.keys.List.PageDown, m.keys.List.HalfPageUp, m.keys.List.HalfPageDown},
+		{m.keys.List.Open, m.keys.Item.New, m.keys.Item.Edit, m.keys.Item.Delete, m.keys.Item.Undo},
+		{m.keys.Item.Archive, m.keys.Item.ShowArchived, m.keys.Item.Pause, m.keys.Item.Refresh},
+	}
+}
+
+// RegisterActions adds form commands and every active form to the palette
+func (m *ListView) RegisterActions(r *actions.Registry) {
+	r.Register(actions.Action{
+		Title:    "Create form",
+		Group:    "Forms",
+		Keywords: []string{"new form", "add form"},
+		Run:      actions.Send(SwitchToCreateMsg{}),
+	})
+	r.RegisterSource(func() ([]actions.Action, error) {
+		if m.db == nil {
+			return nil, fmt.Errorf("database client not initialized")
+		}
+		forms, err := m.db.GetAllForms()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load forms: %w", err)
+		}
+		var found []actions.Action
+		for _, form := range forms {
+			found = append(found,
+				actions.Action{
+					Title: "Edit form " + form.Name,
+					Group: "Forms",
+					Hint:  form.ID,
+					Run:   actions.Send(SwitchToEditMsg{FormID: form.ID}),
+				},
+				actions.Action{
+					Title: "Test webhook for " + form.Name,
+					Group: "Forms",
+					Hint:  form.ID,
+					Run:   actions.Navigate(actions.ViewWebhook, "Test Webhook", webhook.SelectFormMsg{FormID: form.ID}),
+				},
+			)
+		}
+		return found, nil
+	})
+}
+
+func (m *ListView) deleteForm(form database.FormWithStats) tea.Cmd {
 	return func() tea.Msg {
-		err := m.db.DeleteForm(formID)
+		// Snapshot first so the deletion can be undone
+		snapshot, err := m.db.SnapshotForm(form.ID)
 		if err != nil {
-			return FormDeletedMsg{FormID: formID, Error: err}
+			return FormDeletedMsg{FormID: form.ID, Error: err}
 		}
-		// Reload forms after deletion
-		forms, err := m.db.GetAllForms()
-		return FormsLoadedMsg{Forms: forms, Error: err}
+		if err := m.db.DeleteForm(form.ID); err != nil {
+			return FormDeletedMsg{FormID: form.ID, Error: err}
+		}
+		return FormDeletedMsg{FormID: form.ID, Snapshot: snapshot}
+	}
+}
+
+func (m *ListView) archiveForm(form database.FormWithStats) tea.Cmd {
+	return func() tea.Msg {
+		err := m.db.ArchiveForm(form.ID)
+		return FormArchivedMsg{FormID: form.ID, Name: form.Name, Error: err}
+	}
+}
+
+func (m *ListView) restoreForm(form database.FormWithStats) tea.Cmd {
+	return func() tea.Msg {
+		err := m.db.UnarchiveForm(form.ID)
+		return FormRestoredMsg{FormID: form.ID, Error: err}
 	}
-}
\ No newline at end of file
+}
+
+func (m *ListView) togglePause(form database.FormWithStats) tea.Cmd {
+	return func() tea.Msg {
+		if form.IsPaused(time.Now()) {
+			err := m.db.ResumeForm(form.ID)
+			return FormPauseToggledMsg{FormID: form.ID, Paused: false, Error: err}
+		}
+		err := m.db.PauseForm(form.ID, nil)
+		return FormPauseToggledMsg{FormID: form.ID, Paused: true, Error: err}
+	}
+}
+
+// formStatus describes whether the form is delivering or buffering
+func (m *ListView) formStatus(form database.Form) string {
+	if m.archived {
+		return "archived"
+	}
+	if !form.IsPaused(time.Now()) {
+		return "● active"
+	}
+
+	status := "⏸ paused"
+	if form.PausedUntil != nil {
+		status = "⏸ until " + form.PausedUntil.Local().Format("01-02 15:04")
+	}
+	if queued := m.buffered[form.ID]; queued > 0 {
+		status += fmt.Sprintf(" (%d)", queued)
+	}
+	return status
+}
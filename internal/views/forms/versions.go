@@ -0,0 +1,126 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/diff"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+// versionsPane lists a form's saved versions and shows the selected one
+// side by side with the version before it
+type versionsPane struct {
+	styles   *styles.Styles
+	keys     *keys.Map
+	versions []database.FormVersion
+	err      error
+	cursor   int
+	width    int
+}
+
+func newVersionsPane(s *styles.Styles, km *keys.Map) versionsPane {
+	return versionsPane{styles: s, keys: km, width: 100}
+}
+
+func (p *versionsPane) setVersions(versions []database.FormVersion, err error) {
+	p.versions = versions
+	p.err = err
+	p.cursor = 0
+}
+
+func (p *versionsPane) update(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, p.keys.List.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(msg, p.keys.List.Down):
+		if p.cursor < len(p.versions)-1 {
+			p.cursor++
+		}
+	}
+}
+
+func (p versionsPane) view() string {
+	if p.err != nil {
+		return p.styles.Error.Render(fmt.Sprintf("Failed to load versions: %v", p.err))
+	}
+	if len(p.versions) == 0 {
+		return p.styles.Muted.Render("No saved versions yet")
+	}
+
+	// Version list, newest first, around the cursor
+	start := max(p.cursor-2, 0)
+	end := min(start+5, len(p.versions))
+	var list []string
+	for i := start; i < end; i++ {
+		v := p.versions[i]
+		line := fmt.Sprintf("v%-4d %s  %s  %s", v.Version, v.CreatedAt.Local().Format("2006-01-02 15:04"), v.Actor, v.Note)
+		if i == p.cursor {
+			list = append(list, p.styles.ActiveItem.Render(line))
+		} else {
+			list = append(list, p.styles.MenuItem.Render(line))
+		}
+	}
+
+	selected := p.versions[p.cursor]
+	var previous database.FormVersion
+	header := fmt.Sprintf("v%d (first version)", selected.Version)
+	if p.cursor+1 < len(p.versions) {
+		previous = p.versions[p.cursor+1]
+		header = fmt.Sprintf("v%d → v%d", previous.Version, selected.Version)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, list...),
+		"",
+		p.styles.Label.Render(header),
+		p.renderDiff(previous, selected),
+	)
+}
+
+// renderDiff draws the two versions in columns, colouring changed lines
+func (p versionsPane) renderDiff(before, after database.FormVersion) string {
+	var beforeLines []string
+	if before.Version > 0 {
+		beforeLines = before.Lines()
+	}
+	lines := diff.Lines(beforeLines, after.Lines())
+
+	column := max((p.width-3)/2, 20)
+	cell := lipgloss.NewStyle().Width(column).MaxWidth(column)
+	removed := cell.Copy().Foreground(p.styles.Colors.Error)
+	added := cell.Copy().Foreground(p.styles.Colors.Success)
+	separator := p.styles.Muted.Render(" │ ")
+
+	var rows []string
+	for _, line := range lines {
+		left, right := cell.Render(truncate(line.Left, column)), cell.Render(truncate(line.Right, column))
+		switch line.Op {
+		case diff.Delete:
+			left = removed.Render(truncate(line.Left, column))
+		case diff.Insert:
+			right = added.Render(truncate(line.Right, column))
+		case diff.Change:
+			left = removed.Render(truncate(line.Left, column))
+			right = added.Render(truncate(line.Right, column))
+		}
+		rows = append(rows, left+separator+right)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,785 @@
+package leads
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+// boardLimit caps how many leads the board loads at once
+const boardLimit = 500
+
+// cardsPerColumn caps how many cards a column shows around the cursor
+const cardsPerColumn = 8
+
+// modal is the dialog open over the board
+type modal int
+
+const (
+	modalNone modal = iota
+	modalNote
+	modalFollowUp
+	modalOwner
+	modalDetail
+)
+
+// Model is the leads board: one column per lead status, for one form at a
+// time or all of them
+type Model struct {
+	config   *config.Config
+	styles   *styles.Styles
+	keys     *keys.Map
+	spinner  spinner.Model
+	input    textinput.Model
+	db       *database.Client
+	columns  map[string][]database.Submission
+	fields   map[string][]database.Field
+	contacts []database.Contact
+	forms    []string
+	// formFilter indexes forms; -1 shows every form
+	formFilter int
+	column     int
+	row        int
+	// focus is a lead to put the cursor on once the board reloads
+	focus int
+	modal modal
+	// ownerCursor indexes contacts; 0 is "unassigned"
+	ownerCursor int
+	notes       []database.LeadNote
+	history     []database.LeadStatusChange
+	loading     bool
+	// refreshing is a background reload that keeps the board on screen
+	refreshing bool
+	updatedAt  time.Time
+	// changes highlights leads a background refresh added or moved
+	changes components.Changes
+	status  string
+	err     error
+	width   int
+	height  int
+}
+
+func New(cfg *config.Config, s *styles.Styles, km *keys.Map) *Model {
+	sp := components.NewSpinner(cfg, s)
+
+	input := textinput.New()
+	input.CharLimit = 500
+
+	// Create database client
+	db, err := database.NewClient(cfg)
+	if err != nil {
+		log.Error("Failed to create database client", "error", err)
+	}
+
+	return &Model{
+		config:     cfg,
+		styles:     s,
+		keys:       km,
+		spinner:    sp,
+		input:      input,
+		db:         db,
+		formFilter: -1,
+		err:        err,
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Refresh reloads the board in the background
+func (m *Model) Refresh() tea.Cmd {
+	if m.loading || m.refreshing || m.db == nil {
+		return nil
+	}
+	m.refreshing = true
+	return m.load("")
+}
+
+// UpdatedAt reports when the board on screen was loaded
+func (m *Model) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// StartLoading loads the board when the view becomes active
+func (m *Model) StartLoading() tea.Cmd {
+	if m.loading || m.db == nil {
+		return nil
+	}
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, m.load(""))
+}
+
+// HasModal reports whether Esc should close a dialog instead of leaving
+// the view
+func (m *Model) HasModal() bool {
+	return m.modal != modalNone
+}
+
+// ShortHelp lists the keys shown in the footer
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Join("Navigate", m.keys.List.Left, m.keys.List.Right, m.keys.List.Up, m.keys.List.Down),
+		keys.Join("Move", m.keys.Leads.MoveBack, m.keys.Leads.MoveForward),
+		m.keys.Leads.Note,
+		m.keys.Leads.Owner,
+		m.keys.Leads.FollowUp,
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.List.Left, m.keys.List.Right, m.keys.List.Up, m.keys.List.Down, keys.Describe(m.keys.List.Open, "Details")},
+		{m.keys.Leads.MoveBack, m.keys.Leads.MoveForward, m.keys.Leads.Note, m.keys.Leads.Owner, m.keys.Leads.FollowUp},
+		{m.keys.Filter.Form, m.keys.Item.Refresh},
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+		if m.modal != modalNone {
+			return m, m.updateModal(msg)
+		}
+
+		cards := m.columns[database.LeadStatuses[m.column]]
+		switch {
+		case key.Matches(msg, m.keys.List.Left):
+			if m.column > 0 {
+				m.column--
+				m.clampRow()
+			}
+		case key.Matches(msg, m.keys.List.Right):
+			if m.column < len(database.LeadStatuses)-1 {
+				m.column++
+				m.clampRow()
+			}
+		case key.Matches(msg, m.keys.List.Up):
+			if m.row > 0 {
+				m.row--
+			}
+		case key.Matches(msg, m.keys.List.Down):
+			if m.row < len(cards)-1 {
+				m.row++
+			}
+		case key.Matches(msg, m.keys.Leads.MoveBack):
+			if lead := m.selected(); lead != nil && m.column > 0 {
+				return m, m.move(lead.ID, database.LeadStatuses[m.column-1], -1)
+			}
+		case key.Matches(msg, m.keys.Leads.MoveForward):
+			if lead := m.selected(); lead != nil && m.column < len(database.LeadStatuses)-1 {
+				return m, m.move(lead.ID, database.LeadStatuses[m.column+1], 1)
+			}
+		case key.Matches(msg, m.keys.Leads.Note):
+			if m.selected() != nil {
+				return m, m.openInput(modalNote, "Note: ", "")
+			}
+		case key.Matches(msg, m.keys.Leads.FollowUp):
+			if lead := m.selected(); lead != nil {
+				value := ""
+				if lead.FollowUpAt != nil {
+					value = lead.FollowUpAt.Local().Format("2006-01-02 15:04")
+				}
+				return m, m.openInput(modalFollowUp, "Follow up: ", value)
+			}
+		case key.Matches(msg, m.keys.Leads.Owner):
+			if lead := m.selected(); lead != nil {
+				m.modal = modalOwner
+				m.ownerCursor = 0
+				for i, contact := range m.contacts {
+					if lead.OwnerContactID != nil && contact.ID == *lead.OwnerContactID {
+						m.ownerCursor = i + 1
+					}
+				}
+			}
+		case key.Matches(msg, m.keys.List.Open):
+			if lead := m.selected(); lead != nil {
+				m.loading = true
+				return m, m.loadDetail(lead.ID)
+			}
+		case key.Matches(msg, m.keys.Filter.Form):
+			// Cycle through the forms, then back to every form
+			m.formFilter++
+			if m.formFilter >= len(m.forms) {
+				m.formFilter = -1
+			}
+			m.row = 0
+			m.loading = true
+			return m, m.load("")
+		case key.Matches(msg, m.keys.Item.Refresh):
+			m.loading = true
+			return m, m.load("")
+		}
+
+	case LeadsLoadedMsg:
+		m.loading = false
+		refreshing := m.refreshing
+		m.refreshing = false
+		if msg.Error != nil {
+			if refreshing {
+				// Keep the board on screen; the footer shows how old it is
+				log.Warn("Failed to refresh leads", "error", msg.Error)
+				break
+			}
+			m.status = fmt.Sprintf("Failed to load leads: %v", msg.Error)
+			break
+		}
+		m.updatedAt = time.Now()
+		m.columns = make(map[string][]database.Submission, len(database.LeadStatuses))
+		for _, lead := range msg.Leads {
+			m.columns[lead.LeadStatus] = append(m.columns[lead.LeadStatus], lead)
+		}
+		m.fields = msg.Fields
+		m.contacts = msg.Contacts
+		prints := make(map[string]string, len(msg.Leads))
+		for _, lead := range msg.Leads {
+			prints[fmt.Sprint(lead.ID)] = lead.LeadStatus + " " + ansi.Strip(m.renderCard(lead, 80, false))
+		}
+		cmds = append(cmds, m.changes.Track(prints, refreshing))
+		m.forms = msg.Forms
+		if m.formFilter >= len(m.forms) {
+			m.formFilter = -1
+		}
+		m.status = msg.Status
+		m.clampRow()
+		if m.focus != 0 {
+			for i, lead := range m.columns[database.LeadStatuses[m.column]] {
+				if lead.ID == m.focus {
+					m.row = i
+				}
+			}
+			m.focus = 0
+		}
+
+	case LeadDetailMsg:
+		m.loading = false
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Failed to load lead: %v", msg.Error)
+			break
+		}
+		m.notes = msg.Notes
+		m.history = msg.History
+		m.modal = modalDetail
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) updateModal(msg tea.KeyMsg) tea.Cmd {
+	switch m.modal {
+	case modalDetail:
+		switch {
+		case key.Matches(msg, m.keys.Global.Back, m.keys.List.Open, m.keys.Global.Quit):
+			m.modal = modalNone
+		}
+		return nil
+
+	case modalOwner:
+		switch {
+		case key.Matches(msg, m.keys.Global.Back):
+			m.modal = modalNone
+		case key.Matches(msg, m.keys.List.Up):
+			if m.ownerCursor > 0 {
+				m.ownerCursor--
+			}
+		case key.Matches(msg, m.keys.List.Down):
+			if m.ownerCursor < len(m.contacts) {
+				m.ownerCursor++
+			}
+		case key.Matches(msg, m.keys.List.Open):
+			m.modal = modalNone
+			var owner *int
+			if m.ownerCursor > 0 {
+				id := m.contacts[m.ownerCursor-1].ID
+				owner = &id
+			}
+			return m.assign(m.selected().ID, owner)
+		}
+		return nil
+	}
+
+	// Note and follow-up dialogs edit the text input
+	switch msg.String() {
+	case "esc":
+		m.closeInput()
+		return nil
+	case "enter":
+		value := strings.TrimSpace(m.input.Value())
+		id := m.selected().ID
+		kind := m.modal
+		m.closeInput()
+		if kind == modalNote {
+			if value == "" {
+				return nil
+			}
+			return m.addNote(id, value)
+		}
+		at, err := parseFollowUp(value)
+		if err != nil {
+			m.status = err.Error()
+			return nil
+		}
+		return m.setFollowUp(id, at)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return cmd
+}
+
+func (m *Model) View() string {
+	if m.err != nil {
+		return m.renderError()
+	}
+
+	title := m.styles.Title.Render("🗂  Leads")
+	if m.loading && m.columns == nil {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "", m.spinner.View()+" Loading leads...")
+	}
+
+	if m.modal == modalDetail {
+		return lipgloss.JoinVertical(lipgloss.Top,
+			title,
+			"",
+			m.renderDetail(),
+			"",
+			m.styles.Help.Render(keys.Help(keys.Describe(m.keys.Global.Back, "Back to board"))),
+		)
+	}
+
+	parts := []string{
+		title,
+		m.styles.Muted.Render("Form: " + m.formLabel()),
+		"",
+		m.renderBoard(),
+	}
+
+	switch m.modal {
+	case modalNote, modalFollowUp:
+		parts = append(parts, "", m.input.View())
+		if m.modal == modalFollowUp {
+			parts = append(parts, m.styles.Muted.Render("2006-01-02 15:04, a duration like 48h, or empty to clear"))
+		}
+	case modalOwner:
+		parts = append(parts, "", m.renderOwners())
+	}
+
+	if m.loading {
+		parts = append(parts, "", m.spinner.View()+" Saving...")
+	} else if m.status != "" {
+		parts = append(parts, "", m.styles.Info.Render(m.status))
+	}
+
+	parts = append(parts, "", m.styles.Help.Render(keys.Help(
+		keys.Join("Move", m.keys.List.Left, m.keys.List.Right, m.keys.List.Up, m.keys.List.Down),
+		keys.Join("Change status", m.keys.Leads.MoveBack, m.keys.Leads.MoveForward),
+		m.keys.Leads.Note,
+		m.keys.Leads.Owner,
+		m.keys.Leads.FollowUp,
+		keys.Describe(m.keys.List.Open, "Details"),
+		m.keys.Filter.Form,
+		m.keys.Item.Refresh,
+	)))
+
+	return lipgloss.JoinVertical(lipgloss.Top, parts...)
+}
+
+func (m *Model) renderBoard() string {
+	width := 30
+	if m.width > 0 {
+		width = max((m.width-4)/len(database.LeadStatuses)-3, 20)
+	}
+
+	var columns []string
+	for i, status := range database.LeadStatuses {
+		cards := m.columns[status]
+		active := i == m.column
+
+		heading := m.styles.Subtitle.Render(fmt.Sprintf("%s (%d)", statusLabel(status), len(cards)))
+		lines := []string{heading, ""}
+
+		// Scroll the active column so the cursor stays visible
+		start := 0
+		if active && m.row >= cardsPerColumn {
+			start = m.row - cardsPerColumn + 1
+		}
+		end := min(start+cardsPerColumn, len(cards))
+		if start > 0 {
+			lines = append(lines, m.styles.Muted.Render(fmt.Sprintf("↑ %d more", start)))
+		}
+		for j := start; j < end; j++ {
+			lines = append(lines, m.renderCard(cards[j], width-2, active && j == m.row))
+		}
+		if end < len(cards) {
+			lines = append(lines, m.styles.Muted.Render(fmt.Sprintf("↓ %d more", len(cards)-end)))
+		}
+		if len(cards) == 0 {
+			lines = append(lines, m.styles.Muted.Render("No leads"))
+		}
+
+		border := m.styles.Colors.Border
+		if active {
+			border = m.styles.Colors.Primary
+		}
+		columns = append(columns, lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(border).
+			Padding(0, 1).
+			MarginRight(1).
+			Width(width).
+			Render(lipgloss.JoinVertical(lipgloss.Top, lines...)))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+}
+
+func (m *Model) renderCard(lead database.Submission, width int, selected bool) string {
+	var values []string
+	for _, field := range lead.LabeledFields(m.fields[lead.FormID]) {
+		if field.Value != "" {
+			values = append(values, field.Value)
+		}
+		if len(values) == 2 {
+			break
+		}
+	}
+	name := truncate(fmt.Sprintf("#%d %s", lead.ID, strings.Join(values, " • ")), width)
+
+	meta := []string{lead.CreatedAt.Local().Format("Jan 02")}
+	if m.formFilter < 0 {
+		meta = append(meta, lead.FormID)
+	}
+	if lead.OwnerContactID != nil {
+		meta = append(meta, "@"+m.ownerName(*lead.OwnerContactID))
+	}
+	metaLine := m.styles.Muted.Render(truncate(strings.Join(meta, " • "), width))
+
+	if lead.FollowUpAt != nil {
+		style := m.styles.Muted
+		if lead.FollowUpAt.Before(time.Now()) {
+			style = m.styles.Warning
+		}
+		metaLine += "\n" + style.Render("⏰ "+lead.FollowUpAt.Local().Format("Jan 02 15:04"))
+	}
+
+	if selected {
+		return m.styles.ActiveItem.Render(name) + "\n" + metaLine
+	}
+	if m.changes.Changed(fmt.Sprint(lead.ID)) {
+		return m.styles.Highlight.Render(name) + "\n" + metaLine
+	}
+	return m.styles.Text.Render(name) + "\n" + metaLine
+}
+
+func (m *Model) renderOwners() string {
+	lines := []string{m.styles.Subtitle.Render("Assign owner")}
+	options := []string{"Unassigned"}
+	for _, contact := range m.contacts {
+		options = append(options, fmt.Sprintf("%s (%s)", contact.Name, contact.PhoneNumber))
+	}
+	for i, option := range options {
+		if i == m.ownerCursor {
+			lines = append(lines, m.styles.ActiveItem.Render("▸ "+option))
+		} else {
+			lines = append(lines, m.styles.Text.Render("  "+option))
+		}
+	}
+	lines = append(lines, m.styles.Help.Render(keys.Help(
+		keys.Describe(m.keys.List.Open, "Assign"),
+		keys.Describe(m.keys.Global.Back, "Cancel"),
+	)))
+	return lipgloss.JoinVertical(lipgloss.Top, lines...)
+}
+
+func (m *Model) renderDetail() string {
+	lead := m.selected()
+	if lead == nil {
+		return ""
+	}
+
+	lines := []string{
+		m.styles.Subtitle.Render(fmt.Sprintf("Lead #%d • %s • %s", lead.ID, lead.FormID, statusLabel(lead.LeadStatus))),
+		m.styles.Muted.Render(lead.CreatedAt.Local().Format("Monday, 2006-01-02 15:04")),
+		"",
+	}
+	for _, field := range lead.LabeledFields(m.fields[lead.FormID]) {
+		lines = append(lines, fmt.Sprintf("%s %s", m.styles.Label.Render(field.Label+":"), field.Value))
+	}
+
+	owner := "Unassigned"
+	if lead.OwnerContactID != nil {
+		owner = m.ownerName(*lead.OwnerContactID)
+	}
+	lines = append(lines, "", fmt.Sprintf("%s %s", m.styles.Label.Render("Owner:"), owner))
+	if lead.FollowUpAt != nil {
+		lines = append(lines, fmt.Sprintf("%s %s", m.styles.Label.Render("Follow up:"), lead.FollowUpAt.Local().Format("2006-01-02 15:04")))
+	}
+
+	if len(m.notes) > 0 {
+		lines = append(lines, "", m.styles.Subtitle.Render("Notes"))
+		for _, note := range m.notes {
+			lines = append(lines, fmt.Sprintf("%s %s",
+				m.styles.Muted.Render(note.CreatedAt.Local().Format("Jan 02 15:04")+" "+note.Actor+":"), note.Body))
+		}
+	}
+	if len(m.history) > 0 {
+		lines = append(lines, "", m.styles.Subtitle.Render("History"))
+		for _, change := range m.history {
+			lines = append(lines, m.styles.Muted.Render(fmt.Sprintf("%s %s: %s → %s",
+				change.CreatedAt.Local().Format("Jan 02 15:04"), change.Actor,
+				statusLabel(change.FromStatus), statusLabel(change.ToStatus))))
+		}
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Colors.Border).
+		Padding(0, 2).
+		Width(90).
+		Render(lipgloss.JoinVertical(lipgloss.Top, lines...))
+}
+
+func (m *Model) renderError() string {
+	errorView := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
+	help := m.styles.Help.Render("Check your configuration and try again")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		errorView,
+		help,
+	)
+}
+
+// selected returns the lead under the cursor
+func (m *Model) selected() *database.Submission {
+	cards := m.columns[database.LeadStatuses[m.column]]
+	if m.row < 0 || m.row >= len(cards) {
+		return nil
+	}
+	return &cards[m.row]
+}
+
+func (m *Model) clampRow() {
+	cards := m.columns[database.LeadStatuses[m.column]]
+	if m.row >= len(cards) {
+		m.row = max(len(cards)-1, 0)
+	}
+}
+
+func (m *Model) openInput(kind modal, prompt, value string) tea.Cmd {
+	m.modal = kind
+	m.input.Prompt = prompt
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	return m.input.Focus()
+}
+
+func (m *Model) closeInput() {
+	m.modal = modalNone
+	m.input.Blur()
+	m.input.SetValue("")
+}
+
+func (m *Model) formLabel() string {
+	if m.formFilter < 0 || m.formFilter >= len(m.forms) {
+		return "all"
+	}
+	return m.forms[m.formFilter]
+}
+
+func (m *Model) ownerName(id int) string {
+	for _, contact := range m.contacts {
+		if contact.ID == id {
+			return contact.Name
+		}
+	}
+	return fmt.Sprintf("contact %d", id)
+}
+
+func (m *Model) load(status string) tea.Cmd {
+	filter := database.SubmissionFilter{Limit: boardLimit}
+	if m.formFilter >= 0 && m.formFilter < len(m.forms) {
+		filter.FormID = m.forms[m.formFilter]
+	}
+
+	return func() tea.Msg {
+		if m.db == nil {
+			return LeadsLoadedMsg{Error: fmt.Errorf("database client not initialized")}
+		}
+
+		leads, err := m.db.GetSubmissions(filter)
+		if err != nil {
+			return LeadsLoadedMsg{Error: err}
+		}
+		fields, err := m.db.GetFieldsByForm()
+		if err != nil {
+			return LeadsLoadedMsg{Error: err}
+		}
+		contacts, err := m.db.GetAllContacts()
+		if err != nil {
+			return LeadsLoadedMsg{Error: err}
+		}
+		forms, err := m.db.GetAllForms()
+		if err != nil {
+			return LeadsLoadedMsg{Error: err}
+		}
+		var formIDs []string
+		for _, form := range forms {
+			formIDs = append(formIDs, form.ID)
+		}
+
+		return LeadsLoadedMsg{
+			Leads:    leads,
+			Fields:   fields,
+			Contacts: contacts,
+			Forms:    formIDs,
+			Status:   status,
+		}
+	}
+}
+
+func (m *Model) loadDetail(id int) tea.Cmd {
+	return func() tea.Msg {
+		notes, err := m.db.GetLeadNotes(id)
+		if err != nil {
+			return LeadDetailMsg{Error: err}
+		}
+		history, err := m.db.GetLeadStatusHistory(id)
+		return LeadDetailMsg{Notes: notes, History: history, Error: err}
+	}
+}
+
+// move changes a lead's status and keeps the cursor on it in its new column
+func (m *Model) move(id int, status string, step int) tea.Cmd {
+	m.loading = true
+	m.column += step
+	m.row = 0
+	m.focus = id
+	return m.save(func() error {
+		return m.db.SetLeadStatus(id, status)
+	}, fmt.Sprintf("Moved lead #%d to %s", id, statusLabel(status)))
+}
+
+func (m *Model) assign(id int, owner *int) tea.Cmd {
+	m.loading = true
+	status := fmt.Sprintf("Unassigned lead #%d", id)
+	if owner != nil {
+		status = fmt.Sprintf("Assigned lead #%d to %s", id, m.ownerName(*owner))
+	}
+	return m.save(func() error {
+		return m.db.AssignLead(id, owner)
+	}, status)
+}
+
+func (m *Model) addNote(id int, body string) tea.Cmd {
+	m.loading = true
+	return m.save(func() error {
+		return m.db.AddLeadNote(id, body)
+	}, fmt.Sprintf("Added a note to lead #%d", id))
+}
+
+func (m *Model) setFollowUp(id int, at *time.Time) tea.Cmd {
+	m.loading = true
+	status := fmt.Sprintf("Cleared the follow-up of lead #%d", id)
+	if at != nil {
+		status = fmt.Sprintf("Follow up on lead #%d at %s", id, at.Local().Format("2006-01-02 15:04"))
+	}
+	return m.save(func() error {
+		return m.db.SetLeadFollowUp(id, at)
+	}, status)
+}
+
+// save runs a change and reloads the board with its outcome
+func (m *Model) save(change func() error, status string) tea.Cmd {
+	reload := m.load(status)
+	return func() tea.Msg {
+		if err := change(); err != nil {
+			return reload().(LeadsLoadedMsg).withStatus(fmt.Sprintf("Failed: %v", err))
+		}
+		return reload()
+	}
+}
+
+// parseFollowUp reads a follow-up time as a local date, a local date and
+// time, or a duration from now; empty clears it
+func parseFollowUp(value string) (*time.Time, error) {
+	if value == "" || value == "none" {
+		return nil, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		at := time.Now().Add(d)
+		return &at, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		if at, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &at, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid follow-up %q (use 2006-01-02 15:04 or a duration like 48h)", value)
+}
+
+func statusLabel(status string) string {
+	if status == "" {
+		return status
+	}
+	return strings.ToUpper(status[:1]) + status[1:]
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if width <= 1 || len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// Message types
+type LeadsLoadedMsg struct {
+	Leads    []database.Submission
+	Fields   map[string][]database.Field
+	Contacts []database.Contact
+	Forms    []string
+	Status   string
+	Error    error
+}
+
+func (msg LeadsLoadedMsg) withStatus(status string) LeadsLoadedMsg {
+	msg.Status = status
+	return msg
+}
+
+type LeadDetailMsg struct {
+	Notes   []database.LeadNote
+	History []database.LeadStatusChange
+	Error   error
+}
@@ -0,0 +1,394 @@
+package logs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tail"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+const (
+	// pollInterval is how often the pane checks for new rows
+	pollInterval = 2 * time.Second
+	// backlog is how many recent rows of each kind the pane starts with
+	backlog = 20
+	// maxEvents caps how many events the pane keeps
+	maxEvents = 500
+)
+
+// statusFilter is a preset the pane can filter by
+type statusFilter struct {
+	label    string
+	statuses []string
+}
+
+var statusFilters = []statusFilter{
+	{"all", nil},
+	{"failures", []string{database.DeliveryFailed, database.DeliveryPartial}},
+	{"successes", []string{database.DeliverySuccess, database.DeliveryResent}},
+}
+
+// Model is a live tail of incoming submissions and deliveries
+type Model struct {
+	config   *config.Config
+	styles   *styles.Styles
+	keys     *keys.Map
+	spinner  spinner.Model
+	db       *database.Client
+	follower *tail.Follower
+	events   []tail.Event
+	fields   map[string][]database.Field
+	forms    []string
+	// formFilter indexes forms; -1 shows every form
+	formFilter   int
+	statusFilter int
+	// offset scrolls back from the newest event
+	offset  int
+	paused  bool
+	loading bool
+	// generation invalidates polls from before the view was left or the
+	// filters changed
+	generation int
+	status     string
+	err        error
+	width      int
+	height     int
+}
+
+func New(cfg *config.Config, s *styles.Styles, km *keys.Map) *Model {
+	sp := components.NewSpinner(cfg, s)
+
+	// Create database client
+	db, err := database.NewClient(cfg)
+	if err != nil {
+		log.Error("Failed to create database client", "error", err)
+	}
+
+	return &Model{
+		config:     cfg,
+		styles:     s,
+		keys:       km,
+		spinner:    sp,
+		db:         db,
+		formFilter: -1,
+		err:        err,
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// StartLoading starts following when the view becomes active. Polls only
+// reach the view while it is shown, so following stops by itself when the
+// user navigates away.
+func (m *Model) StartLoading() tea.Cmd {
+	if m.db == nil {
+		return nil
+	}
+	return m.restart()
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.MouseMsg:
+		// The wheel scrolls back from the newest event, which is at the bottom
+		if step := components.Wheel(msg); step != 0 {
+			m.offset = max(0, min(len(m.events)-1, m.offset-step))
+		}
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.List.Up):
+			if m.offset < len(m.events)-1 {
+				m.offset++
+			}
+		case key.Matches(msg, m.keys.List.Down):
+			if m.offset > 0 {
+				m.offset--
+			}
+		case key.Matches(msg, m.keys.List.Bottom):
+			m.offset = 0
+		case key.Matches(msg, m.keys.Logs.Pause):
+			m.paused = !m.paused
+		case key.Matches(msg, m.keys.Logs.Clear):
+			m.events = nil
+			m.offset = 0
+		case key.Matches(msg, m.keys.Filter.Form):
+			// Cycle through the forms, then back to every form
+			m.formFilter++
+			if m.formFilter >= len(m.forms) {
+				m.formFilter = -1
+			}
+			return m, m.restart()
+		case key.Matches(msg, m.keys.Filter.Status):
+			m.statusFilter = (m.statusFilter + 1) % len(statusFilters)
+			return m, m.restart()
+		}
+
+	case FollowStartedMsg:
+		if msg.generation != m.generation {
+			break
+		}
+		m.loading = false
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Failed to start following: %v", msg.Error)
+			break
+		}
+		m.follower = msg.Follower
+		m.fields = msg.Fields
+		m.forms = msg.Forms
+		m.events = nil
+		m.offset = 0
+		m.status = ""
+		cmds = append(cmds, m.poll())
+
+	case EventsMsg:
+		if msg.generation != m.generation {
+			break
+		}
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Failed to poll: %v", msg.Error)
+		} else {
+			m.status = ""
+			m.events = append(m.events, msg.Events...)
+			if len(m.events) > maxEvents {
+				m.events = m.events[len(m.events)-maxEvents:]
+			}
+			// Keep the same rows in view while scrolled back
+			if m.offset > 0 {
+				m.offset = min(m.offset+len(msg.Events), max(len(m.events)-1, 0))
+			}
+		}
+		cmds = append(cmds, m.tick())
+
+	case pollMsg:
+		if msg.generation == m.generation {
+			if m.paused {
+				cmds = append(cmds, m.tick())
+			} else {
+				cmds = append(cmds, m.poll())
+			}
+		}
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) View() string {
+	if m.err != nil {
+		return m.renderError()
+	}
+
+	title := m.styles.Title.Render("📡 Live Tail")
+	if m.loading {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "", m.spinner.View()+" Connecting...")
+	}
+
+	state := m.styles.Success.Render("● following")
+	if m.paused {
+		state = m.styles.Warning.Render("❚❚ paused")
+	}
+	filters := m.styles.Muted.Render(fmt.Sprintf("Form: %s • Status: %s • %d events",
+		m.formLabel(), statusFilters[m.statusFilter].label, len(m.events)))
+
+	parts := []string{title, state + "  " + filters, ""}
+	if len(m.events) == 0 {
+		parts = append(parts, m.styles.Muted.Render("Waiting for submissions..."))
+	} else {
+		parts = append(parts, m.renderEvents())
+	}
+
+	if m.status != "" {
+		parts = append(parts, "", m.styles.Error.Render(m.status))
+	}
+	parts = append(parts, "", m.styles.Help.Render(keys.Help(
+		m.keys.Logs.Pause,
+		keys.Join("Scroll", m.keys.List.Up, m.keys.List.Down),
+		keys.Describe(m.keys.List.Bottom, "Newest"),
+		m.keys.Filter.Form,
+		m.keys.Filter.Status,
+		m.keys.Logs.Clear,
+		m.keys.Global.Back,
+	)))
+
+	return lipgloss.JoinVertical(lipgloss.Top, parts...)
+}
+
+func (m *Model) renderEvents() string {
+	rows := m.height - 12
+	if rows < 5 {
+		rows = 20
+	}
+
+	end := len(m.events) - m.offset
+	start := max(end-rows, 0)
+
+	lines := make([]string, 0, end-start)
+	for _, event := range m.events[start:end] {
+		line := fmt.Sprintf("%s %-10s #%-6d %-20s %-8s %s",
+			event.Time.Local().Format("15:04:05"),
+			event.Kind, event.ID, truncate(event.FormID, 20), event.Status,
+			event.Summary(m.fields),
+		)
+		if m.width > 0 {
+			line = truncate(line, m.width-4)
+		}
+
+		style := m.styles.Text
+		switch {
+		case event.Failed():
+			style = m.styles.Error
+		case event.Kind == tail.KindDelivery:
+			style = m.styles.Success
+		}
+		lines = append(lines, style.Render(line))
+	}
+	if m.offset > 0 {
+		lines = append(lines, m.styles.Muted.Render(fmt.Sprintf("↓ %d newer", m.offset)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Top, lines...)
+}
+
+// ShortHelp lists the keys shown in the footer
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		m.keys.Logs.Pause,
+		keys.Join("Scroll", m.keys.List.Up, m.keys.List.Down),
+		m.keys.Filter.Form,
+		m.keys.Filter.Status,
+		m.keys.Logs.Clear,
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.List.Up, m.keys.List.Down, keys.Describe(m.keys.List.Bottom, "Newest")},
+		{m.keys.Logs.Pause, m.keys.Logs.Clear},
+		{m.keys.Filter.Form, m.keys.Filter.Status},
+	}
+}
+
+func (m *Model) renderError() string {
+	errorView := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
+	help := m.styles.Help.Render("Check your configuration and try again")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		errorView,
+		help,
+	)
+}
+
+func (m *Model) formLabel() string {
+	if m.formFilter < 0 || m.formFilter >= len(m.forms) {
+		return "all"
+	}
+	return m.forms[m.formFilter]
+}
+
+// restart follows from scratch with the current filters
+func (m *Model) restart() tea.Cmd {
+	m.generation++
+	m.loading = true
+	m.follower = nil
+
+	generation := m.generation
+	filter := database.TailFilter{Statuses: statusFilters[m.statusFilter].statuses}
+	if m.formFilter >= 0 && m.formFilter < len(m.forms) {
+		filter.FormID = m.forms[m.formFilter]
+	}
+
+	start := func() tea.Msg {
+		follower, err := tail.NewFollower(m.db, filter, backlog)
+		if err != nil {
+			return FollowStartedMsg{generation: generation, Error: err}
+		}
+		fields, err := m.db.GetFieldsByForm()
+		if err != nil {
+			return FollowStartedMsg{generation: generation, Error: err}
+		}
+		forms, err := m.db.GetAllForms()
+		if err != nil {
+			return FollowStartedMsg{generation: generation, Error: err}
+		}
+		var formIDs []string
+		for _, form := range forms {
+			formIDs = append(formIDs, form.ID)
+		}
+		return FollowStartedMsg{
+			generation: generation,
+			Follower:   follower,
+			Fields:     fields,
+			Forms:      formIDs,
+		}
+	}
+	return tea.Batch(m.spinner.Tick, start)
+}
+
+func (m *Model) poll() tea.Cmd {
+	follower := m.follower
+	generation := m.generation
+	return func() tea.Msg {
+		events, err := follower.Poll()
+		return EventsMsg{generation: generation, Events: events, Error: err}
+	}
+}
+
+func (m *Model) tick() tea.Cmd {
+	generation := m.generation
+	return tea.Tick(pollInterval, func(time.Time) tea.Msg {
+		return pollMsg{generation: generation}
+	})
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if width <= 1 || len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// Message types
+type FollowStartedMsg struct {
+	generation int
+	Follower   *tail.Follower
+	Fields     map[string][]database.Field
+	Forms      []string
+	Error      error
+}
+
+type EventsMsg struct {
+	generation int
+	Events     []tail.Event
+	Error      error
+}
+
+type pollMsg struct {
+	generation int
+}
@@ -0,0 +1,327 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	uptime "github.com/thalysguimaraes/elementor-whatsapp/internal/monitor"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+// timeRange is a preset window the timeline covers
+type timeRange struct {
+	label string
+	span  time.Duration
+}
+
+var timeRanges = []timeRange{
+	{"Last 24 hours", 24 * time.Hour},
+	{"Last 7 days", 7 * 24 * time.Hour},
+	{"Last 30 days", 30 * 24 * time.Hour},
+}
+
+// Model shows the Z-API connection state, an uptime timeline and outages
+type Model struct {
+	config     *config.Config
+	styles     *styles.Styles
+	keys       *keys.Map
+	spinner    spinner.Model
+	db         *database.Client
+	report     *uptime.Report
+	rangeIndex int
+	// offset scrolls the outage list
+	offset  int
+	loading bool
+	// notStarted is set when the worker has not recorded anything yet
+	notStarted bool
+	err        error
+	width      int
+	height     int
+}
+
+func New(cfg *config.Config, s *styles.Styles, km *keys.Map) *Model {
+	sp := components.NewSpinner(cfg, s)
+
+	// Create database client
+	db, err := database.NewClient(cfg)
+	if err != nil {
+		log.Error("Failed to create database client", "error", err)
+	}
+
+	return &Model{
+		config:     cfg,
+		styles:     s,
+		keys:       km,
+		spinner:    sp,
+		db:         db,
+		rangeIndex: 1,
+		err:        err,
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// StartLoading reads the monitoring tables when the view becomes active
+func (m *Model) StartLoading() tea.Cmd {
+	if m.loading || m.db == nil {
+		return nil
+	}
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, m.load())
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.MouseMsg:
+		if step := components.Wheel(msg); step != 0 && m.report != nil {
+			m.offset = max(0, min(len(m.report.Outages)-1, m.offset+step))
+		}
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.List.Up):
+			if m.offset > 0 {
+				m.offset--
+			}
+		case key.Matches(msg, m.keys.List.Down):
+			if m.report != nil && m.offset < len(m.report.Outages)-1 {
+				m.offset++
+			}
+		case key.Matches(msg, m.keys.Filter.Time):
+			m.rangeIndex = (m.rangeIndex + 1) % len(timeRanges)
+			m.loading = true
+			return m, m.load()
+		case key.Matches(msg, m.keys.Item.Refresh):
+			m.loading = true
+			return m, m.load()
+		}
+
+	case ReportLoadedMsg:
+		m.loading = false
+		m.notStarted = errors.Is(msg.Error, database.ErrMonitoringNotStarted)
+		m.err = msg.Error
+		if m.notStarted {
+			m.err = nil
+		}
+		if msg.Error == nil {
+			m.report = msg.Report
+			m.offset = 0
+		}
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) View() string {
+	if m.err != nil {
+		return m.renderError()
+	}
+
+	title := m.styles.Title.Render("🩺 Z-API Monitoring")
+	help := m.styles.Help.Render(keys.Help(
+		m.keys.Filter.Time,
+		keys.Join("Scroll outages", m.keys.List.Up, m.keys.List.Down),
+		m.keys.Item.Refresh,
+		m.keys.Global.Back,
+	))
+
+	if m.notStarted || (m.report != nil && m.report.State == nil) {
+		return lipgloss.JoinVertical(lipgloss.Top,
+			title,
+			"",
+			m.styles.Muted.Render("Monitoring has not run yet."),
+			m.styles.Muted.Render("Set MONITORING_ENABLED=true on the worker and wait for its cron to check Z-API."),
+			"",
+			help,
+		)
+	}
+	if m.report == nil {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "", m.spinner.View()+" Loading connection history...")
+	}
+
+	subtitle := m.styles.Muted.Render(timeRanges[m.rangeIndex].label)
+	if m.loading {
+		subtitle += " " + m.spinner.View()
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		title,
+		subtitle,
+		"",
+		m.renderState(),
+		"",
+		m.renderTimeline(),
+		"",
+		m.renderOutages(),
+		"",
+		help,
+	)
+}
+
+func (m *Model) renderState() string {
+	state := m.report.State
+
+	connection := m.styles.Success.Render("● Connected")
+	if !state.Connected {
+		connection = m.styles.Error.Render("● Disconnected")
+	}
+	session := m.styles.Success.Render("up")
+	if !state.Session {
+		session = m.styles.Warning.Render("none")
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s  %s", connection, m.styles.Muted.Render(fmt.Sprintf("for %s, since %s",
+			uptime.FormatDuration(time.Since(state.LastChanged)),
+			state.LastChanged.Local().Format("Jan 02 15:04")))),
+		fmt.Sprintf("%s %s", m.styles.Label.Render("Session:"), session),
+	}
+	if msg := database.StatusError(state.Status); msg != "" {
+		lines = append(lines, fmt.Sprintf("%s %s", m.styles.Label.Render("Last error:"), m.styles.Error.Render(msg)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Top, lines...)
+}
+
+func (m *Model) renderTimeline() string {
+	report := m.report
+
+	rateStyle := m.styles.Success
+	switch {
+	case report.Uptime < 0:
+		rateStyle = m.styles.Muted
+	case report.Uptime < 0.95:
+		rateStyle = m.styles.Error
+	case report.Uptime < 0.999:
+		rateStyle = m.styles.Warning
+	}
+
+	cells := 72
+	if m.width > 0 {
+		cells = min(max(m.width-8, 12), 120)
+	}
+	timeline := components.Timeline(report.Timeline(cells),
+		m.styles.Success, m.styles.Error, m.styles.Warning, m.styles.Muted)
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		m.styles.Subtitle.Render("Uptime"),
+		fmt.Sprintf("%s  %s",
+			rateStyle.Render(formatRate(report.Uptime)),
+			m.styles.Muted.Render(fmt.Sprintf("%s down in %d outages", uptime.FormatDuration(report.Downtime), len(report.Outages)))),
+		timeline,
+		m.styles.Muted.Render(fmt.Sprintf("%s → now", report.Since.Local().Format("Jan 02 15:04"))),
+	)
+}
+
+func (m *Model) renderOutages() string {
+	lines := []string{m.styles.Subtitle.Render("Outages")}
+	outages := m.report.Outages
+	if len(outages) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Top, append(lines, m.styles.Muted.Render("No outages in this range"))...)
+	}
+
+	rows := m.height - 22
+	if rows < 3 {
+		rows = 8
+	}
+	end := min(m.offset+rows, len(outages))
+	for _, outage := range outages[m.offset:end] {
+		to := outage.End.Local().Format("Jan 02 15:04")
+		style := m.styles.Text
+		if outage.Ongoing {
+			to = "ongoing"
+			style = m.styles.Error
+		}
+		line := fmt.Sprintf("%s → %-12s %10s", outage.Start.Local().Format("Jan 02 15:04"), to, uptime.FormatDuration(outage.Duration()))
+		if outage.Error != "" {
+			line += "  " + outage.Error
+		}
+		lines = append(lines, style.Render(line))
+	}
+	if end < len(outages) {
+		lines = append(lines, m.styles.Muted.Render(fmt.Sprintf("↓ %d older", len(outages)-end)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Top, lines...)
+}
+
+// ShortHelp lists the keys shown in the footer
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		m.keys.Filter.Time,
+		keys.Join("Scroll outages", m.keys.List.Up, m.keys.List.Down),
+		m.keys.Item.Refresh,
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.List.Up, m.keys.List.Down},
+		{m.keys.Filter.Time, m.keys.Item.Refresh},
+	}
+}
+
+func (m *Model) renderError() string {
+	errorView := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
+	help := m.styles.Help.Render(fmt.Sprintf("Press '%s' to retry", m.keys.Item.Refresh.Help().Key))
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		errorView,
+		help,
+	)
+}
+
+func (m *Model) load() tea.Cmd {
+	since := time.Now().Add(-timeRanges[m.rangeIndex].span)
+
+	return func() tea.Msg {
+		if m.db == nil {
+			return ReportLoadedMsg{Error: fmt.Errorf("database client not initialized")}
+		}
+
+		report, err := uptime.Compute(m.db, database.MonitorZAPI, since)
+		return ReportLoadedMsg{Report: report, Error: err}
+	}
+}
+
+func formatRate(rate float64) string {
+	if rate < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", rate*100)
+}
+
+// Message types
+type ReportLoadedMsg struct {
+	Report *uptime.Report
+	Error  error
+}
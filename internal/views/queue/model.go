@@ -0,0 +1,365 @@
+package queue
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+// refreshInterval is how often the monitor reloads while it is shown
+const refreshInterval = 5 * time.Second
+
+var (
+	tabStatuses = []string{database.JobPending, database.JobInFlight, database.JobDead}
+	tabLabels   = []string{"Pending", "In flight", "Dead"}
+)
+
+// Model monitors the outbound queue
+type Model struct {
+	config  *config.Config
+	styles  *styles.Styles
+	keys    *keys.Map
+	spinner spinner.Model
+	db      *database.Client
+	stats   *database.QueueStats
+	jobs    []database.OutboundJob
+	tab     int
+	cursor  int
+	loading bool
+	// generation invalidates refresh ticks from before the view was left
+	generation int
+	updatedAt  time.Time
+	status     string
+	width      int
+	height     int
+	err        error
+}
+
+func New(cfg *config.Config, s *styles.Styles, km *keys.Map) *Model {
+	sp := components.NewSpinner(cfg, s)
+
+	// Create database client
+	db, err := database.NewClient(cfg)
+	if err != nil {
+		log.Error("Failed to create database client", "error", err)
+	}
+
+	return &Model{
+		config:  cfg,
+		styles:  s,
+		keys:    km,
+		spinner: sp,
+		db:      db,
+		err:     err,
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// StartLoading loads the queue when the view becomes active and keeps it
+// refreshing. Ticks only reach the view while it is shown, so refreshing
+// stops by itself when the user navigates away.
+func (m *Model) StartLoading() tea.Cmd {
+	if m.db == nil {
+		return nil
+	}
+	m.generation++
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, m.load(""), m.tick())
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.MouseMsg:
+		if m.loading {
+			return m, nil
+		}
+		if step := components.Wheel(msg); step != 0 {
+			m.cursor = max(0, min(len(m.jobs)-1, m.cursor+step))
+		} else if components.Clicked(msg) {
+			if tab := components.TabAt(m.View(), m.styles, tabLabels, m.tab, msg.X, msg.Y); tab >= 0 && tab != m.tab {
+				m.tab = tab
+				m.cursor = 0
+				m.loading = true
+				return m, m.load("")
+			}
+		}
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.List.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, m.keys.List.Down):
+			if m.cursor < len(m.jobs)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, m.keys.List.NextTab, m.keys.List.Right):
+			m.tab = (m.tab + 1) % len(tabStatuses)
+			m.cursor = 0
+			m.loading = true
+			return m, m.load("")
+		case key.Matches(msg, m.keys.List.PrevTab, m.keys.List.Left):
+			m.tab = (m.tab + len(tabStatuses) - 1) % len(tabStatuses)
+			m.cursor = 0
+			m.loading = true
+			return m, m.load("")
+		case key.Matches(msg, m.keys.Queue.Requeue):
+			// Requeue the dead job under the cursor
+			if tabStatuses[m.tab] == database.JobDead && m.cursor < len(m.jobs) {
+				return m, m.requeue(m.jobs[m.cursor].ID)
+			}
+		case key.Matches(msg, m.keys.Queue.RequeueAll):
+			if tabStatuses[m.tab] == database.JobDead && len(m.jobs) > 0 {
+				return m, m.requeue()
+			}
+		case key.Matches(msg, m.keys.Item.Reload):
+			m.loading = true
+			return m, m.load("")
+		}
+
+	case QueueLoadedMsg:
+		m.loading = false
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Failed to load queue: %v", msg.Error)
+		} else {
+			m.stats = msg.Stats
+			m.jobs = msg.Jobs
+			m.updatedAt = time.Now()
+			if msg.Status != "" {
+				m.status = msg.Status
+			}
+			if m.cursor >= len(m.jobs) {
+				m.cursor = max(len(m.jobs)-1, 0)
+			}
+		}
+
+	case refreshMsg:
+		if msg.generation == m.generation {
+			if !m.loading {
+				cmds = append(cmds, m.load(""))
+			}
+			cmds = append(cmds, m.tick())
+		}
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) View() string {
+	if m.err != nil {
+		return m.renderError()
+	}
+
+	title := m.styles.Title.Render("📤 Outbound Queue")
+	description := m.styles.Muted.Render("Messages waiting to be sent, retried with backoff until delivered or dead")
+
+	if m.loading && m.stats == nil {
+		return lipgloss.JoinVertical(
+			lipgloss.Top,
+			title,
+			description,
+			"",
+			m.spinner.View()+" Loading queue...",
+		)
+	}
+
+	parts := []string{title, description, "", m.renderCounters(), ""}
+
+	parts = append(parts, components.RenderTabs(m.styles, tabLabels, m.tab), "")
+	if len(m.jobs) == 0 {
+		parts = append(parts, m.styles.Muted.Render("No "+tabLabels[m.tab]+" jobs"))
+	} else {
+		parts = append(parts, m.renderJobs())
+	}
+
+	if m.status != "" {
+		parts = append(parts, "", m.styles.Info.Render(m.status))
+	}
+	if !m.updatedAt.IsZero() {
+		parts = append(parts, "", m.styles.Muted.Render("Updated "+m.updatedAt.Format("15:04:05")))
+	}
+
+	help := []key.Binding{
+		keys.Describe(m.keys.List.NextTab, "Switch list"),
+		keys.Describe(m.keys.Item.Reload, "Refresh"),
+		m.keys.Global.Back,
+	}
+	if tabStatuses[m.tab] == database.JobDead {
+		help = append([]key.Binding{m.keys.Queue.Requeue, m.keys.Queue.RequeueAll}, help...)
+	}
+	parts = append(parts, m.styles.Help.Render(keys.Help(help...)))
+
+	return lipgloss.JoinVertical(lipgloss.Top, parts...)
+}
+
+// ShortHelp lists the keys shown in the footer
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Join("Navigate", m.keys.List.Up, m.keys.List.Down),
+		keys.Describe(m.keys.List.NextTab, "Switch list"),
+		m.keys.Queue.Requeue,
+		m.keys.Queue.RequeueAll,
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.List.Up, m.keys.List.Down, m.keys.List.NextTab, m.keys.List.PrevTab, keys.Describe(m.keys.List.Left, "Previous tab"), keys.Describe(m.keys.List.Right, "Next tab")},
+		{m.keys.Queue.Requeue, m.keys.Queue.RequeueAll, keys.Describe(m.keys.Item.Reload, "Refresh")},
+	}
+}
+
+func (m *Model) renderCounters() string {
+	stats := m.stats
+	if stats == nil {
+		stats = &database.QueueStats{}
+	}
+
+	counter := func(label string, count int, style lipgloss.Style) string {
+		return lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(m.styles.Colors.Border).
+			Padding(0, 2).
+			MarginRight(1).
+			Render(lipgloss.JoinVertical(lipgloss.Left,
+				m.styles.Muted.Render(label),
+				style.Render(fmt.Sprintf("%d", count)),
+			))
+	}
+
+	deadStyle := m.styles.Text
+	if stats.Dead > 0 {
+		deadStyle = m.styles.Error
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		counter("Pending", stats.Pending, m.styles.Warning),
+		counter("In flight", stats.InFlight, m.styles.Info),
+		counter("Dead", stats.Dead, deadStyle),
+		counter("Sent", stats.Sent, m.styles.Success),
+	)
+}
+
+func (m *Model) renderJobs() string {
+	header := fmt.Sprintf("%-7s %-20s %-16s %-9s %s", "Job", "Form", "Recipient", "Attempts", "Detail")
+	rows := []string{m.styles.TableHeader.Render(header)}
+
+	for i, job := range m.jobs {
+		var detail string
+		switch job.Status {
+		case database.JobPending:
+			detail = "next " + job.NextAttemptAt.Local().Format("15:04:05")
+		case database.JobInFlight:
+			if job.LockedUntil != nil {
+				detail = "lease until " + job.LockedUntil.Local().Format("15:04:05")
+			}
+		}
+		if job.LastError != "" {
+			if detail != "" {
+				detail += " • "
+			}
+			detail += job.LastError
+		}
+		if len(detail) > 60 {
+			detail = detail[:59] + "…"
+		}
+
+		row := fmt.Sprintf("#%-6d %-20s %-16s %-9s %s",
+			job.ID, job.FormID, job.Phone,
+			fmt.Sprintf("%d/%d", job.Attempts, job.MaxAttempts),
+			detail,
+		)
+		if i == m.cursor {
+			rows = append(rows, m.styles.ActiveItem.Render(row))
+		} else {
+			rows = append(rows, m.styles.Text.Render(row))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Top, rows...)
+}
+
+func (m *Model) renderError() string {
+	errorView := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
+	help := m.styles.Help.Render("Check your configuration and try again")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		errorView,
+		help,
+	)
+}
+
+func (m *Model) tick() tea.Cmd {
+	generation := m.generation
+	return tea.Tick(refreshInterval, func(time.Time) tea.Msg {
+		return refreshMsg{generation: generation}
+	})
+}
+
+func (m *Model) load(status string) tea.Cmd {
+	tab := m.tab
+	return func() tea.Msg {
+		stats, err := m.db.GetQueueStats()
+		if err != nil {
+			return QueueLoadedMsg{Error: err}
+		}
+		jobs, err := m.db.GetJobs(tabStatuses[tab], 100)
+		return QueueLoadedMsg{Stats: stats, Jobs: jobs, Status: status, Error: err}
+	}
+}
+
+func (m *Model) requeue(ids ...int) tea.Cmd {
+	m.loading = true
+	return func() tea.Msg {
+		n, err := m.db.RequeueDeadJobs(ids...)
+		if err != nil {
+			return QueueLoadedMsg{Error: err}
+		}
+		return m.load(fmt.Sprintf("Requeued %d job(s)", n))()
+	}
+}
+
+// Message types
+type QueueLoadedMsg struct {
+	Stats  *database.QueueStats
+	Jobs   []database.OutboundJob
+	Status string
+	Error  error
+}
+
+type refreshMsg struct {
+	generation int
+}
@@ -1,69 +1,239 @@
 package settings
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
 	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+// itemKind decides how a setting is shown and edited
+type itemKind int
+
+const (
+	kindText itemKind = iota
+	kindSecret
+	kindBool
+	kindDuration
+	kindInt
+	kindChoice
 )
 
 type Model struct {
-	config   *config.Config
+	config *config.Config
+	// draft holds unsaved edits; saving copies it onto config
+	draft    config.Config
 	styles   *styles.Styles
+	keys     *keys.Map
 	sections []Section
 	selected int
+	editing  bool
+	input    textinput.Model
+	dirty    bool
+	status   string
+	isError  bool
 	width    int
 	height   int
 }
 
 type Section struct {
-	Title  string
-	Items  []ConfigItem
+	Title string
+	Items []ConfigItem
+	Note  string
 }
 
 type ConfigItem struct {
-	Label       string
-	Value       string
-	Key         string
-	Sensitive   bool
+	Label   string
+	Key     string
+	Kind    itemKind
+	Choices func(*config.Config) []string
+	get     func(*config.Config) string
+	set     func(*config.Config, string) error
 }
 
-func New(cfg *config.Config, s *styles.Styles) *Model {
-	sections := []Section{
+func New(cfg *config.Config, s *styles.Styles, km *keys.Map) *Model {
+	input := textinput.New()
+	input.CharLimit = 256
+	input.Width = 50
+
+	return &Model{
+		config:   cfg,
+		draft:    *cfg,
+		styles:   s,
+		keys:     km,
+		sections: buildSections(),
+		input:    input,
+	}
+}
+
+func buildSections() []Section {
+	return []Section{
 		{
 			Title: "Cloudflare Configuration",
 			Items: []ConfigItem{
-				{Label: "Account ID", Value: cfg.Cloudflare.AccountID, Key: "cloudflare.account_id"},
-				{Label: "API Token", Value: maskToken(cfg.Cloudflare.APIToken), Key: "cloudflare.api_token", Sensitive: true},
-				{Label: "Database ID", Value: cfg.Cloudflare.DatabaseID, Key: "cloudflare.database_id"},
-				{Label: "Worker URL", Value: cfg.Cloudflare.WorkerURL, Key: "cloudflare.worker_url"},
+				textItem("Account ID", "cloudflare.account_id", func(c *config.Config) *string { return &c.Cloudflare.AccountID }),
+				secretItem("API Token", "cloudflare.api_token", func(c *config.Config) *string { return &c.Cloudflare.APIToken }),
+				textItem("Database ID", "cloudflare.database_id", func(c *config.Config) *string { return &c.Cloudflare.DatabaseID }),
+				textItem("Worker URL", "cloudflare.worker_url", func(c *config.Config) *string { return &c.Cloudflare.WorkerURL }),
 			},
 		},
 		{
 			Title: "Z-API Configuration",
 			Items: []ConfigItem{
-				{Label: "Instance ID", Value: cfg.ZAPI.InstanceID, Key: "zapi.instance_id"},
-				{Label: "Instance Token", Value: maskToken(cfg.ZAPI.InstanceToken), Key: "zapi.instance_token", Sensitive: true},
-				{Label: "Client Token", Value: maskToken(cfg.ZAPI.ClientToken), Key: "zapi.client_token", Sensitive: true},
+				textItem("Instance ID", "zapi.instance_id", func(c *config.Config) *string { return &c.ZAPI.InstanceID }),
+				secretItem("Instance Token", "zapi.instance_token", func(c *config.Config) *string { return &c.ZAPI.InstanceToken }),
+				secretItem("Client Token", "zapi.client_token", func(c *config.Config) *string { return &c.ZAPI.ClientToken }),
 			},
 		},
 		{
 			Title: "UI Preferences",
 			Items: []ConfigItem{
-				{Label: "Theme", Value: cfg.UI.Theme, Key: "ui.theme"},
-				{Label: "Mouse Support", Value: boolToString(cfg.UI.Mouse), Key: "ui.mouse"},
-				{Label: "Animations", Value: boolToString(cfg.UI.Animations), Key: "ui.animations"},
-				{Label: "Vim Bindings", Value: boolToString(cfg.UI.VimBindings), Key: "ui.vim_bindings"},
-				{Label: "Auto Refresh", Value: cfg.UI.AutoRefresh.String(), Key: "ui.auto_refresh"},
+				{
+					Label:   "Theme",
+					Key:     "ui.theme",
+					Kind:    kindChoice,
+					Choices: func(*config.Config) []string { return styles.Names() },
+					get:     func(c *config.Config) string { return c.UI.Theme },
+					set: func(c *config.Config, v string) error {
+						c.UI.Theme = v
+						return nil
+					},
+				},
+				boolItem("Mouse Support", "ui.mouse", func(c *config.Config) *bool { return &c.UI.Mouse }),
+				boolItem("Animations", "ui.animations", func(c *config.Config) *bool { return &c.UI.Animations }),
+				boolItem("Reduced Motion", "ui.reduced_motion", func(c *config.Config) *bool { return &c.UI.ReducedMotion }),
+				boolItem("Vim Bindings", "ui.vim_bindings", func(c *config.Config) *bool { return &c.UI.VimBindings }),
+				durationItem("Auto Refresh", "ui.auto_refresh", func(c *config.Config) *time.Duration { return &c.UI.AutoRefresh }),
+				boolItem("Confirm Destructive", "ui.confirm_destructive", func(c *config.Config) *bool { return &c.UI.ConfirmDestructive }),
+			},
+			Note: "Spinners and the reduced motion redraw rate change on the next start",
+		},
+		{
+			Title: "Outbound Queue",
+			Items: []ConfigItem{
+				intItem("Max Attempts", "queue.max_attempts", func(c *config.Config) *int { return &c.Queue.MaxAttempts }),
+				durationItem("Base Delay", "queue.base_delay", func(c *config.Config) *time.Duration { return &c.Queue.BaseDelay }),
+				durationItem("Max Delay", "queue.max_delay", func(c *config.Config) *time.Duration { return &c.Queue.MaxDelay }),
+				durationItem("Poll Interval", "queue.poll_interval", func(c *config.Config) *time.Duration { return &c.Queue.PollInterval }),
+				intItem("Batch Size", "queue.batch_size", func(c *config.Config) *int { return &c.Queue.BatchSize }),
+			},
+		},
+		{
+			Title: "Alerts",
+			Items: []ConfigItem{
+				durationItem("Interval", "alerts.interval", func(c *config.Config) *time.Duration { return &c.Alerts.Interval }),
+				durationItem("Repeat", "alerts.repeat", func(c *config.Config) *time.Duration { return &c.Alerts.Repeat }),
+			},
+			Note: "Rules and notifiers are lists; edit them in config.yaml",
+		},
+		{
+			Title: "General",
+			Items: []ConfigItem{
+				{
+					Label: "Profile",
+					Key:   "profile",
+					Kind:  kindChoice,
+					Choices: func(c *config.Config) []string {
+						names := []string{""}
+						for name := range c.Profiles {
+							names = append(names, name)
+						}
+						sort.Strings(names[1:])
+						return names
+					},
+					get: func(c *config.Config) string { return c.Profile },
+					set: func(c *config.Config, v string) error { return c.SetProfile(v) },
+				},
+				textItem("Audit Actor", "actor", func(c *config.Config) *string { return &c.Actor }),
 			},
 		},
 	}
-	
-	return &Model{
-		config:   cfg,
-		styles:   s,
-		sections: sections,
-		selected: 0,
+}
+
+func textItem(label, key string, field func(*config.Config) *string) ConfigItem {
+	return ConfigItem{
+		Label: label,
+		Key:   key,
+		Kind:  kindText,
+		get:   func(c *config.Config) string { return *field(c) },
+		set: func(c *config.Config, v string) error {
+			*field(c) = v
+			return nil
+		},
+	}
+}
+
+// secretItem takes a plaintext value or a reference such as
+// keyring:ewctl/cloudflare
+func secretItem(label, key string, field func(*config.Config) *string) ConfigItem {
+	item := textItem(label, key, field)
+	item.Kind = kindSecret
+	item.set = func(c *config.Config, v string) error {
+		return c.SetSecret(key, v)
+	}
+	return item
+}
+
+func boolItem(label, key string, field func(*config.Config) *bool) ConfigItem {
+	return ConfigItem{
+		Label: label,
+		Key:   key,
+		Kind:  kindBool,
+		get:   func(c *config.Config) string { return strconv.FormatBool(*field(c)) },
+		set: func(c *config.Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("%s must be true or false", key)
+			}
+			*field(c) = b
+			return nil
+		},
+	}
+}
+
+func durationItem(label, key string, field func(*config.Config) *time.Duration) ConfigItem {
+	return ConfigItem{
+		Label: label,
+		Key:   key,
+		Kind:  kindDuration,
+		get:   func(c *config.Config) string { return field(c).String() },
+		set: func(c *config.Config, v string) error {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("%s must be a duration like 30s, 5m or 1h", key)
+			}
+			if d < 0 {
+				return fmt.Errorf("%s cannot be negative", key)
+			}
+			*field(c) = d
+			return nil
+		},
+	}
+}
+
+func intItem(label, key string, field func(*config.Config) *int) ConfigItem {
+	return ConfigItem{
+		Label: label,
+		Key:   key,
+		Kind:  kindInt,
+		get:   func(c *config.Config) string { return strconv.Itoa(*field(c)) },
+		set: func(c *config.Config, v string) error {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%s must be a whole number", key)
+			}
+			*field(c) = n
+			return nil
+		},
 	}
 }
 
@@ -71,119 +241,324 @@ func (m *Model) Init() tea.Cmd {
 	return nil
 }
 
+// ShortHelp lists the keys shown in the footer
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Join("Navigate", m.keys.List.Up, m.keys.List.Down),
+		keys.Describe(m.keys.List.Open, "Edit"),
+		keys.Join("Choose", m.keys.List.Left, m.keys.List.Right),
+		m.keys.Item.Save,
+		m.keys.Item.Undo,
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.List.Up, m.keys.List.Down, keys.Describe(m.keys.List.Open, "Edit"), keys.Describe(m.keys.List.Select, "Toggle")},
+		{keys.Describe(m.keys.List.Left, "Previous choice"), keys.Describe(m.keys.List.Right, "Next choice")},
+		{m.keys.Item.Save, keys.Describe(m.keys.Item.Undo, "Undo changes")},
+	}
+}
+
+// HasModal reports whether Esc should cancel an edit instead of leaving
+// the view
+func (m *Model) HasModal() bool {
+	return m.editing
+}
+
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		
+
+	case tea.MouseMsg:
+		if step := components.Wheel(msg); step != 0 && !m.editing {
+			m.selected = max(0, min(m.getTotalItems()-1, m.selected+step))
+		}
+
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "up", "k":
+		if m.editing {
+			return m.updateEditing(msg)
+		}
+
+		item := m.selectedItem()
+		switch {
+		case key.Matches(msg, m.keys.List.Up):
 			if m.selected > 0 {
 				m.selected--
 			}
-		case "down", "j":
+		case key.Matches(msg, m.keys.List.Down):
 			if m.selected < m.getTotalItems()-1 {
 				m.selected++
 			}
-		case "enter":
-			// Edit selected item
-			// TODO: Open edit dialog
-		case "s":
-			// Save configuration
-			// TODO: Save to file
-		case "esc", "q":
-			// Navigate back to dashboard
-			return m, func() tea.Msg {
-				type SwitchViewMsg struct {
-					View  int
-					Title string
-				}
-				return SwitchViewMsg{View: 0, Title: "Dashboard"}
+		case key.Matches(msg, m.keys.List.Open, m.keys.List.Select):
+			switch item.Kind {
+			case kindBool:
+				m.apply(item, strconv.FormatBool(item.get(&m.draft) != "true"))
+			case kindChoice:
+				m.cycle(item, 1)
+			default:
+				m.startEditing(item)
+			}
+		case key.Matches(msg, m.keys.List.Right):
+			if item.Kind == kindChoice {
+				m.cycle(item, 1)
+			}
+		case key.Matches(msg, m.keys.List.Left):
+			if item.Kind == kindChoice {
+				m.cycle(item, -1)
 			}
+		case key.Matches(msg, m.keys.Item.Undo):
+			m.draft = *m.config
+			m.dirty = false
+			m.setStatus("Unsaved changes discarded", false)
+		case key.Matches(msg, m.keys.Item.Save):
+			return m, m.save()
 		}
 	}
-	
+
 	return m, nil
 }
 
+func (m *Model) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	item := m.selectedItem()
+
+	switch msg.String() {
+	case "esc":
+		m.editing = false
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		value := m.input.Value()
+		// An empty secret keeps the current one
+		if item.Kind == kindSecret && value == "" {
+			m.editing = false
+			m.input.Blur()
+			return m, nil
+		}
+		if m.apply(item, value) {
+			m.editing = false
+			m.input.Blur()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) startEditing(item ConfigItem) {
+	m.editing = true
+	m.status = ""
+	m.input.Reset()
+	m.input.EchoMode = textinput.EchoNormal
+	m.input.Placeholder = ""
+	if item.Kind == kindSecret {
+		m.input.EchoMode = textinput.EchoPassword
+		m.input.Placeholder = "value, or a keyring:, age:, cmd: or env: reference"
+	} else {
+		m.input.SetValue(item.get(&m.draft))
+	}
+	m.input.Focus()
+}
+
+// apply sets an item on the draft and reports whether the value was valid
+func (m *Model) apply(item ConfigItem, value string) bool {
+	if err := item.set(&m.draft, value); err != nil {
+		m.setStatus(err.Error(), true)
+		return false
+	}
+	m.dirty = true
+	m.setStatus(fmt.Sprintf("%s changed; press s to save", item.Key), false)
+	return true
+}
+
+func (m *Model) cycle(item ConfigItem, step int) {
+	choices := item.Choices(&m.draft)
+	if len(choices) == 0 {
+		return
+	}
+	current := 0
+	for i, choice := range choices {
+		if choice == item.get(&m.draft) {
+			current = i
+		}
+	}
+	next := (current + step + len(choices)) % len(choices)
+	m.apply(item, choices[next])
+}
+
+// save validates the draft, writes it to config.yaml and makes it the
+// running configuration
+func (m *Model) save() tea.Cmd {
+	if err := m.draft.Validate(); err != nil {
+		m.setStatus(fmt.Sprintf("Not saved: %v", err), true)
+		return nil
+	}
+	if err := config.Save(&m.draft, ""); err != nil {
+		m.setStatus(fmt.Sprintf("Failed to save: %v", err), true)
+		return nil
+	}
+
+	reconnect := m.draft.Cloudflare != m.config.Cloudflare || m.draft.ZAPI != m.config.ZAPI
+	*m.config = m.draft
+	m.dirty = false
+
+	status := fmt.Sprintf("Saved to %s", m.config.Path())
+	if reconnect {
+		status += "; restart ewctl to reconnect with the new credentials"
+	}
+	m.setStatus(status, false)
+
+	cfg := m.config
+	return func() tea.Msg {
+		return ConfigSavedMsg{Config: cfg}
+	}
+}
+
+func (m *Model) setStatus(status string, isError bool) {
+	m.status = status
+	m.isError = isError
+}
+
+func (m *Model) selectedItem() ConfigItem {
+	index := 0
+	for _, section := range m.sections {
+		for _, item := range section.Items {
+			if index == m.selected {
+				return item
+			}
+			index++
+		}
+	}
+	return ConfigItem{}
+}
+
 func (m *Model) View() string {
 	title := m.styles.Title.Render("⚙️ Settings")
-	
+
 	description := m.styles.Muted.Render("Configure application settings and preferences")
-	
+	if m.dirty {
+		description += "  " + m.styles.Warning.Render("● unsaved changes")
+	}
+
 	// Render sections
 	var sectionViews []string
 	itemIndex := 0
-	
+
 	for _, section := range m.sections {
 		sectionTitle := m.styles.Subtitle.Render(section.Title)
-		
+
 		var items []string
 		for _, item := range section.Items {
 			var itemView string
-			
+
 			label := m.styles.Label.Render(item.Label + ":")
-			value := item.Value
-			if value == "" {
-				value = m.styles.Muted.Render("(not set)")
-			} else if !item.Sensitive {
-				value = m.styles.Text.Render(value)
-			} else {
-				value = m.styles.Warning.Render(value)
+			value := m.renderValue(item)
+			if itemIndex == m.selected && m.editing {
+				value = m.input.View()
 			}
-			
+
 			itemContent := lipgloss.JoinHorizontal(
 				lipgloss.Top,
 				lipgloss.NewStyle().Width(25).Render(label),
 				value,
 			)
-			
+
 			if itemIndex == m.selected {
 				itemView = m.styles.ActiveItem.Render("▶ " + itemContent)
 			} else {
 				itemView = "  " + itemContent
 			}
-			
+
 			items = append(items, itemView)
 			itemIndex++
 		}
-		
+		if section.Note != "" {
+			items = append(items, m.styles.Muted.Render("  "+section.Note))
+		}
+
 		sectionContent := lipgloss.JoinVertical(
 			lipgloss.Top,
 			sectionTitle,
 			lipgloss.JoinVertical(lipgloss.Top, items...),
 		)
-		
+
 		sectionBox := lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
 			BorderForeground(m.styles.Colors.Border).
-			Padding(1, 2).
-			Width(70).
-			MarginBottom(1).
+			Padding(0, 2).
+			Width(76).
 			Render(sectionContent)
-		
+
 		sectionViews = append(sectionViews, sectionBox)
 	}
-	
-	content := lipgloss.JoinVertical(
-		lipgloss.Top,
-		sectionViews...,
-	)
-	
+
+	// Two columns when the terminal is wide enough
+	var content string
+	if m.width >= 160 {
+		half := (len(sectionViews) + 1) / 2
+		content = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.JoinVertical(lipgloss.Top, sectionViews[:half]...),
+			" ",
+			lipgloss.JoinVertical(lipgloss.Top, sectionViews[half:]...),
+		)
+	} else {
+		content = lipgloss.JoinVertical(lipgloss.Top, sectionViews...)
+	}
+
+	parts := []string{title, description, "", content}
+	if m.status != "" {
+		style := m.styles.Success
+		if m.isError {
+			style = m.styles.Error
+		}
+		parts = append(parts, "", style.Render(m.status))
+	}
+
 	// Actions
-	actions := m.styles.Help.Render("Press Enter to edit • 's' to save changes")
-	
-	return lipgloss.JoinVertical(
-		lipgloss.Top,
-		title,
-		description,
-		"",
-		content,
-		"",
-		actions,
+	actions := keys.Help(
+		keys.Describe(m.keys.List.Open, "Edit"),
+		keys.Join("Choose", m.keys.List.Left, m.keys.List.Right),
+		m.keys.Item.Save,
+		keys.Describe(m.keys.Item.Undo, "Undo changes"),
+		m.keys.Global.Back,
 	)
+	if m.editing {
+		actions = "Enter: Apply • Esc: Cancel"
+		if m.selectedItem().Kind == kindSecret {
+			actions = "Enter: Apply (empty keeps the current value) • Esc: Cancel"
+		}
+	}
+	parts = append(parts, "", m.styles.Help.Render(actions))
+
+	return lipgloss.JoinVertical(lipgloss.Top, parts...)
+}
+
+func (m *Model) renderValue(item ConfigItem) string {
+	value := item.get(&m.draft)
+	switch item.Kind {
+	case kindBool:
+		return m.styles.Text.Render(boolToString(value == "true"))
+	case kindSecret:
+		source := m.draft.SecretSource(item.Key)
+		if value == "" {
+			return m.styles.Muted.Render("(" + source + ")")
+		}
+		return m.styles.Warning.Render(maskToken(value)) + " " + m.styles.Muted.Render("from "+source)
+	case kindChoice:
+		if value == "" {
+			value = "none"
+		}
+		return m.styles.Text.Render("‹ " + value + " ›")
+	}
+	if value == "" {
+		return m.styles.Muted.Render("(not set)")
+	}
+	return m.styles.Text.Render(value)
 }
 
 func (m *Model) getTotalItems() int {
@@ -209,4 +584,9 @@ func boolToString(b bool) string {
 		return "enabled"
 	}
 	return "disabled"
-}
\ No newline at end of file
+}
+
+// Message types
+type ConfigSavedMsg struct {
+	Config *config.Config
+}
@@ -0,0 +1,602 @@
+package submissions
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/components"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/config"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/database"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/export"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/styles"
+	"github.com/thalysguimaraes/elementor-whatsapp/internal/tui/keys"
+)
+
+// pageSize caps how many submissions the inbox loads at once
+const pageSize = 200
+
+// dateRange is a preset the inbox can filter by
+type dateRange struct {
+	label string
+	since func(now time.Time) time.Time
+}
+
+var dateRanges = []dateRange{
+	{"All time", func(time.Time) time.Time { return time.Time{} }},
+	{"Today", func(now time.Time) time.Time {
+		y, m, d := now.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	}},
+	{"Last 7 days", func(now time.Time) time.Time { return now.AddDate(0, 0, -7) }},
+	{"Last 30 days", func(now time.Time) time.Time { return now.AddDate(0, 0, -30) }},
+}
+
+// Model is the lead inbox: every stored submission, searchable and
+// filterable by form and date
+type Model struct {
+	config      *config.Config
+	styles      *styles.Styles
+	keys        *keys.Map
+	table       table.Model
+	spinner     spinner.Model
+	search      textinput.Model
+	db          *database.Client
+	submissions []database.Submission
+	fields      map[string][]database.Field
+	forms       []string
+	total       int
+	// formFilter indexes forms; -1 shows every form
+	formFilter int
+	dateFilter int
+	searching  bool
+	// detail is the submission open in the detail pane
+	detail  *database.Submission
+	loading bool
+	// refreshing is a background reload that keeps the table on screen
+	refreshing bool
+	updatedAt  time.Time
+	// changes highlights submissions a background refresh added or changed
+	changes components.Changes
+	err     error
+	width   int
+	height  int
+	// exportPath asks where to export the filtered submissions
+	exportPath textinput.Model
+	exporting  bool
+	status     string
+}
+
+func New(cfg *config.Config, s *styles.Styles, km *keys.Map) *Model {
+	columns := []table.Column{
+		{Title: "ID", Width: 7},
+		{Title: "Received", Width: 17},
+		{Title: "Form", Width: 20},
+		{Title: "Lead", Width: 40},
+		{Title: "Delivery", Width: 9},
+		{Title: "Country", Width: 7},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows([]table.Row{}),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+
+	tableStyle := table.DefaultStyles()
+	tableStyle.Header = tableStyle.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(s.Colors.Border).
+		BorderBottom(true).
+		Bold(false)
+	tableStyle.Selected = tableStyle.Selected.
+		Foreground(s.Colors.Primary).
+		Background(s.Colors.BgSecondary).
+		Bold(false)
+	t.SetStyles(tableStyle)
+
+	sp := components.NewSpinner(cfg, s)
+
+	search := textinput.New()
+	search.Placeholder = "name, email, phone..."
+	search.Prompt = "/ "
+	search.CharLimit = 100
+
+	exportPath := textinput.New()
+	exportPath.Prompt = "Export to: "
+	exportPath.CharLimit = 255
+
+	// Create database client
+	db, err := database.NewClient(cfg)
+	if err != nil {
+		log.Error("Failed to create database client", "error", err)
+	}
+
+	return &Model{
+		config:     cfg,
+		styles:     s,
+		keys:       km,
+		table:      t,
+		spinner:    sp,
+		search:     search,
+		exportPath: exportPath,
+		db:         db,
+		formFilter: -1,
+		err:        err,
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// StartLoading loads the inbox when the view becomes active
+func (m *Model) StartLoading() tea.Cmd {
+	if m.loading || m.db == nil {
+		return nil
+	}
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, m.load)
+}
+
+// Refresh reloads the inbox in the background
+func (m *Model) Refresh() tea.Cmd {
+	if m.loading || m.refreshing || m.db == nil {
+		return nil
+	}
+	m.refreshing = true
+	return m.load
+}
+
+// UpdatedAt reports when the submissions on screen were loaded
+func (m *Model) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// HasModal reports whether Esc should close the search or detail pane
+// instead of leaving the view
+func (m *Model) HasModal() bool {
+	return m.searching || m.exporting || m.detail != nil
+}
+
+// ShortHelp lists the keys shown in the footer
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Join("Navigate", m.keys.List.Up, m.keys.List.Down),
+		m.keys.Item.Search,
+		m.keys.Filter.Form,
+		keys.Describe(m.keys.Filter.Time, "Date"),
+		m.keys.Item.Export,
+		keys.Describe(m.keys.List.Open, "Details"),
+	}
+}
+
+// FullHelp lists every key of the view for the ? overlay
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{m.keys.List.Up, m.keys.List.Down, m.keys.List.Top, m.keys.List.Bottom},
+		{m.keys.List.PageUp, m.keys.List.PageDown, m.keys.List.HalfPageUp, m.keys.List.HalfPageDown},
+		{m.keys.Item.Search, m.keys.Filter.Form, keys.Describe(m.keys.Filter.Time, "Date"), m.keys.Filter.Clear},
+		{keys.Describe(m.keys.List.Open, "Details"), m.keys.Item.Export, m.keys.Item.Refresh},
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.table.SetHeight(m.height - 12)
+
+	case tea.MouseMsg:
+		if m.loading || m.HasModal() {
+			return m, nil
+		}
+		components.TableMouse(&m.table, m.View(), msg)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+
+		if m.searching {
+			switch msg.String() {
+			case "enter":
+				m.searching = false
+				m.search.Blur()
+				m.loading = true
+				return m, m.load
+			case "esc":
+				m.searching = false
+				m.search.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			return m, cmd
+		}
+
+		if m.exporting {
+			switch msg.String() {
+			case "enter":
+				path := strings.TrimSpace(m.exportPath.Value())
+				m.exporting = false
+				m.exportPath.Blur()
+				if path == "" {
+					return m, nil
+				}
+				m.status = "Exporting to " + path + "..."
+				return m, m.export(path)
+			case "esc":
+				m.exporting = false
+				m.exportPath.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.exportPath, cmd = m.exportPath.Update(msg)
+			return m, cmd
+		}
+
+		if m.detail != nil {
+			if key.Matches(msg, m.keys.Global.Back, m.keys.List.Open, m.keys.Global.Quit) {
+				m.detail = nil
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Item.Search):
+			m.searching = true
+			return m, m.search.Focus()
+		case key.Matches(msg, m.keys.List.Open):
+			if i := m.table.Cursor(); i < len(m.submissions) {
+				m.detail = &m.submissions[i]
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Filter.Form):
+			// Cycle through the forms, then back to every form
+			m.formFilter++
+			if m.formFilter >= len(m.forms) {
+				m.formFilter = -1
+			}
+			m.loading = true
+			return m, m.load
+		case key.Matches(msg, m.keys.Filter.Time):
+			m.dateFilter = (m.dateFilter + 1) % len(dateRanges)
+			m.loading = true
+			return m, m.load
+		case key.Matches(msg, m.keys.Filter.Clear):
+			// Clear every filter
+			m.formFilter = -1
+			m.dateFilter = 0
+			m.search.SetValue("")
+			m.loading = true
+			return m, m.load
+		case key.Matches(msg, m.keys.Item.Export):
+			m.exporting = true
+			m.exportPath.SetValue(m.exportFileName())
+			m.exportPath.CursorEnd()
+			return m, m.exportPath.Focus()
+		case key.Matches(msg, m.keys.Item.Refresh):
+			m.loading = true
+			return m, m.load
+		}
+
+	case ExportDoneMsg:
+		if msg.Error != nil {
+			m.status = fmt.Sprintf("Export failed: %v", msg.Error)
+		} else {
+			m.status = fmt.Sprintf("Exported %d submissions to %s", msg.Count, msg.Path)
+		}
+
+	case SubmissionsLoadedMsg:
+		m.loading = false
+		refreshing := m.refreshing
+		m.refreshing = false
+		if msg.Error != nil && refreshing {
+			// Keep the submissions on screen; the footer shows how old they are
+			log.Warn("Failed to refresh submissions", "error", msg.Error)
+			break
+		}
+		m.err = msg.Error
+		if msg.Error == nil {
+			m.updatedAt = time.Now()
+		}
+		m.submissions = msg.Submissions
+		m.total = msg.Total
+		m.fields = msg.Fields
+		m.forms = msg.Forms
+		if m.formFilter >= len(m.forms) {
+			m.formFilter = -1
+		}
+		cmds = append(cmds, m.updateTable(refreshing))
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	// The keymap changes when settings are saved
+	m.table.KeyMap = m.keys.Table()
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) View() string {
+	if m.err != nil {
+		return m.renderError()
+	}
+
+	if m.loading {
+		return m.spinner.View() + " Loading submissions..."
+	}
+
+	title := m.styles.Title.Render("📥 Lead Inbox")
+
+	if m.detail != nil {
+		return lipgloss.JoinVertical(lipgloss.Top,
+			title,
+			"",
+			m.renderDetail(*m.detail),
+			"",
+			m.styles.Help.Render(keys.Help(keys.Describe(m.keys.Global.Back, "Back to inbox"))),
+		)
+	}
+
+	filters := []string{"Form: " + m.formLabel(), "Date: " + dateRanges[m.dateFilter].label}
+	if q := m.search.Value(); q != "" {
+		filters = append(filters, fmt.Sprintf("Search: %q", q))
+	}
+	count := fmt.Sprintf("%d submissions", m.total)
+	if m.total > len(m.submissions) {
+		count = fmt.Sprintf("showing %d of %d submissions", len(m.submissions), m.total)
+	}
+	stats := m.styles.Muted.Render(count + " • " + strings.Join(filters, " • "))
+
+	body := m.table.View()
+	if m.changes.Active() {
+		body = components.HighlightTable(m.table, m.styles.Highlight, func(row int) bool {
+			return row < len(m.submissions) && m.changes.Changed(fmt.Sprint(m.submissions[row].ID))
+		})
+	}
+	if len(m.submissions) == 0 {
+		body = m.styles.Muted.Render("No submissions match these filters")
+	}
+
+	parts := []string{title, stats, ""}
+	if m.searching {
+		parts = append(parts, m.search.View(), "")
+	}
+	parts = append(parts, body, "")
+	if m.exporting {
+		parts = append(parts, m.exportPath.View(),
+			m.styles.Muted.Render("The extension picks the format: .csv, .jsonl or .xlsx"), "")
+	} else if m.status != "" {
+		parts = append(parts, m.styles.Info.Render(m.status), "")
+	}
+	parts = append(parts,
+		m.styles.Help.Render(keys.Help(
+			m.keys.Item.Search,
+			m.keys.Filter.Form,
+			keys.Describe(m.keys.Filter.Time, "Date"),
+			m.keys.Filter.Clear,
+			keys.Describe(m.keys.List.Open, "Details"),
+			m.keys.Item.Export,
+			m.keys.Item.Refresh,
+		)))
+
+	return lipgloss.JoinVertical(lipgloss.Top, parts...)
+}
+
+func (m *Model) renderDetail(s database.Submission) string {
+	lines := []string{
+		m.styles.Subtitle.Render(fmt.Sprintf("Submission #%d • %s", s.ID, s.FormID)),
+		m.styles.Muted.Render(s.CreatedAt.Local().Format("Monday, 2006-01-02 15:04:05")),
+		"",
+	}
+
+	for _, field := range s.LabeledFields(m.fields[s.FormID]) {
+		lines = append(lines, fmt.Sprintf("%s %s", m.styles.Label.Render(field.Label+":"), field.Value))
+	}
+
+	delivery := m.styles.Text
+	switch s.DeliveryStatus {
+	case database.DeliverySuccess, database.DeliveryResent:
+		delivery = m.styles.Success
+	case database.DeliveryPartial, "buffered", "pending":
+		delivery = m.styles.Warning
+	case database.DeliveryFailed:
+		delivery = m.styles.Error
+	}
+
+	var source []string
+	for _, part := range []string{s.City, s.Country, s.IP} {
+		if part != "" {
+			source = append(source, part)
+		}
+	}
+
+	lines = append(lines, "",
+		fmt.Sprintf("%s %s", m.styles.Label.Render("Delivery:"), delivery.Render(s.DeliveryStatus)),
+		fmt.Sprintf("%s %s", m.styles.Label.Render("Source:"), strings.Join(source, ", ")),
+	)
+	if s.UserAgent != "" {
+		lines = append(lines, fmt.Sprintf("%s %s", m.styles.Label.Render("Agent:"), s.UserAgent))
+	}
+	if s.Referer != "" {
+		lines = append(lines, fmt.Sprintf("%s %s", m.styles.Label.Render("Referer:"), s.Referer))
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Colors.Border).
+		Padding(0, 2).
+		Width(90).
+		Render(lipgloss.JoinVertical(lipgloss.Top, lines...))
+}
+
+func (m *Model) renderError() string {
+	errorView := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
+	help := m.styles.Help.Render(fmt.Sprintf("Press '%s' to retry", m.keys.Item.Refresh.Help().Key))
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		errorView,
+		help,
+	)
+}
+
+func (m *Model) formLabel() string {
+	if m.formFilter < 0 || m.formFilter >= len(m.forms) {
+		return "all"
+	}
+	return m.forms[m.formFilter]
+}
+
+func (m *Model) filter() database.SubmissionFilter {
+	filter := database.SubmissionFilter{
+		Search: m.search.Value(),
+		Since:  dateRanges[m.dateFilter].since(time.Now()),
+		Limit:  pageSize,
+	}
+	if m.formFilter >= 0 && m.formFilter < len(m.forms) {
+		filter.FormID = m.forms[m.formFilter]
+	}
+	return filter
+}
+
+// exportFileName suggests a file name for the current filters
+func (m *Model) exportFileName() string {
+	return fmt.Sprintf("submissions-%s-%s.csv", m.formLabel(), time.Now().Format("2006-01-02"))
+}
+
+// export streams every submission matching the current filters to a file
+func (m *Model) export(path string) tea.Cmd {
+	filter := m.filter()
+	filter.Limit = 0
+	return func() tea.Msg {
+		format := export.FormatFromPath(path)
+		if format == "" {
+			return ExportDoneMsg{Path: path, Error: fmt.Errorf("unknown format, use a .csv, .jsonl or .xlsx file")}
+		}
+		fields, err := m.db.GetFieldsByForm()
+		if err != nil {
+			return ExportDoneMsg{Path: path, Error: err}
+		}
+
+		f, err := os.Create(path)
+		if err != nil {
+			return ExportDoneMsg{Path: path, Error: fmt.Errorf("failed to create %s: %w", path, err)}
+		}
+		defer f.Close()
+
+		writer, err := export.NewWriter(format, f, export.NewLayout(fields, filter.FormID))
+		if err != nil {
+			return ExportDoneMsg{Path: path, Error: err}
+		}
+		count, err := export.Stream(m.db, filter, writer)
+		return ExportDoneMsg{Path: path, Count: count, Error: err}
+	}
+}
+
+func (m *Model) load() tea.Msg {
+	if m.db == nil {
+		return SubmissionsLoadedMsg{Error: fmt.Errorf("database client not initialized")}
+	}
+
+	filter := m.filter()
+	submissions, err := m.db.GetSubmissions(filter)
+	if err != nil {
+		return SubmissionsLoadedMsg{Error: err}
+	}
+	total, err := m.db.CountSubmissions(filter)
+	if err != nil {
+		return SubmissionsLoadedMsg{Error: err}
+	}
+	fields, err := m.db.GetFieldsByForm()
+	if err != nil {
+		return SubmissionsLoadedMsg{Error: err}
+	}
+
+	forms, err := m.db.GetAllForms()
+	if err != nil {
+		return SubmissionsLoadedMsg{Error: err}
+	}
+	var formIDs []string
+	for _, form := range forms {
+		formIDs = append(formIDs, form.ID)
+	}
+
+	return SubmissionsLoadedMsg{
+		Submissions: submissions,
+		Total:       total,
+		Fields:      fields,
+		Forms:       formIDs,
+	}
+}
+
+// updateTable fills the table with the loaded submissions and, when
+// highlight is set, highlights the ones that changed since the last load
+func (m *Model) updateTable(highlight bool) tea.Cmd {
+	var rows []table.Row
+	prints := make(map[string]string, len(m.submissions))
+	for _, s := range m.submissions {
+		var values []string
+		for _, field := range s.LabeledFields(m.fields[s.FormID]) {
+			if field.Value != "" {
+				values = append(values, field.Value)
+			}
+			if len(values) == 2 {
+				break
+			}
+		}
+		rows = append(rows, table.Row{
+			fmt.Sprintf("#%d", s.ID),
+			s.CreatedAt.Local().Format("2006-01-02 15:04"),
+			s.FormID,
+			strings.Join(values, " • "),
+			s.DeliveryStatus,
+			s.Country,
+		})
+		prints[fmt.Sprint(s.ID)] = strings.Join(rows[len(rows)-1], "\t")
+	}
+
+	m.table.SetRows(rows)
+	if m.table.Cursor() >= len(rows) {
+		m.table.SetCursor(max(len(rows)-1, 0))
+	}
+	return m.changes.Track(prints, highlight)
+}
+
+// Message types
+type SubmissionsLoadedMsg struct {
+	Submissions []database.Submission
+	Total       int
+	Fields      map[string][]database.Field
+	Forms       []string
+	Error       error
+}
+
+type ExportDoneMsg struct {
+	Path  string
+	Count int
+	Error error
+}
@@ -71,6 +71,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+
+	case SelectFormMsg:
+		// Opened from the command palette for a form
+		m.inputs[0].SetValue(msg.FormID)
+		m.focused = 1
 		
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -163,4 +168,11 @@ func (m *Model) prevInput() {
 	if m.focused < 0 {
 		m.focused = len(m.inputs) - 1
 	}
+}
+
+// Message types
+
+// SelectFormMsg fills in the form to test
+type SelectFormMsg struct {
+	FormID string
 }
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- Migration: Soft-delete (archive) for forms and contacts
+-- Archived rows are hidden from listings and ignored by the worker until
+-- restored or purged with `ewctl forms purge` / `ewctl contacts purge`
+
+ALTER TABLE forms ADD COLUMN archived_at DATETIME;
+ALTER TABLE contacts ADD COLUMN archived_at DATETIME;
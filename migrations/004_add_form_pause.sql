@@ -0,0 +1,19 @@
+-- Migration: Enable/disable forms with an optional pause window
+-- Paused forms still accept webhooks; the worker buffers each submission
+-- and delivers it once the form is resumed or the window ends
+
+ALTER TABLE forms ADD COLUMN enabled BOOLEAN DEFAULT 1;
+ALTER TABLE forms ADD COLUMN paused_until DATETIME;
+
+-- Submissions received while a form is paused, delivered on resume
+CREATE TABLE IF NOT EXISTS buffered_submissions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  form_id TEXT NOT NULL,
+  message TEXT NOT NULL,         -- Formatted WhatsApp message
+  payload TEXT,                  -- Extracted fields as JSON
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  delivered_at DATETIME,         -- NULL until sent
+  FOREIGN KEY (form_id) REFERENCES forms(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_buffered_submissions_pending ON buffered_submissions(form_id, delivered_at);
@@ -0,0 +1,16 @@
+-- Migration: Audit trail of configuration changes
+
+-- Audit trail of configuration changes made through ewctl
+CREATE TABLE IF NOT EXISTS audit_log (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  actor TEXT NOT NULL,           -- Config `actor` or the OS user
+  profile TEXT,                  -- Active config profile
+  action TEXT NOT NULL,          -- create, update, delete, import, archive, pause, ...
+  entity_type TEXT NOT NULL,     -- form or contact
+  entity_id TEXT NOT NULL,
+  before_json TEXT,              -- State before the change (NULL on create)
+  after_json TEXT,               -- State after the change (NULL on delete)
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity_type, entity_id);
@@ -0,0 +1,14 @@
+-- Migration: Form version history for diff and rollback
+-- Versions are kept when a form is deleted and removed when it is purged
+
+-- Numbered snapshots of each form, taken on every save
+CREATE TABLE IF NOT EXISTS form_versions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  form_id TEXT NOT NULL,
+  version INTEGER NOT NULL,      -- 1, 2, 3... per form
+  snapshot TEXT NOT NULL,        -- Full form (fields and numbers) as JSON
+  note TEXT,                     -- e.g. 'created', 'rollback to v3'
+  actor TEXT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE(form_id, version)
+);
@@ -0,0 +1,31 @@
+-- Migration: Delivery log for replaying failed WhatsApp sends
+-- The worker records every webhook in webhook_logs and each recipient's
+-- outcome in delivery_recipients; `ewctl deliveries retry` resends the
+-- failed ones
+
+-- One row per webhook the worker processed
+CREATE TABLE IF NOT EXISTS webhook_logs (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  form_id TEXT,
+  status TEXT,                   -- success, partial, failed, resent
+  request TEXT,                  -- {"message": ..., "fields": {...}}
+  response TEXT,                 -- Per-recipient Z-API results
+  duration_ms INTEGER,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (form_id) REFERENCES forms(id) ON DELETE CASCADE
+);
+
+-- Outcome of each delivery per recipient; resends only target the failed ones
+CREATE TABLE IF NOT EXISTS delivery_recipients (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  log_id INTEGER NOT NULL,
+  phone TEXT NOT NULL,
+  status TEXT NOT NULL,          -- sent, failed, sending (claimed by a resend)
+  attempts INTEGER DEFAULT 1,
+  last_error TEXT,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (log_id) REFERENCES webhook_logs(id) ON DELETE CASCADE,
+  UNIQUE(log_id, phone)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_logs_status ON webhook_logs(status, created_at);
@@ -0,0 +1,24 @@
+-- Migration: Durable outbound queue
+-- The worker enqueues recipients it could not reach; ewctl retries them
+-- with exponential backoff until they are sent or dead-lettered
+
+-- Outbound messages, one job per recipient, drained by `ewctl queue run`
+-- or `ewctl serve` with exponential backoff and dead-lettering
+CREATE TABLE IF NOT EXISTS outbound_jobs (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  form_id TEXT,
+  log_id INTEGER,                -- webhook_logs row the job retries, if any
+  phone TEXT NOT NULL,
+  message TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending', -- pending, in_flight, sent, dead
+  attempts INTEGER DEFAULT 0,
+  max_attempts INTEGER DEFAULT 5,
+  next_attempt_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  locked_by TEXT,                -- Runner holding the job while in flight
+  locked_until DATETIME,         -- Lease expiry; expired jobs are picked up again
+  last_error TEXT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbound_jobs_due ON outbound_jobs(status, next_attempt_at);
@@ -0,0 +1,23 @@
+-- Migration: Store every form submission
+-- The worker records each submission with its fields, raw payload, source
+-- metadata and delivery outcome before sending it to WhatsApp
+
+-- Every form submission as received, the source of truth for leads
+CREATE TABLE IF NOT EXISTS submissions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  form_id TEXT NOT NULL,
+  fields TEXT,                   -- Extracted fields as JSON
+  payload TEXT,                  -- Raw request body
+  country TEXT,                  -- From Cloudflare's request metadata
+  city TEXT,
+  user_agent TEXT,
+  ip TEXT,
+  referer TEXT,
+  delivery_status TEXT,          -- pending, buffered, success, partial, failed, resent
+  log_id INTEGER,                -- webhook_logs row of the delivery
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE buffered_submissions ADD COLUMN submission_id INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_submissions_form ON submissions(form_id, created_at);
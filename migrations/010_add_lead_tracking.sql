@@ -0,0 +1,32 @@
+-- Migration: Work submissions as leads
+-- Each submission gets a status, an owner contact and a follow-up date;
+-- status changes and notes are kept as the lead's history
+
+ALTER TABLE submissions ADD COLUMN lead_status TEXT DEFAULT 'new';
+ALTER TABLE submissions ADD COLUMN owner_contact_id INTEGER;
+ALTER TABLE submissions ADD COLUMN follow_up_at DATETIME;
+
+-- Every lead status change, for the lead's timeline
+CREATE TABLE IF NOT EXISTS lead_status_history (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  submission_id INTEGER NOT NULL,
+  from_status TEXT,
+  to_status TEXT NOT NULL,
+  actor TEXT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (submission_id) REFERENCES submissions(id) ON DELETE CASCADE
+);
+
+-- Free-form notes on a lead
+CREATE TABLE IF NOT EXISTS lead_notes (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  submission_id INTEGER NOT NULL,
+  body TEXT NOT NULL,
+  actor TEXT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (submission_id) REFERENCES submissions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_submissions_lead ON submissions(form_id, lead_status);
+CREATE INDEX IF NOT EXISTS idx_lead_status_history ON lead_status_history(submission_id);
+CREATE INDEX IF NOT EXISTS idx_lead_notes ON lead_notes(submission_id);
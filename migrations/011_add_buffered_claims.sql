@@ -0,0 +1,6 @@
+-- Migration: Claim buffered submissions before sending them
+-- The scheduled flush and the flush started by a resume webhook can run at
+-- the same time; each claims a submission before sending so only one
+-- delivers it
+
+ALTER TABLE buffered_submissions ADD COLUMN claimed_at DATETIME;
@@ -0,0 +1,135 @@
+package zapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the Z-API endpoint used by the worker
+const DefaultBaseURL = "https://api.z-api.io"
+
+// Client sends WhatsApp messages through a Z-API instance
+type Client struct {
+	httpClient    *http.Client
+	baseURL       string
+	instanceID    string
+	instanceToken string
+	clientToken   string
+}
+
+// SendResult is Z-API's answer to a send-text request
+type SendResult struct {
+	ZaapID    string `json:"zaapId"`
+	MessageID string `json:"messageId"`
+	ID        string `json:"id"`
+}
+
+// Status describes whether the instance can send messages
+type Status struct {
+	Connected           bool   `json:"connected"`
+	Session             bool   `json:"session"`
+	SmartphoneConnected bool   `json:"smartphoneConnected"`
+	Error               string `json:"error"`
+}
+
+// APIError is returned when Z-API answers with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("z-api returned %d: %s", e.StatusCode, e.Body)
+}
+
+// NewClient creates a client for the given instance credentials
+func NewClient(instanceID, instanceToken, clientToken string) *Client {
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		baseURL:       DefaultBaseURL,
+		instanceID:    instanceID,
+		instanceToken: instanceToken,
+		clientToken:   clientToken,
+	}
+}
+
+// WithBaseURL points the client at another Z-API host; empty keeps the
+// default
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	if baseURL != "" {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+	return c
+}
+
+// Configured reports whether the instance credentials are set
+func (c *Client) Configured() bool {
+	return c.instanceID != "" && c.instanceToken != ""
+}
+
+// SendText sends a text message to a phone number
+func (c *Client) SendText(phone, message string) (*SendResult, error) {
+	body, err := json.Marshal(map[string]string{"phone": phone, "message": message})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	var result SendResult
+	if err := c.do("POST", "send-text", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Status reports whether the instance is connected to WhatsApp
+func (c *Client) Status() (*Status, error) {
+	var status Status
+	if err := c.do("GET", "status", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func (c *Client) do(method, path string, body []byte, out interface{}) error {
+	if !c.Configured() {
+		return fmt.Errorf("z-api instance_id and instance_token are not configured")
+	}
+
+	url := fmt.Sprintf("%s/instances/%s/token/%s/%s", c.baseURL, c.instanceID, c.instanceToken, path)
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.clientToken != "" {
+		req.Header.Set("Client-Token", c.clientToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &APIError{StatusCode: resp.StatusCode, Body: string(data)}
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return nil
+}
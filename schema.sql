@@ -6,7 +6,10 @@ CREATE TABLE IF NOT EXISTS forms (
   name TEXT NOT NULL,
   description TEXT,
   created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  archived_at DATETIME,           -- Set when archived; NULL while active
+  enabled BOOLEAN DEFAULT 1,      -- 0 while paused: submissions are buffered
+  paused_until DATETIME           -- Optional end of the pause window
 );
 
 -- Form fields mapping: maps Elementor field IDs to friendly labels
@@ -30,7 +33,8 @@ CREATE TABLE IF NOT EXISTS contacts (
   role TEXT,
   notes TEXT,
   created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  archived_at DATETIME            -- Set when archived; NULL while active
 );
 
 -- WhatsApp numbers per form
@@ -46,11 +50,142 @@ CREATE TABLE IF NOT EXISTS form_numbers (
   UNIQUE(form_id, phone_number)
 );
 
+-- One row per webhook the worker processed
+CREATE TABLE IF NOT EXISTS webhook_logs (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  form_id TEXT,
+  status TEXT,                   -- success, partial, failed, resent
+  request TEXT,                  -- {"message": ..., "fields": {...}}
+  response TEXT,                 -- Per-recipient Z-API results
+  duration_ms INTEGER,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (form_id) REFERENCES forms(id) ON DELETE CASCADE
+);
+
+-- Outcome of each delivery per recipient; resends only target the failed ones
+CREATE TABLE IF NOT EXISTS delivery_recipients (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  log_id INTEGER NOT NULL,
+  phone TEXT NOT NULL,
+  status TEXT NOT NULL,          -- sent, failed, sending (claimed by a resend)
+  attempts INTEGER DEFAULT 1,
+  last_error TEXT,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (log_id) REFERENCES webhook_logs(id) ON DELETE CASCADE,
+  UNIQUE(log_id, phone)
+);
+
+-- Outbound messages, one job per recipient, drained by `ewctl queue run`
+-- or `ewctl serve` with exponential backoff and dead-lettering
+CREATE TABLE IF NOT EXISTS outbound_jobs (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  form_id TEXT,
+  log_id INTEGER,                -- webhook_logs row the job retries, if any
+  phone TEXT NOT NULL,
+  message TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending', -- pending, in_flight, sent, dead
+  attempts INTEGER DEFAULT 0,
+  max_attempts INTEGER DEFAULT 5,
+  next_attempt_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  locked_by TEXT,                -- Runner holding the job while in flight
+  locked_until DATETIME,         -- Lease expiry; expired jobs are picked up again
+  last_error TEXT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Submissions received while a form is paused, delivered on resume
+CREATE TABLE IF NOT EXISTS buffered_submissions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  form_id TEXT NOT NULL,
+  message TEXT NOT NULL,         -- Formatted WhatsApp message
+  payload TEXT,                  -- Extracted fields as JSON
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  delivered_at DATETIME,         -- NULL until sent
+  submission_id INTEGER,         -- submissions row the message was built from
+  claimed_at DATETIME,           -- Set while a worker run is sending it
+  FOREIGN KEY (form_id) REFERENCES forms(id) ON DELETE CASCADE
+);
+
+-- Every form submission as received, the source of truth for leads
+CREATE TABLE IF NOT EXISTS submissions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  form_id TEXT NOT NULL,
+  fields TEXT,                   -- Extracted fields as JSON
+  payload TEXT,                  -- Raw request body
+  country TEXT,                  -- From Cloudflare's request metadata
+  city TEXT,
+  user_agent TEXT,
+  ip TEXT,
+  referer TEXT,
+  delivery_status TEXT,          -- pending, buffered, success, partial, failed, resent
+  log_id INTEGER,                -- webhook_logs row of the delivery
+  lead_status TEXT DEFAULT 'new', -- new, contacted, qualified, lost
+  owner_contact_id INTEGER,      -- Contact working the lead
+  follow_up_at DATETIME,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (owner_contact_id) REFERENCES contacts(id) ON DELETE SET NULL
+);
+
+-- Every lead status change, for the lead's timeline
+CREATE TABLE IF NOT EXISTS lead_status_history (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  submission_id INTEGER NOT NULL,
+  from_status TEXT,
+  to_status TEXT NOT NULL,
+  actor TEXT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (submission_id) REFERENCES submissions(id) ON DELETE CASCADE
+);
+
+-- Free-form notes on a lead
+CREATE TABLE IF NOT EXISTS lead_notes (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  submission_id INTEGER NOT NULL,
+  body TEXT NOT NULL,
+  actor TEXT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (submission_id) REFERENCES submissions(id) ON DELETE CASCADE
+);
+
+-- Numbered snapshots of each form, taken on every save
+CREATE TABLE IF NOT EXISTS form_versions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  form_id TEXT NOT NULL,
+  version INTEGER NOT NULL,      -- 1, 2, 3... per form
+  snapshot TEXT NOT NULL,        -- Full form (fields and numbers) as JSON
+  note TEXT,                     -- e.g. 'created', 'rollback to v3'
+  actor TEXT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE(form_id, version)
+);
+
+-- Audit trail of configuration changes made through ewctl
+CREATE TABLE IF NOT EXISTS audit_log (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  actor TEXT NOT NULL,           -- Config `actor` or the OS user
+  profile TEXT,                  -- Active config profile
+  action TEXT NOT NULL,          -- create, update, delete, import, archive, pause, ...
+  entity_type TEXT NOT NULL,     -- form or contact
+  entity_id TEXT NOT NULL,
+  before_json TEXT,              -- State before the change (NULL on create)
+  after_json TEXT,               -- State after the change (NULL on delete)
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Create indexes for better query performance
 CREATE INDEX IF NOT EXISTS idx_form_fields_form_id ON form_fields(form_id);
 CREATE INDEX IF NOT EXISTS idx_form_numbers_form_id ON form_numbers(form_id);
 CREATE INDEX IF NOT EXISTS idx_form_numbers_contact_id ON form_numbers(contact_id);
 CREATE INDEX IF NOT EXISTS idx_contacts_phone ON contacts(phone_number);
+CREATE INDEX IF NOT EXISTS idx_buffered_submissions_pending ON buffered_submissions(form_id, delivered_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_logs_status ON webhook_logs(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_outbound_jobs_due ON outbound_jobs(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_submissions_form ON submissions(form_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_submissions_lead ON submissions(form_id, lead_status);
+CREATE INDEX IF NOT EXISTS idx_lead_status_history ON lead_status_history(submission_id);
+CREATE INDEX IF NOT EXISTS idx_lead_notes ON lead_notes(submission_id);
 
 -- Insert a default form (the current hardcoded configuration)
 INSERT INTO forms (id, name, description) 
@@ -137,6 +137,26 @@ export default {
         // Get form configuration from database
         const formConfig = await getFormConfiguration(env, formId);
         
+        if (formConfig && formConfig.archived_at) {
+          console.log(JSON.stringify({
+            type: 'form_archived',
+            timestamp: new Date().toISOString(),
+            formId
+          }));
+          
+          return new Response(JSON.stringify({
+            success: false,
+            error: 'Form archived',
+            formId
+          }), {
+            status: 410,
+            headers: { 
+              'Content-Type': 'application/json',
+              'Access-Control-Allow-Origin': '*'
+            }
+          });
+        }
+        
         if (!formConfig) {
           console.log(JSON.stringify({
             type: 'form_not_found',
@@ -206,78 +226,51 @@ export default {
         
         // Get numbers from form configuration
         const numbers = formConfig.numbers.map(n => n.phone_number);
+        const paused = isFormPaused(formConfig);
         
-        // Send messages
-        const zapiUrl = `https://api.z-api.io/instances/${env.ZAPI_INSTANCE_ID}/token/${env.ZAPI_INSTANCE_TOKEN}/send-text`;
+        // Store the submission so the lead survives a lost WhatsApp message
+        const submissionId = await recordSubmission(env, request, formId, extractedFields, rawBody, paused ? 'buffered' : 'pending');
         
-        const results = await Promise.all(
-          numbers.map(async (phone) => {
-            const sendStart = Date.now();
-            try {
-              const response = await fetch(zapiUrl, {
-                method: 'POST',
-                headers: {
-                  'Content-Type': 'application/json',
-                  'Client-Token': env.ZAPI_CLIENT_TOKEN
-                },
-                body: JSON.stringify({ phone, message })
-              });
-              
-              const responseText = await response.text();
-              let result;
-              try {
-                result = JSON.parse(responseText);
-              } catch (e) {
-                result = { raw: responseText };
-              }
-              
-              const sendDuration = Date.now() - sendStart;
-              
-              console.log(JSON.stringify({
-                type: 'whatsapp_send_result',
-                timestamp: new Date().toISOString(),
-                formId,
-                phone,
-                success: response.ok,
-                statusCode: response.status,
-                duration: sendDuration,
-                response: result
-              }));
-              
-              return { 
-                phone, 
-                success: response.ok, 
-                statusCode: response.status,
-                duration: sendDuration,
-                result 
-              };
-            } catch (error) {
-              const sendDuration = Date.now() - sendStart;
-              console.error(JSON.stringify({
-                type: 'whatsapp_send_error',
-                timestamp: new Date().toISOString(),
-                formId,
-                phone,
-                error: error.message,
-                stack: error.stack,
-                duration: sendDuration
-              }));
-              
-              return { 
-                phone, 
-                success: false, 
-                error: error.message,
-                errorType: error.name,
-                duration: sendDuration
-              };
+        // Paused forms accept the submission but hold it until resumed
+        if (paused) {
+          await env.DB.prepare(
+            'INSERT INTO buffered_submissions (form_id, message, payload, submission_id) VALUES (?, ?, ?, ?)'
+          ).bind(formId, message, JSON.stringify(extractedFields), submissionId).run();
+          
+          console.log(JSON.stringify({
+            type: 'submission_buffered',
+            timestamp: new Date().toISOString(),
+            formId,
+            pausedUntil: formConfig.paused_until || null
+          }));
+          
+          return new Response(JSON.stringify({
+            success: true,
+            buffered: true,
+            form: formConfig.name,
+            message: 'Formulário pausado: envio adiado até a retomada'
+          }), {
+            status: 202,
+            headers: { 
+              'Content-Type': 'application/json',
+              'Access-Control-Allow-Origin': '*'
             }
-          })
-        );
+          });
+        }
+        
+        // Deliver anything buffered while the form was paused
+        ctx.waitUntil(flushBufferedSubmissions(env, formId));
+        
+        // Send messages
+        const results = await sendWhatsAppMessages(env, formId, numbers, message);
         
         const successful = results.filter(r => r.success).length;
         const failed = results.filter(r => !r.success).length;
         const totalDuration = Date.now() - startTime;
         
+        // Keep a record so failed recipients can be replayed from ewctl
+        ctx.waitUntil(logDelivery(env, formId, message, extractedFields, results, totalDuration, submissionId));
+        
         const responseLog = {
           type: 'webhook_completed',
           timestamp: new Date().toISOString(),
@@ -395,6 +388,9 @@ export default {
 
   // Scheduled handler for cron monitoring
   async scheduled(event, env, ctx) {
+    // Deliver submissions of forms whose pause ended or were resumed
+    ctx.waitUntil(flushBufferedSubmissions(env));
+    
     if (env.MONITORING_ENABLED !== 'true') {
       console.log('Monitoring is disabled');
       return;
@@ -566,9 +562,14 @@ async function getFormConfiguration(env, formId) {
       'SELECT * FROM form_fields WHERE form_id = ? ORDER BY field_order'
     ).bind(formId).all();
     
-    // Get numbers
+    // Get numbers, skipping recipients whose contact is archived or gone.
+    // Only numbers never linked to a contact are sent without one.
     const { results: numbers } = await env.DB.prepare(
-      'SELECT * FROM form_numbers WHERE form_id = ? ORDER BY id'
+      `SELECT fn.* FROM form_numbers fn
+       LEFT JOIN contacts c ON c.id = fn.contact_id
+       WHERE fn.form_id = ?
+         AND (fn.contact_id IS NULL OR (c.id IS NOT NULL AND c.archived_at IS NULL))
+       ORDER BY fn.id`
     ).bind(formId).all();
     
     return {
@@ -594,6 +595,262 @@ async function getFormConfiguration(env, formId) {
   }
 }
 
+async function sendWhatsAppMessages(env, formId, numbers, message) {
+  const zapiUrl = `https://api.z-api.io/instances/${env.ZAPI_INSTANCE_ID}/token/${env.ZAPI_INSTANCE_TOKEN}/send-text`;
+  
+  return Promise.all(
+    numbers.map(async (phone) => {
+      const sendStart = Date.now();
+      try {
+        const response = await fetch(zapiUrl, {
+          method: 'POST',
+          headers: {
+            'Content-Type': 'application/json',
+            'Client-Token': env.ZAPI_CLIENT_TOKEN
+          },
+          body: JSON.stringify({ phone, message })
+        });
+        
+        const responseText = await response.text();
+        let result;
+        try {
+          result = JSON.parse(responseText);
+        } catch (e) {
+          result = { raw: responseText };
+        }
+        
+        const sendDuration = Date.now() - sendStart;
+        
+        console.log(JSON.stringify({
+          type: 'whatsapp_send_result',
+          timestamp: new Date().toISOString(),
+          formId,
+          phone,
+          success: response.ok,
+          statusCode: response.status,
+          duration: sendDuration,
+          response: result
+        }));
+        
+        return { 
+          phone, 
+          success: response.ok, 
+          statusCode: response.status,
+          duration: sendDuration,
+          result 
+        };
+      } catch (error) {
+        const sendDuration = Date.now() - sendStart;
+        console.error(JSON.stringify({
+          type: 'whatsapp_send_error',
+          timestamp: new Date().toISOString(),
+          formId,
+          phone,
+          error: error.message,
+          stack: error.stack,
+          duration: sendDuration
+        }));
+        
+        return { 
+          phone, 
+          success: false, 
+          error: error.message,
+          errorType: error.name,
+          duration: sendDuration
+        };
+      }
+    })
+  );
+}
+
+// Records a delivery in webhook_logs and each recipient's outcome in
+// delivery_recipients, which `ewctl deliveries retry` uses to resend only
+// to recipients that did not get the message. Returns whether the delivery
+// and the retry jobs for failed recipients were stored.
+async function logDelivery(env, formId, message, fields, results, duration, submissionId) {
+  try {
+    const failed = results.filter(r => !r.success).length;
+    let status = 'partial';
+    if (results.length === 0 || failed === results.length) {
+      status = 'failed';
+    } else if (failed === 0) {
+      status = 'success';
+    }
+    
+    const log = await env.DB.prepare(
+      'INSERT INTO webhook_logs (form_id, status, request, response, duration_ms) VALUES (?, ?, ?, ?, ?)'
+    ).bind(formId, status, JSON.stringify({ message, fields }), JSON.stringify(results), duration).run();
+    
+    const logId = log.meta.last_row_id;
+    const statements = results.map(r => env.DB.prepare(
+      'INSERT INTO delivery_recipients (log_id, phone, status, attempts, last_error) VALUES (?, ?, ?, 1, ?)'
+    ).bind(logId, r.phone, r.success ? 'sent' : 'failed', r.success ? null : (r.error || `HTTP ${r.statusCode}`)));
+    
+    // Queue failed recipients so ewctl retries them with backoff
+    for (const r of results.filter(r => !r.success)) {
+      statements.push(env.DB.prepare(
+        'INSERT INTO outbound_jobs (form_id, log_id, phone, message, attempts, last_error) VALUES (?, ?, ?, ?, 1, ?)'
+      ).bind(formId, logId, r.phone, message, r.error || `HTTP ${r.statusCode}`));
+    }
+    if (submissionId) {
+      statements.push(env.DB.prepare(
+        'UPDATE submissions SET delivery_status = ?, log_id = ? WHERE id = ?'
+      ).bind(status, logId, submissionId));
+    }
+    if (statements.length > 0) {
+      await env.DB.batch(statements);
+    }
+    return true;
+  } catch (error) {
+    console.error(JSON.stringify({
+      type: 'delivery_log_error',
+      timestamp: new Date().toISOString(),
+      formId,
+      error: error.message
+    }));
+    return false;
+  }
+}
+
+// Stores a submission with its source metadata. Returns the new row ID, or
+// null when it could not be stored; delivery goes ahead either way.
+async function recordSubmission(env, request, formId, fields, rawBody, status) {
+  try {
+    const cf = request.cf || {};
+    const result = await env.DB.prepare(
+      `INSERT INTO submissions (form_id, fields, payload, country, city, user_agent, ip, referer, delivery_status)
+       VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+    ).bind(
+      formId,
+      JSON.stringify(fields),
+      rawBody,
+      cf.country || null,
+      cf.city || null,
+      request.headers.get('User-Agent'),
+      request.headers.get('CF-Connecting-IP'),
+      request.headers.get('Referer'),
+      status
+    ).run();
+    return result.meta.last_row_id;
+  } catch (error) {
+    console.error(JSON.stringify({
+      type: 'submission_store_error',
+      timestamp: new Date().toISOString(),
+      formId,
+      error: error.message
+    }));
+    return null;
+  }
+}
+
+// A form is paused while disabled, unless its pause window has ended
+function isFormPaused(form) {
+  if (form.enabled === undefined || form.enabled === null || Number(form.enabled) !== 0) {
+    return false;
+  }
+  if (!form.paused_until) {
+    return true;
+  }
+  return parseD1Timestamp(form.paused_until) > new Date();
+}
+
+// D1 stores CURRENT_TIMESTAMP as "YYYY-MM-DD HH:MM:SS" in UTC
+function parseD1Timestamp(value) {
+  return new Date(value.includes('T') ? value : value.replace(' ', 'T') + 'Z');
+}
+
+// Sends buffered submissions of forms that are no longer paused. Pass a
+// formId to flush a single form. Each submission is claimed before it is
+// sent, so a flush started by a resume webhook and the scheduled flush
+// never both deliver it; claims older than ten minutes are abandoned.
+async function flushBufferedSubmissions(env, formId) {
+  try {
+    let query = `SELECT b.* FROM buffered_submissions b
+      JOIN forms f ON f.id = b.form_id
+      WHERE b.delivered_at IS NULL
+        AND (b.claimed_at IS NULL OR b.claimed_at < datetime('now', '-10 minutes'))
+        AND f.archived_at IS NULL
+        AND NOT (COALESCE(f.enabled, 1) = 0 AND (f.paused_until IS NULL OR f.paused_until > CURRENT_TIMESTAMP))`;
+    const params = [];
+    if (formId) {
+      query += ' AND b.form_id = ?';
+      params.push(formId);
+    }
+    query += ' ORDER BY b.id LIMIT 50';
+    
+    const { results: pending } = await env.DB.prepare(query).bind(...params).all();
+    if (!pending || pending.length === 0) {
+      return 0;
+    }
+    
+    const configs = {};
+    let delivered = 0;
+    for (const submission of pending) {
+      const claim = await env.DB.prepare(
+        `UPDATE buffered_submissions SET claimed_at = CURRENT_TIMESTAMP
+         WHERE id = ? AND delivered_at IS NULL
+           AND (claimed_at IS NULL OR claimed_at < datetime('now', '-10 minutes'))`
+      ).bind(submission.id).run();
+      if (!claim.meta || claim.meta.changes !== 1) {
+        // Another flush is sending it
+        continue;
+      }
+      const release = () => env.DB.prepare(
+        'UPDATE buffered_submissions SET claimed_at = NULL WHERE id = ?'
+      ).bind(submission.id).run();
+      
+      if (!(submission.form_id in configs)) {
+        configs[submission.form_id] = await getFormConfiguration(env, submission.form_id);
+      }
+      const formConfig = configs[submission.form_id];
+      if (!formConfig || formConfig.archived_at || isFormPaused(formConfig)) {
+        await release();
+        continue;
+      }
+      
+      const numbers = formConfig.numbers.map(n => n.phone_number);
+      const sendStart = Date.now();
+      const results = await sendWhatsAppMessages(env, submission.form_id, numbers, submission.message);
+      let fields = {};
+      try {
+        fields = JSON.parse(submission.payload || '{}');
+      } catch (e) {
+        // Keep the delivery record even if the payload is unreadable
+      }
+      const logged = await logDelivery(env, submission.form_id, submission.message, fields, results, Date.now() - sendStart, submission.submission_id);
+      if (!logged && results.some(r => !r.success)) {
+        // Nothing queued the failed recipients; leave it buffered so the
+        // next run retries
+        await release();
+        continue;
+      }
+      
+      await env.DB.prepare(
+        'UPDATE buffered_submissions SET delivered_at = CURRENT_TIMESTAMP WHERE id = ?'
+      ).bind(submission.id).run();
+      delivered++;
+    }
+    
+    console.log(JSON.stringify({
+      type: 'buffered_flush',
+      timestamp: new Date().toISOString(),
+      formId: formId || null,
+      pending: pending.length,
+      delivered
+    }));
+    
+    return delivered;
+  } catch (error) {
+    console.error(JSON.stringify({
+      type: 'buffered_flush_error',
+      timestamp: new Date().toISOString(),
+      formId: formId || null,
+      error: error.message
+    }));
+    return 0;
+  }
+}
+
 function getDefaultConfiguration(env) {
   return {
     id: 'default',
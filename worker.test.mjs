@@ -0,0 +1,197 @@
+// Tests for the worker, run with `node --test` (see `make worker-test`).
+// D1 is faked with a local SQLite database through the sqlite3 command.
+import { test } from 'node:test';
+import assert from 'node:assert/strict';
+import { spawnSync } from 'node:child_process';
+import { mkdtempSync, readFileSync } from 'node:fs';
+import { tmpdir } from 'node:os';
+import { join } from 'node:path';
+
+const hasSqlite = spawnSync('sqlite3', ['-version']).status === 0;
+
+// Load the worker with its helpers exported
+const source = readFileSync(new URL('./worker.js', import.meta.url), 'utf8') +
+  '\nexport { flushBufferedSubmissions, getFormConfiguration };\n';
+const worker = await import('data:text/javascript;base64,' + Buffer.from(source).toString('base64'));
+
+// A minimal D1 binding: prepare/bind/all/run/first and batch
+class FakeD1 {
+  constructor() {
+    this.path = join(mkdtempSync(join(tmpdir(), 'd1-')), 'd1.sqlite');
+    this.exec(readFileSync(new URL('./schema.sql', import.meta.url), 'utf8'));
+  }
+
+  exec(script) {
+    const out = spawnSync('sqlite3', ['-bail', '-json', this.path], {
+      input: 'PRAGMA foreign_keys = ON;\n' + script,
+      encoding: 'utf8'
+    });
+    if (out.status !== 0) {
+      throw new Error(out.stderr.trim());
+    }
+    return splitArrays(out.stdout);
+  }
+
+  query(sql, params) {
+    const sets = this.exec(`${bind(sql, params)};\nSELECT changes() AS changes, last_insert_rowid() AS last_row_id;\n`);
+    const meta = sets.pop()[0];
+    return { results: sets[0] || [], success: true, meta };
+  }
+
+  prepare(sql) {
+    const db = this;
+    return {
+      params: [],
+      bind(...params) {
+        this.params = params;
+        return this;
+      },
+      async all() {
+        return db.query(sql, this.params);
+      },
+      async run() {
+        return db.query(sql, this.params);
+      },
+      async first() {
+        return db.query(sql, this.params).results[0] || null;
+      }
+    };
+  }
+
+  async batch(statements) {
+    const results = [];
+    for (const statement of statements) {
+      results.push(await statement.run());
+    }
+    return results;
+  }
+
+  rows(sql) {
+    return this.query(sql, []).results;
+  }
+}
+
+// bind replaces each ? outside a string literal with its parameter
+function bind(sql, params) {
+  let out = '';
+  let quote = null;
+  let next = 0;
+  for (const ch of sql) {
+    if (quote) {
+      if (ch === quote) quote = null;
+    } else if (ch === "'" || ch === '"') {
+      quote = ch;
+    } else if (ch === '?') {
+      out += literal(params[next++]);
+      continue;
+    }
+    out += ch;
+  }
+  return out;
+}
+
+function literal(value) {
+  if (value === null || value === undefined) return 'NULL';
+  if (typeof value === 'boolean') return value ? '1' : '0';
+  if (typeof value === 'number') return String(value);
+  return `'${String(value).replaceAll("'", "''")}'`;
+}
+
+// sqlite3 -json prints one array per statement that returns rows
+function splitArrays(text) {
+  const sets = [];
+  let depth = 0;
+  let start = 0;
+  let inString = false;
+  for (let i = 0; i < text.length; i++) {
+    const ch = text[i];
+    if (inString) {
+      if (ch === '\\') i++;
+      else if (ch === '"') inString = false;
+    } else if (ch === '"') {
+      inString = true;
+    } else if (ch === '[') {
+      if (depth++ === 0) start = i;
+    } else if (ch === ']' && --depth === 0) {
+      sets.push(JSON.parse(text.slice(start, i + 1)));
+    }
+  }
+  return sets;
+}
+
+// newEnv returns a worker environment whose Z-API sends are recorded
+function newEnv(t) {
+  const sent = [];
+  t.mock.method(globalThis, 'fetch', async (url, init) => {
+    sent.push(JSON.parse(init.body));
+    return new Response('{}', { status: 200 });
+  });
+  t.mock.method(console, 'log', () => {});
+  const env = { DB: new FakeD1(), ZAPI_INSTANCE_ID: 'i', ZAPI_INSTANCE_TOKEN: 't', ZAPI_CLIENT_TOKEN: 'c' };
+  env.DB.exec(`
+    INSERT INTO forms (id, name) VALUES ('contact', 'Contact');
+    INSERT INTO form_numbers (form_id, phone_number) VALUES ('contact', '5511999990001');
+  `);
+  return { env, sent };
+}
+
+function buffer(env, messages, claimedAt = null) {
+  for (const message of messages) {
+    env.DB.query('INSERT INTO buffered_submissions (form_id, message, claimed_at) VALUES (?, ?, ?)',
+      ['contact', message, claimedAt]);
+  }
+}
+
+test('concurrent flushes deliver each buffered submission once', { skip: !hasSqlite }, async (t) => {
+  const { env, sent } = newEnv(t);
+  buffer(env, ['a', 'b', 'c']);
+
+  const delivered = await Promise.all([
+    worker.flushBufferedSubmissions(env, 'contact'),
+    worker.flushBufferedSubmissions(env)
+  ]);
+
+  assert.equal(delivered[0] + delivered[1], 3);
+  assert.deepEqual(sent.map(s => s.message).sort(), ['a', 'b', 'c']);
+  assert.equal(env.DB.rows('SELECT * FROM buffered_submissions WHERE delivered_at IS NULL').length, 0);
+  assert.equal(env.DB.rows('SELECT * FROM webhook_logs').length, 3);
+});
+
+test('paused forms keep their submissions buffered and unclaimed', { skip: !hasSqlite }, async (t) => {
+  const { env, sent } = newEnv(t);
+  buffer(env, ['a', 'b']);
+  env.DB.exec("UPDATE forms SET enabled = 0, paused_until = datetime('now', '+1 hour');");
+
+  assert.equal(await worker.flushBufferedSubmissions(env), 0);
+  assert.equal(sent.length, 0);
+  assert.equal(env.DB.rows('SELECT * FROM buffered_submissions WHERE claimed_at IS NOT NULL').length, 0);
+
+  // Once the window ends the next run delivers them
+  env.DB.exec("UPDATE forms SET paused_until = datetime('now', '-1 minute');");
+  assert.equal(await worker.flushBufferedSubmissions(env), 2);
+});
+
+test('claims held by another run are skipped until they go stale', { skip: !hasSqlite }, async (t) => {
+  const { env, sent } = newEnv(t);
+  buffer(env, ['claimed'], new Date().toISOString().slice(0, 19).replace('T', ' '));
+  buffer(env, ['abandoned'], '2000-01-01 00:00:00');
+
+  assert.equal(await worker.flushBufferedSubmissions(env), 1);
+  assert.deepEqual(sent.map(s => s.message), ['abandoned']);
+  assert.equal(env.DB.rows('SELECT * FROM buffered_submissions WHERE delivered_at IS NULL').length, 1);
+});
+
+test('recipients linked to archived or missing contacts are not messaged', { skip: !hasSqlite }, async (t) => {
+  const { env } = newEnv(t);
+  env.DB.exec(`
+    INSERT INTO contacts (id, phone_number, name) VALUES (1, '5511999990002', 'Active');
+    INSERT INTO contacts (id, phone_number, name, archived_at) VALUES (2, '5511999990003', 'Archived', CURRENT_TIMESTAMP);
+    INSERT INTO form_numbers (form_id, phone_number, contact_id) VALUES ('contact', '5511999990002', 1);
+    INSERT INTO form_numbers (form_id, phone_number, contact_id) VALUES ('contact', '5511999990003', 2);
+    PRAGMA foreign_keys = OFF;
+    INSERT INTO form_numbers (form_id, phone_number, contact_id) VALUES ('contact', '5511999990004', 99);
+  `);
+
+  const config = await worker.getFormConfiguration(env, 'contact');
+  assert.deepEqual(config.numbers.map(n => n.phone_number), ['5511999990001', '5511999990002']);
+});